@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/urfave/cli/v3"
 
@@ -40,6 +41,9 @@ type config struct {
 	paths         []string
 	modeStr       string
 	mode          fs.FileMode
+	groupStr      string
+	gid           int
+	containerRoot string
 	containerSpec string
 }
 
@@ -76,6 +80,16 @@ func (m command) build() *cli.Command {
 				Usage:       "Specify the file mode",
 				Destination: &cfg.modeStr,
 			},
+			&cli.StringFlag{
+				Name:        "group",
+				Usage:       "Specify the numeric group ID to assign to the paths. If this is not specified, the group is left unchanged.",
+				Destination: &cfg.groupStr,
+			},
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root filesystem of the container. If this is not specified, the root is determined from the bundle in the OCI container state that is read from STDIN.",
+				Destination: &cfg.containerRoot,
+			},
 			&cli.StringFlag{
 				Name:        "container-spec",
 				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
@@ -98,6 +112,15 @@ func (m command) validateFlags(_ *cli.Command, cfg *config) error {
 	}
 	cfg.mode = fs.FileMode(modeInt)
 
+	cfg.gid = -1
+	if cfg.groupStr != "" {
+		gid, err := strconv.ParseUint(cfg.groupStr, 10, 31)
+		if err != nil {
+			return fmt.Errorf("failed to parse group as a numeric ID: %v", err)
+		}
+		cfg.gid = int(gid)
+	}
+
 	for _, p := range cfg.paths {
 		if strings.TrimSpace(p) == "" {
 			return fmt.Errorf("paths must not be empty")
@@ -108,20 +131,15 @@ func (m command) validateFlags(_ *cli.Command, cfg *config) error {
 }
 
 func (m command) run(_ *cli.Command, cfg *config) error {
-	s, err := oci.LoadContainerState(cfg.containerSpec)
-	if err != nil {
-		return fmt.Errorf("failed to load container state: %v", err)
-	}
-
-	containerRoot, err := s.GetContainerRoot()
+	containerRoot, err := oci.ResolveContainerRoot(cfg.containerRoot, cfg.containerSpec)
 	if err != nil {
-		return fmt.Errorf("failed to determined container root: %v", err)
+		return fmt.Errorf("failed to determine container root: %v", err)
 	}
 	if containerRoot == "" {
 		return fmt.Errorf("empty container root detected")
 	}
 
-	paths := m.getPaths(containerRoot, cfg.paths, cfg.mode)
+	paths := m.getPaths(containerRoot, cfg.paths, cfg.mode, cfg.gid)
 	if len(paths) == 0 {
 		m.logger.Debugf("No paths specified; exiting")
 		return nil
@@ -134,13 +152,23 @@ func (m command) run(_ *cli.Command, cfg *config) error {
 			m.logger.Debugf("Ignoring permission error with chmod: %v", err)
 			err = nil
 		}
+		if err != nil || cfg.gid < 0 {
+			continue
+		}
+		err = os.Chown(path, -1, cfg.gid)
+		if errors.Is(err, fs.ErrPermission) {
+			m.logger.Debugf("Ignoring permission error with chown: %v", err)
+			err = nil
+		}
 	}
 
 	return err
 }
 
 // getPaths updates the specified paths relative to the root.
-func (m command) getPaths(root string, paths []string, desiredMode fs.FileMode) []string {
+// Paths that already have the desired mode and group are skipped. A negative
+// desiredGID indicates that the group is not checked.
+func (m command) getPaths(root string, paths []string, desiredMode fs.FileMode, desiredGID int) []string {
 	var pathsInRoot []string
 	for _, f := range paths {
 		path := filepath.Join(root, f)
@@ -149,8 +177,8 @@ func (m command) getPaths(root string, paths []string, desiredMode fs.FileMode)
 			m.logger.Debugf("Skipping path %q: %v", path, err)
 			continue
 		}
-		if (stat.Mode()&(fs.ModePerm|fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky))^desiredMode == 0 {
-			m.logger.Debugf("Skipping path %q: already desired mode", path)
+		if (stat.Mode()&(fs.ModePerm|fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky))^desiredMode == 0 && hasGroup(stat, desiredGID) {
+			m.logger.Debugf("Skipping path %q: already desired mode and group", path)
 			continue
 		}
 		pathsInRoot = append(pathsInRoot, path)
@@ -158,3 +186,16 @@ func (m command) getPaths(root string, paths []string, desiredMode fs.FileMode)
 
 	return pathsInRoot
 }
+
+// hasGroup checks whether the file has the specified group. A negative gid
+// matches any group.
+func hasGroup(info fs.FileInfo, gid int) bool {
+	if gid < 0 {
+		return true
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+	return int(stat.Gid) == gid
+}
@@ -0,0 +1,134 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	argsFromFlagName = "args-from"
+)
+
+// withArgsFrom adds the --args-from flag to the specified hook command.
+// If this flag is specified, additional arguments are read from the specified
+// file and are applied in addition to the arguments specified on the command
+// line. This allows hooks to be invoked with a large number of arguments
+// without hitting argument length limits.
+func withArgsFrom(c *cli.Command) *cli.Command {
+	c.Flags = append(c.Flags,
+		&cli.StringFlag{
+			Name: argsFromFlagName,
+			Usage: "Specify a file to read additional arguments from. " +
+				"Each non-empty line is treated as a single argument and lines starting with '#' are ignored.",
+		},
+	)
+
+	before := c.Before
+	c.Before = func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		if filename := cmd.String(argsFromFlagName); filename != "" {
+			args, err := readArgsFromFile(filename)
+			if err != nil {
+				return ctx, err
+			}
+			if err := applyArgs(cmd, args); err != nil {
+				return ctx, fmt.Errorf("failed to apply arguments from %v: %w", filename, err)
+			}
+		}
+		if before == nil {
+			return ctx, nil
+		}
+		return before(ctx, cmd)
+	}
+
+	return c
+}
+
+// readArgsFromFile reads the arguments from the specified file.
+// Each line is treated as a single argument. Empty lines and lines starting
+// with '#' are ignored.
+func readArgsFromFile(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open arguments file: %w", err)
+	}
+	defer file.Close()
+
+	var args []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args = append(args, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read arguments file: %w", err)
+	}
+	return args, nil
+}
+
+// applyArgs applies the specified arguments to the flags of the command.
+// Flags may be specified as --flag=value or as --flag followed by the value
+// as the next argument. Boolean flags do not require a value.
+func applyArgs(cmd *cli.Command, args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("unexpected argument %q; only flags are supported", arg)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name == argsFromFlagName {
+			return fmt.Errorf("nested --%v is not supported", argsFromFlagName)
+		}
+		if !hasValue {
+			if isBoolFlag(cmd, name) {
+				value = "true"
+			} else {
+				if i+1 >= len(args) {
+					return fmt.Errorf("missing value for flag %q", name)
+				}
+				i++
+				value = args[i]
+			}
+		}
+		if err := cmd.Set(name, value); err != nil {
+			return fmt.Errorf("failed to set flag %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// isBoolFlag checks whether the flag with the specified name is a boolean flag.
+func isBoolFlag(cmd *cli.Command, name string) bool {
+	for _, flag := range cmd.Flags {
+		if !slices.Contains(flag.Names(), name) {
+			continue
+		}
+		_, ok := flag.(*cli.BoolFlag)
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestArgsFrom(t *testing.T) {
+	testCases := []struct {
+		description   string
+		args          []string
+		argsFile      string
+		expectedError bool
+		expectedLinks []string
+		expectedForce bool
+	}{
+		{
+			description:   "no args file",
+			args:          []string{"--link", "a::b"},
+			expectedLinks: []string{"a::b"},
+		},
+		{
+			description: "args are appended",
+			args:        []string{"--link", "a::b"},
+			argsFile: `
+# A comment
+--link
+c::d
+
+--link=e::f
+--force
+`,
+			expectedLinks: []string{"a::b", "c::d", "e::f"},
+			expectedForce: true,
+		},
+		{
+			description:   "positional args are not supported",
+			argsFile:      "c::d",
+			expectedError: true,
+		},
+		{
+			description:   "missing value",
+			argsFile:      "--link",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var links []string
+			var force bool
+			c := withArgsFrom(&cli.Command{
+				Name: "test",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:        "link",
+						Destination: &links,
+					},
+					&cli.BoolFlag{
+						Name:        "force",
+						Destination: &force,
+					},
+				},
+				Action: func(context.Context, *cli.Command) error {
+					return nil
+				},
+			})
+
+			args := append([]string{"test"}, tc.args...)
+			if tc.argsFile != "" {
+				filename := filepath.Join(t.TempDir(), "args")
+				require.NoError(t, os.WriteFile(filename, []byte(tc.argsFile), 0600))
+				args = append(args, "--args-from", filename)
+			}
+
+			err := c.Run(context.Background(), args)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedLinks, links)
+			require.Equal(t, tc.expectedForce, force)
+		})
+	}
+}
+
+func TestArgsFromMissingFile(t *testing.T) {
+	c := withArgsFrom(&cli.Command{
+		Name: "test",
+		Action: func(context.Context, *cli.Command) error {
+			return nil
+		},
+	})
+
+	err := c.Run(context.Background(), []string{"test", "--args-from", filepath.Join(t.TempDir(), "missing")})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -26,8 +26,10 @@ import (
 	symlinks "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/create-symlinks"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/cudacompat"
 	disabledevicenodemodification "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/disable-device-node-modification"
+	setapplicationclocks "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/set-application-clocks"
 	updateapplicationprofile "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/update-application-profile"
 	ldcache "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/update-ldcache"
+	waitfordevicenodes "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/wait-for-device-nodes"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -92,6 +94,8 @@ func ConfigureCDIHookCommand(logger logger.Interface, base *cli.Command) *cli.Co
 		cudacompat.NewCommand(logger),
 		disabledevicenodemodification.NewCommand(logger),
 		updateapplicationprofile.NewCommand(logger),
+		setapplicationclocks.NewCommand(logger),
+		waitfordevicenodes.NewCommand(logger),
 		{
 			Name:   "noop",
 			Usage:  "The noop hook performs no actions and is only added to facilitate basic testing of the CLI",
@@ -102,6 +106,10 @@ func ConfigureCDIHookCommand(logger logger.Interface, base *cli.Command) *cli.Co
 		},
 	}
 
+	for _, c := range base.Commands {
+		withArgsFrom(c)
+	}
+
 	return base
 }
 
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/urfave/cli/v3"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
@@ -31,12 +32,18 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup/symlinks"
 )
 
+var errEscapesRoot = errors.New("path escapes container root")
+
+var errIsDirectory = errors.New("existing path is a directory")
+
 type command struct {
 	logger logger.Interface
 }
 
 type config struct {
 	links         []string
+	skipExisting  bool
+	containerRoot string
 	containerSpec string
 }
 
@@ -61,9 +68,19 @@ func (m command) build() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
 				Name:        "link",
-				Usage:       "Specify a specific link to create. The link is specified as target::link. If the link exists in the container root, it is removed.",
+				Usage:       "Specify a specific link to create. The link is specified as target::link. If the link exists in the container root, it is atomically replaced.",
 				Destination: &cfg.links,
 			},
+			&cli.BoolFlag{
+				Name:        "skip-existing",
+				Usage:       "Leave existing files at a link path unchanged instead of replacing them with the link.",
+				Destination: &cfg.skipExisting,
+			},
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root filesystem of the container. If this is not specified, the root is determined from the bundle in the OCI container state that is read from STDIN.",
+				Destination: &cfg.containerRoot,
+			},
 			// The following flags are testing-only flags.
 			&cli.StringFlag{
 				Name:        "container-spec",
@@ -78,14 +95,9 @@ func (m command) build() *cli.Command {
 }
 
 func (m command) run(_ *cli.Command, cfg *config) error {
-	s, err := oci.LoadContainerState(cfg.containerSpec)
+	containerRoot, err := oci.ResolveContainerRoot(cfg.containerRoot, cfg.containerSpec)
 	if err != nil {
-		return fmt.Errorf("failed to load container state: %v", err)
-	}
-
-	containerRoot, err := s.GetContainerRoot()
-	if err != nil {
-		return fmt.Errorf("failed to determined container root: %v", err)
+		return fmt.Errorf("failed to determine container root: %v", err)
 	}
 
 	created := make(map[string]bool)
@@ -99,7 +111,7 @@ func (m command) run(_ *cli.Command, cfg *config) error {
 			return fmt.Errorf("invalid symlink specification %v", l)
 		}
 
-		err := m.createLink(containerRoot, parts[0], parts[1])
+		err := m.createLink(containerRoot, parts[0], parts[1], cfg)
 		if err != nil {
 			return fmt.Errorf("failed to create link %v: %w", parts, err)
 		}
@@ -116,12 +128,28 @@ func (m command) run(_ *cli.Command, cfg *config) error {
 // If the specified link already exists and points to the same target, this
 // operation is a no-op.
 // If a file exists at the link path or the link points to a different target
-// this file is removed before creating the link.
+// this file is replaced by the link unless skipExisting is set in the specified
+// config.
 //
 // Note that if the link path resolves to an absolute path oudside of the
 // specified root, this is treated as an absolute path in this root.
-func (m command) createLink(containerRootDir string, targetPath string, link string) error {
-	linkPath := filepath.Join(containerRootDir, link)
+//
+// An error is returned if either the link or the target path refers to a
+// location outside of the container root through the use of '..' path
+// elements.
+func (m command) createLink(containerRootDir string, targetPath string, link string, cfg *config) error {
+	if err := validateLink(targetPath, link); err != nil {
+		return err
+	}
+
+	// We resolve the parent of the link in the container root to ensure that
+	// symlinks in the container do not cause paths outside of the container
+	// root to be checked.
+	linkParent, err := securejoin.SecureJoin(containerRootDir, filepath.Dir(link))
+	if err != nil {
+		return fmt.Errorf("failed to resolve parent of link %v in container root: %w", link, err)
+	}
+	linkPath := filepath.Join(linkParent, filepath.Base(link))
 
 	exists, err := linkExists(targetPath, linkPath)
 	if err != nil {
@@ -132,9 +160,88 @@ func (m command) createLink(containerRootDir string, targetPath string, link str
 		return nil
 	}
 
+	replace, err := m.checkReplaceable(linkPath, cfg)
+	if err != nil {
+		return err
+	}
+	if !replace {
+		m.logger.Warningf("Skipping link %v since the path already exists", linkPath)
+		return nil
+	}
+
 	return m.createSymlinkInRoot(containerRootDir, targetPath, link)
 }
 
+// checkReplaceable checks whether the specified link path may be replaced by
+// a link. Missing paths are always replaceable and existing paths are
+// replaceable unless skipExisting is set in the specified config. Since the
+// link is created as a temporary symlink that is renamed into place, a stale
+// symlink or a file is replaced atomically. A directory is never replaceable.
+func (m command) checkReplaceable(linkPath string, cfg *config) (bool, error) {
+	info, err := os.Lstat(linkPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat existing path %v: %w", linkPath, err)
+	}
+	if cfg.skipExisting {
+		return false, nil
+	}
+
+	switch mode := info.Mode(); {
+	case mode&os.ModeSymlink != 0:
+		currentTarget, err := symlinks.Resolve(linkPath)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve existing link %v: %w", linkPath, err)
+		}
+		m.logger.Infof("Replacing stale link %v pointing to %v", linkPath, currentTarget)
+		return true, nil
+	case mode.IsDir():
+		return false, fmt.Errorf("%v: %w", linkPath, errIsDirectory)
+	default:
+		m.logger.Infof("Replacing existing file %v with a link", linkPath)
+		return true, nil
+	}
+}
+
+// validateLink checks that neither the link nor the target of the link
+// escapes the container root. Relative targets are interpreted relative to the
+// directory containing the link.
+func validateLink(target string, link string) error {
+	if escapesRoot(link) {
+		return fmt.Errorf("link %v: %w", link, errEscapesRoot)
+	}
+	resolvedTarget := target
+	if !filepath.IsAbs(target) {
+		resolvedTarget = filepath.Dir(link) + string(filepath.Separator) + target
+	}
+	if escapesRoot(resolvedTarget) {
+		return fmt.Errorf("target %v of link %v: %w", target, link, errEscapesRoot)
+	}
+	return nil
+}
+
+// escapesRoot checks whether the specified path refers to a location above the
+// root it is interpreted relative to. Note that filepath.Clean cannot be used
+// for this check since it silently drops '..' elements at the root.
+func escapesRoot(path string) bool {
+	var depth int
+	for _, element := range strings.Split(filepath.ToSlash(path), "/") {
+		switch element {
+		case "", ".":
+		case "..":
+			depth--
+			if depth < 0 {
+				return true
+			}
+		default:
+			depth++
+		}
+	}
+	return false
+}
+
 // linkExists checks whether the specified link exists.
 // A link exists if the path exists, is a symlink, and points to the specified target.
 func linkExists(target string, link string) (bool, error) {
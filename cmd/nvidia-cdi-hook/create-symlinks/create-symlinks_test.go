@@ -1,15 +1,18 @@
 package symlinks
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup/symlinks"
 )
 
@@ -152,7 +155,7 @@ func TestCreateLink(t *testing.T) {
 			require.NoError(t, makeFs(containerRoot, tc.containerContents...))
 
 			// nvidia-cdi-hook create-symlinks --link linkSpec
-			err := getTestCommand().createLink(containerRoot, tc.link.target, tc.link.path)
+			err := getTestCommand().createLink(containerRoot, tc.link.target, tc.link.path, &config{})
 			// TODO: We may be able to replace this with require.ErrorIs.
 			if tc.expectedCreateError != nil {
 				require.Error(t, err)
@@ -185,7 +188,7 @@ func TestCreateLinkRelativePath(t *testing.T) {
 	require.NoError(t, makeFs(containerRoot, dirOrLink{path: "/lib/"}))
 
 	// nvidia-cdi-hook create-symlinks --link libfoo.so.1::/lib/libfoo.so
-	err := getTestCommand().createLink(containerRoot, "libfoo.so.1", "/lib/libfoo.so")
+	err := getTestCommand().createLink(containerRoot, "libfoo.so.1", "/lib/libfoo.so", &config{})
 	require.NoError(t, err)
 
 	target, err := symlinks.Resolve(filepath.Join(containerRoot, "/lib/libfoo.so"))
@@ -202,7 +205,7 @@ func TestCreateLinkAbsolutePath(t *testing.T) {
 	require.NoError(t, makeFs(containerRoot, dirOrLink{path: "/lib/"}))
 
 	// nvidia-cdi-hook create-symlinks --link /lib/libfoo.so.1::/lib/libfoo.so
-	err := getTestCommand().createLink(containerRoot, "/lib/libfoo.so.1", "/lib/libfoo.so")
+	err := getTestCommand().createLink(containerRoot, "/lib/libfoo.so.1", "/lib/libfoo.so", &config{})
 	require.NoError(t, err)
 
 	target, err := symlinks.Resolve(filepath.Join(containerRoot, "/lib/libfoo.so"))
@@ -214,16 +217,27 @@ func TestCreateLinkAlreadyExists(t *testing.T) {
 	testCases := []struct {
 		description       string
 		containerContents []dirOrLink
+		skipExisting      bool
+		expectedTarget    string
 		shouldExist       []string
 	}{
 		{
 			description:       "link already exists with correct target",
 			containerContents: []dirOrLink{{path: "/lib/libfoo.so", target: "libfoo.so.1"}},
+			expectedTarget:    "libfoo.so.1",
 			shouldExist:       []string{},
 		},
 		{
 			description:       "link already exists with different target",
 			containerContents: []dirOrLink{{path: "/lib/libfoo.so", target: "different-target"}, {path: "different-target"}},
+			expectedTarget:    "libfoo.so.1",
+			shouldExist:       []string{"{{ .containerRoot }}/different-target"},
+		},
+		{
+			description:       "link already exists with different target and skip existing",
+			containerContents: []dirOrLink{{path: "/lib/libfoo.so", target: "different-target"}, {path: "different-target"}},
+			skipExisting:      true,
+			expectedTarget:    "different-target",
 			shouldExist:       []string{"{{ .containerRoot }}/different-target"},
 		},
 	}
@@ -236,12 +250,12 @@ func TestCreateLinkAlreadyExists(t *testing.T) {
 			require.NoError(t, makeFs(hostRoot))
 			require.NoError(t, makeFs(containerRoot, tc.containerContents...))
 
-			// nvidia-cdi-hook create-symlinks --link libfoo.so.1::/lib/libfoo.so
-			err := getTestCommand().createLink(containerRoot, "libfoo.so.1", "/lib/libfoo.so")
+			// nvidia-cdi-hook create-symlinks [--skip-existing] --link libfoo.so.1::/lib/libfoo.so
+			err := getTestCommand().createLink(containerRoot, "libfoo.so.1", "/lib/libfoo.so", &config{skipExisting: tc.skipExisting})
 			require.NoError(t, err)
 			target, err := symlinks.Resolve(filepath.Join(containerRoot, "lib/libfoo.so"))
 			require.NoError(t, err)
-			require.Equal(t, "libfoo.so.1", target)
+			require.Equal(t, tc.expectedTarget, target)
 
 			for _, p := range tc.shouldExist {
 				require.DirExists(t, strings.ReplaceAll(p, "{{ .containerRoot }}", containerRoot))
@@ -250,6 +264,171 @@ func TestCreateLinkAlreadyExists(t *testing.T) {
 	}
 }
 
+func TestCreateLinkReplaceExisting(t *testing.T) {
+	testCases := []struct {
+		description       string
+		containerContents []dirOrLink
+		regularFile       string
+		skipExisting      bool
+		expectedError     error
+		expectedTarget    string
+	}{
+		{
+			description:    "missing link is created",
+			expectedTarget: "libfoo.so.1",
+		},
+		{
+			description:       "link with correct target is kept",
+			containerContents: []dirOrLink{{path: "/lib/libfoo.so", target: "libfoo.so.1"}},
+			expectedTarget:    "libfoo.so.1",
+		},
+		{
+			description:       "stale link is replaced",
+			containerContents: []dirOrLink{{path: "/lib/libfoo.so", target: "libfoo.so.0"}},
+			expectedTarget:    "libfoo.so.1",
+		},
+		{
+			description:       "dangling absolute stale link is replaced",
+			containerContents: []dirOrLink{{path: "/lib/libfoo.so", target: "/opt/old/libfoo.so.0"}},
+			expectedTarget:    "libfoo.so.1",
+		},
+		{
+			description:    "regular file is replaced",
+			regularFile:    "/lib/libfoo.so",
+			expectedTarget: "libfoo.so.1",
+		},
+		{
+			description:  "regular file is kept with skip existing",
+			regularFile:  "/lib/libfoo.so",
+			skipExisting: true,
+		},
+		{
+			description:       "directory is not replaced",
+			containerContents: []dirOrLink{{path: "/lib/libfoo.so"}},
+			expectedError:     errIsDirectory,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			containerRoot := filepath.Join(tmpDir, "/container-root")
+			require.NoError(t, makeFs(containerRoot, append([]dirOrLink{{path: "/lib/"}}, tc.containerContents...)...))
+			if tc.regularFile != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(containerRoot, tc.regularFile), []byte("contents"), 0o644))
+			}
+
+			// nvidia-cdi-hook create-symlinks [--skip-existing] --link libfoo.so.1::/lib/libfoo.so
+			err := getTestCommand().createLink(containerRoot, "libfoo.so.1", "/lib/libfoo.so", &config{skipExisting: tc.skipExisting})
+			require.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				return
+			}
+
+			if tc.skipExisting {
+				contents, err := os.ReadFile(filepath.Join(containerRoot, tc.regularFile))
+				require.NoError(t, err)
+				require.Equal(t, "contents", string(contents))
+			} else {
+				target, err := symlinks.Resolve(filepath.Join(containerRoot, "/lib/libfoo.so"))
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedTarget, target)
+			}
+
+			// No temporary links are left behind in the link directory.
+			entries, err := os.ReadDir(filepath.Join(containerRoot, "/lib"))
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+		})
+	}
+}
+
+func TestGeneratedHookReplacesStaleLinks(t *testing.T) {
+	containerRoot := filepath.Join(t.TempDir(), "/container-root")
+	require.NoError(t, makeFs(containerRoot,
+		dirOrLink{path: "/lib/libcuda.so.1", target: "libcuda.so.470.00"},
+		dirOrLink{path: "/lib/libcuda.so", target: "libcuda.so.1"},
+	))
+
+	hook := discover.NewHookCreator().Create(discover.CreateSymlinksHook, "libcuda.so.999.88.77::/lib/libcuda.so.1", "libcuda.so.1::/lib/libcuda.so")
+	require.NotNil(t, hook)
+	require.Equal(t, "create-symlinks", hook.Args[1])
+
+	logger, _ := testlog.NewNullLogger()
+	// The hook is run with the arguments of the generated hook. The container
+	// root is specified explicitly instead of reading the container state.
+	args := append(slices.Clone(hook.Args[1:]), "--container-root", containerRoot)
+	require.NoError(t, NewCommand(logger).Run(context.Background(), args))
+
+	target, err := symlinks.Resolve(filepath.Join(containerRoot, "/lib/libcuda.so.1"))
+	require.NoError(t, err)
+	require.Equal(t, "libcuda.so.999.88.77", target)
+
+	target, err = symlinks.Resolve(filepath.Join(containerRoot, "/lib/libcuda.so"))
+	require.NoError(t, err)
+	require.Equal(t, "libcuda.so.1", target)
+}
+
+func TestCreateLinkEscapesRoot(t *testing.T) {
+	testCases := []struct {
+		description   string
+		target        string
+		link          string
+		expectedError error
+	}{
+		{
+			description:   "relative target escapes root",
+			target:        "../../../../etc/shadow",
+			link:          "/lib/libfoo.so",
+			expectedError: errEscapesRoot,
+		},
+		{
+			description:   "relative target with intermediate elements escapes root",
+			target:        "../foo/../../../etc/shadow",
+			link:          "/usr/lib/libfoo.so",
+			expectedError: errEscapesRoot,
+		},
+		{
+			description:   "absolute target escapes root",
+			target:        "/lib/../../etc/shadow",
+			link:          "/lib/libfoo.so",
+			expectedError: errEscapesRoot,
+		},
+		{
+			description:   "link escapes root",
+			target:        "libfoo.so.1",
+			link:          "/../../etc/cron.d/libfoo.so",
+			expectedError: errEscapesRoot,
+		},
+		{
+			description:   "relative link escapes root",
+			target:        "libfoo.so.1",
+			link:          "lib/../../libfoo.so",
+			expectedError: errEscapesRoot,
+		},
+		{
+			description: "relative target resolves to root",
+			target:      "../../etc/libfoo.so.1",
+			link:        "/usr/lib/libfoo.so",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			containerRoot := filepath.Join(tmpDir, "/container-root")
+			require.NoError(t, makeFs(containerRoot, dirOrLink{path: "/lib/"}))
+
+			err := getTestCommand().createLink(containerRoot, tc.target, tc.link, &config{})
+			require.ErrorIs(t, err, tc.expectedError)
+
+			entries, err := os.ReadDir(tmpDir)
+			require.NoError(t, err)
+			require.Len(t, entries, 1, "unexpected entries created outside of the container root")
+		})
+	}
+}
+
 func TestCreateLinkOutOfBounds(t *testing.T) {
 	// (cdesiniotis) This test case fails on linux and rightfully so.
 	// This works with the non-linux createSymlinkInRoot implementation
@@ -285,7 +464,7 @@ func TestCreateLinkOutOfBounds(t *testing.T) {
 	require.Equal(t, hostRoot, path)
 
 	// nvidia-cdi-hook create-symlinks --link ../libfoo.so.1::/lib/foo/libfoo.so
-	err = getTestCommand().createLink(containerRoot, "../libfoo.so.1", "/lib/foo/libfoo.so")
+	err = getTestCommand().createLink(containerRoot, "../libfoo.so.1", "/lib/foo/libfoo.so", &config{})
 	require.NoError(t, err)
 
 	target, err := symlinks.Resolve(filepath.Join(containerRoot, hostRoot, "libfoo.so"))
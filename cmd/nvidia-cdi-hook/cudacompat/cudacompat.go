@@ -50,6 +50,7 @@ type options struct {
 	hostCudaVersion         string
 	// containerSpec allows the path to the container spec to be specified for
 	// testing.
+	containerRoot string
 	containerSpec string
 }
 
@@ -92,6 +93,11 @@ func (m command) build() *cli.Command {
 				Value:       defaultCudaCompatPath,
 				Destination: &options.cudaCompatContainerRoot,
 			},
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root filesystem of the container. If this is not specified, the root is determined from the bundle in the OCI container state that is read from STDIN.",
+				Destination: &options.containerRoot,
+			},
 			&cli.StringFlag{
 				Name:        "container-spec",
 				Hidden:      true,
@@ -116,14 +122,9 @@ func (m command) run(_ *cli.Command, o *options) error {
 		return nil
 	}
 
-	s, err := oci.LoadContainerState(o.containerSpec)
-	if err != nil {
-		return fmt.Errorf("failed to load container state: %w", err)
-	}
-
-	containerRootDir, err := s.GetContainerRoot()
+	containerRootDir, err := oci.ResolveContainerRoot(o.containerRoot, o.containerSpec)
 	if err != nil {
-		return fmt.Errorf("failed to determined container root: %w", err)
+		return fmt.Errorf("failed to determine container root: %w", err)
 	}
 
 	containerRoot, err := newRoot(containerRootDir)
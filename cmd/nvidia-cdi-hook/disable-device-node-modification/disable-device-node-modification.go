@@ -38,6 +38,7 @@ const (
 )
 
 type options struct {
+	containerRoot string
 	containerSpec string
 }
 
@@ -55,6 +56,11 @@ func NewCommand(logger logger.Interface) *cli.Command {
 			return run(ctx, cmd, &cfg)
 		},
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root filesystem of the container. If this is not specified, the root is determined from the bundle in the OCI container state that is read from STDIN.",
+				Destination: &cfg.containerRoot,
+			},
 			&cli.StringFlag{
 				Name:        "container-spec",
 				Hidden:      true,
@@ -80,14 +86,9 @@ func run(_ context.Context, _ *cli.Command, cfg *options) error {
 		return nil
 	}
 
-	s, err := oci.LoadContainerState(cfg.containerSpec)
-	if err != nil {
-		return fmt.Errorf("failed to load container state: %w", err)
-	}
-
-	containerRootDirPath, err := s.GetContainerRoot()
+	containerRootDirPath, err := oci.ResolveContainerRoot(cfg.containerRoot, cfg.containerSpec)
 	if err != nil {
-		return fmt.Errorf("failed to determined container root: %w", err)
+		return fmt.Errorf("failed to determine container root: %w", err)
 	}
 
 	containerRoot, err := os.OpenRoot(containerRootDirPath)
@@ -0,0 +1,107 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package setapplicationclocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type options struct {
+	deviceUUIDs   []string
+	memoryClock   uint32
+	graphicsClock uint32
+}
+
+// NewCommand constructs a set-application-clocks subcommand with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	cfg := options{}
+
+	c := cli.Command{
+		Name: "set-application-clocks",
+		Usage: "Set the application clocks of the specified devices. " +
+			"Failures to set the clocks, for example due to insufficient permissions, are logged as warnings.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, validateFlags(cmd, &cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return run(logger, nvml.New(), &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "device-uuid",
+				Usage:       "Specify the UUID of a device for which the application clocks should be set. This can be specified multiple times.",
+				Destination: &cfg.deviceUUIDs,
+			},
+			&cli.Uint32Flag{
+				Name:        "memory-clock",
+				Usage:       "Specify the memory application clock in MHz",
+				Destination: &cfg.memoryClock,
+			},
+			&cli.Uint32Flag{
+				Name:        "graphics-clock",
+				Usage:       "Specify the graphics application clock in MHz",
+				Destination: &cfg.graphicsClock,
+			},
+		},
+	}
+
+	return &c
+}
+
+func validateFlags(_ *cli.Command, cfg *options) error {
+	if cfg.memoryClock == 0 || cfg.graphicsClock == 0 {
+		return fmt.Errorf("both the memory and graphics clocks must be specified")
+	}
+	return nil
+}
+
+// run sets the application clocks for the requested devices. Since pinning
+// the clocks is an optimization, errors are logged as warnings instead of
+// preventing the container from starting.
+func run(logger logger.Interface, nvmllib nvml.Interface, cfg *options) error {
+	if ret := nvmllib.Init(); ret != nvml.SUCCESS {
+		logger.Warningf("Not setting application clocks: failed to initialize NVML: %v", ret)
+		return nil
+	}
+	defer func() {
+		_ = nvmllib.Shutdown()
+	}()
+
+	for _, uuid := range cfg.deviceUUIDs {
+		device, ret := nvmllib.DeviceGetHandleByUUID(uuid)
+		if ret != nvml.SUCCESS {
+			logger.Warningf("Not setting application clocks for device %v: failed to get device handle: %v", uuid, ret)
+			continue
+		}
+		ret = device.SetApplicationsClocks(cfg.memoryClock, cfg.graphicsClock)
+		switch ret {
+		case nvml.SUCCESS:
+			logger.Debugf("Set application clocks for device %v to %v,%v", uuid, cfg.memoryClock, cfg.graphicsClock)
+		case nvml.ERROR_NO_PERMISSION:
+			logger.Warningf("Not setting application clocks for device %v: insufficient permissions", uuid)
+		default:
+			logger.Warningf("Not setting application clocks for device %v: %v", uuid, ret)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package setapplicationclocks
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	type clocks struct {
+		memory   uint32
+		graphics uint32
+	}
+
+	testCases := []struct {
+		description      string
+		initReturn       nvml.Return
+		setClocksReturn  nvml.Return
+		expectedClocks   []clocks
+		expectedWarnings int
+	}{
+		{
+			description:     "clocks are set",
+			initReturn:      nvml.SUCCESS,
+			setClocksReturn: nvml.SUCCESS,
+			expectedClocks:  []clocks{{1215, 1410}, {1215, 1410}},
+		},
+		{
+			description:      "insufficient permissions is a warning",
+			initReturn:       nvml.SUCCESS,
+			setClocksReturn:  nvml.ERROR_NO_PERMISSION,
+			expectedClocks:   []clocks{{1215, 1410}, {1215, 1410}},
+			expectedWarnings: 2,
+		},
+		{
+			description:      "init failure is a warning",
+			initReturn:       nvml.ERROR_LIBRARY_NOT_FOUND,
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+
+			var setClocks []clocks
+			device := &mock.Device{
+				SetApplicationsClocksFunc: func(memory uint32, graphics uint32) nvml.Return {
+					setClocks = append(setClocks, clocks{memory, graphics})
+					return tc.setClocksReturn
+				},
+			}
+			nvmllib := &mock.Interface{
+				InitFunc: func() nvml.Return {
+					return tc.initReturn
+				},
+				ShutdownFunc: func() nvml.Return {
+					return nvml.SUCCESS
+				},
+				DeviceGetHandleByUUIDFunc: func(uuid string) (nvml.Device, nvml.Return) {
+					return device, nvml.SUCCESS
+				},
+			}
+
+			cfg := &options{
+				deviceUUIDs:   []string{"GPU-0", "GPU-1"},
+				memoryClock:   1215,
+				graphicsClock: 1410,
+			}
+			require.NoError(t, run(logger, nvmllib, cfg))
+			require.Equal(t, tc.expectedClocks, setClocks)
+			require.Len(t, hook.AllEntries(), tc.expectedWarnings)
+		})
+	}
+}
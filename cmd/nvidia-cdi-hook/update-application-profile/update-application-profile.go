@@ -59,6 +59,7 @@ func selectGPUMinors(nodes []deviceNode) []int {
 }
 
 type options struct {
+	containerRoot string
 	containerSpec string
 }
 
@@ -73,6 +74,11 @@ func NewCommand(logger logger.Interface) *cli.Command {
 			return run(ctx, cmd, &cfg, logger)
 		},
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root filesystem of the container. If this is not specified, the root is determined from the bundle in the OCI container state that is read from STDIN.",
+				Destination: &cfg.containerRoot,
+			},
 			&cli.StringFlag{
 				Name:        "container-spec",
 				Hidden:      true,
@@ -86,12 +92,7 @@ func NewCommand(logger logger.Interface) *cli.Command {
 }
 
 func run(_ context.Context, _ *cli.Command, cfg *options, logger logger.Interface) error {
-	s, err := oci.LoadContainerState(cfg.containerSpec)
-	if err != nil {
-		return fmt.Errorf("failed to load container state: %w", err)
-	}
-
-	containerRootDirPath, err := s.GetContainerRoot()
+	containerRootDirPath, err := oci.ResolveContainerRoot(cfg.containerRoot, cfg.containerSpec)
 	if err != nil {
 		return fmt.Errorf("failed to determine container root: %w", err)
 	}
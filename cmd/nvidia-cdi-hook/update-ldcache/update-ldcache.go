@@ -42,6 +42,7 @@ type command struct {
 type options struct {
 	folders       []string
 	ldconfigPath  string
+	containerRoot string
 	containerSpec string
 }
 
@@ -86,6 +87,11 @@ func (m command) build() *cli.Command {
 				Destination: &cfg.ldconfigPath,
 				Value:       "/sbin/ldconfig",
 			},
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root filesystem of the container. If this is not specified, the root is determined from the bundle in the OCI container state that is read from STDIN.",
+				Destination: &cfg.containerRoot,
+			},
 			&cli.StringFlag{
 				Name:        "container-spec",
 				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
@@ -105,14 +111,9 @@ func (m command) validateFlags(_ *cli.Command, cfg *options) error {
 }
 
 func (m command) run(_ *cli.Command, cfg *options) error {
-	s, err := oci.LoadContainerState(cfg.containerSpec)
-	if err != nil {
-		return fmt.Errorf("failed to load container state: %v", err)
-	}
-
-	containerRootDir, err := s.GetContainerRoot()
+	containerRootDir, err := oci.ResolveContainerRoot(cfg.containerRoot, cfg.containerSpec)
 	if err != nil || containerRootDir == "" || containerRootDir == "/" {
-		return fmt.Errorf("failed to determined container root: %v", err)
+		return fmt.Errorf("failed to determine container root: %v", err)
 	}
 
 	runner, err := ldconfig.NewRunner(
@@ -0,0 +1,175 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package waitfordevicenodes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+const (
+	defaultTimeout  = 10 * time.Second
+	defaultInterval = 100 * time.Millisecond
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type config struct {
+	deviceNodes   []string
+	timeout       time.Duration
+	interval      time.Duration
+	containerRoot string
+	containerSpec string
+}
+
+// NewCommand constructs a wait-for-device-nodes command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build the wait-for-device-nodes command
+func (m command) build() *cli.Command {
+	cfg := config{}
+
+	c := cli.Command{
+		Name: "wait-for-device-nodes",
+		Usage: "Wait for the specified device nodes to exist in the container before allowing it to start. " +
+			"The container root is prefixed to the specified paths.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(cmd, &cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(ctx, &cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "device-node",
+				Usage:       "Specify the path of a device node to wait for. This can be specified multiple times.",
+				Destination: &cfg.deviceNodes,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "Specify the maximum time to wait for the device nodes to exist",
+				Value:       defaultTimeout,
+				Destination: &cfg.timeout,
+			},
+			&cli.DurationFlag{
+				Name:        "interval",
+				Usage:       "Specify the interval at which the device nodes are checked",
+				Value:       defaultInterval,
+				Destination: &cfg.interval,
+			},
+			&cli.StringFlag{
+				Name:        "container-root",
+				Usage:       "Specify the root filesystem of the container. If this is not specified, the root is determined from the bundle in the OCI container state that is read from STDIN.",
+				Destination: &cfg.containerRoot,
+			},
+			&cli.StringFlag{
+				Name:        "container-spec",
+				Hidden:      true,
+				Category:    "testing-only",
+				Usage:       "Specify the path to the OCI container spec. If empty or '-' the spec will be read from STDIN",
+				Destination: &cfg.containerSpec,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(_ *cli.Command, cfg *config) error {
+	for _, p := range cfg.deviceNodes {
+		if strings.TrimSpace(p) == "" {
+			return fmt.Errorf("device nodes must not be empty")
+		}
+	}
+	if cfg.timeout <= 0 {
+		return fmt.Errorf("the timeout must be positive")
+	}
+	if cfg.interval <= 0 {
+		return fmt.Errorf("the interval must be positive")
+	}
+	return nil
+}
+
+func (m command) run(ctx context.Context, cfg *config) error {
+	if len(cfg.deviceNodes) == 0 {
+		m.logger.Debugf("No device nodes specified; exiting")
+		return nil
+	}
+
+	containerRoot, err := oci.ResolveContainerRoot(cfg.containerRoot, cfg.containerSpec)
+	if err != nil {
+		return fmt.Errorf("failed to determine container root: %w", err)
+	}
+	if containerRoot == "" {
+		return fmt.Errorf("empty container root detected")
+	}
+
+	return m.waitForDeviceNodes(ctx, containerRoot, cfg)
+}
+
+// waitForDeviceNodes polls for the configured device nodes under the
+// specified root until they all exist or the timeout expires. If the timeout
+// expires, the error lists the device nodes that are still missing.
+func (m command) waitForDeviceNodes(ctx context.Context, root string, cfg *config) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		missing := getMissing(root, cfg.deviceNodes)
+		if len(missing) == 0 {
+			return nil
+		}
+		m.logger.Debugf("Waiting for device nodes %v", missing)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("device nodes %v did not appear within %v", missing, cfg.timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// getMissing returns the device nodes that do not exist under the specified
+// root.
+func getMissing(root string, deviceNodes []string) []string {
+	var missing []string
+	for _, deviceNode := range deviceNodes {
+		if _, err := os.Stat(filepath.Join(root, deviceNode)); err != nil {
+			missing = append(missing, deviceNode)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,96 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package waitfordevicenodes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForDeviceNodes(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		existing      []string
+		createdLater  []string
+		deviceNodes   []string
+		expectedError string
+	}{
+		{
+			description: "no device nodes returns immediately",
+		},
+		{
+			description: "existing device nodes",
+			existing:    []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+			deviceNodes: []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+		},
+		{
+			description:  "device node created while waiting",
+			existing:     []string{"/dev/nvidia-uvm-tools"},
+			createdLater: []string{"/dev/nvidia-uvm"},
+			deviceNodes:  []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+		},
+		{
+			description:   "missing device nodes are reported",
+			existing:      []string{"/dev/nvidia-uvm-tools"},
+			deviceNodes:   []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+			expectedError: "device nodes [/dev/nvidia-uvm] did not appear within 200ms",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			for _, path := range tc.existing {
+				createFile(t, filepath.Join(root, path))
+			}
+			for _, path := range tc.createdLater {
+				require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0755))
+			}
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				for _, path := range tc.createdLater {
+					_ = os.WriteFile(filepath.Join(root, path), nil, 0600)
+				}
+			}()
+
+			cfg := &config{
+				deviceNodes: tc.deviceNodes,
+				timeout:     200 * time.Millisecond,
+				interval:    5 * time.Millisecond,
+			}
+			err := command{logger: logger}.waitForDeviceNodes(context.Background(), root, cfg)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func createFile(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+}
@@ -323,6 +323,28 @@ func TestGetNvidiaConfig(t *testing.T) {
 				Requirements:       []string{},
 			},
 		},
+		{
+			description: "Modern image, devices 'all', injected using CDI",
+			env: map[string]string{
+				image.EnvVarNvidiaVisibleDevices: "all",
+				image.EnvVarNvidiaCTKCDIInjected: "true",
+			},
+			privileged:     false,
+			expectedConfig: nil,
+		},
+		{
+			description: "Modern image, devices 'all', CDI injected marker false",
+			env: map[string]string{
+				image.EnvVarNvidiaVisibleDevices: "all",
+				image.EnvVarNvidiaCTKCDIInjected: "false",
+			},
+			privileged: false,
+			expectedConfig: &nvidiaConfig{
+				Devices:            []string{"all"},
+				DriverCapabilities: image.DefaultDriverCapabilities.String(),
+				Requirements:       []string{},
+			},
+		},
 		{
 			description: "No cuda envs, devices 'all'",
 			env: map[string]string{
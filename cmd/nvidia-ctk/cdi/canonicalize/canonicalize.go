@@ -0,0 +1,141 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package canonicalize
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	input  string
+	output string
+	format string
+}
+
+// NewCommand constructs a cdi canonicalize command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "canonicalize",
+		Usage: "Rewrite a CDI specification in the canonical form of generated specifications",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "input",
+				Usage:       "Specify the file to read the CDI specification from. If this is '-' the specification is read from STDIN",
+				Value:       "-",
+				Destination: &opts.input,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Specify the file to output the canonical CDI specification to. If this is '' the specification is output to STDOUT",
+				Destination: &opts.output,
+			},
+			&cli.StringFlag{
+				Name: "format",
+				Usage: "Specify the output format [yaml | json]. " +
+					"If this is not specified, the format is inferred from the output file name, with YAML being used for STDOUT.",
+				Destination: &opts.format,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.format == "" {
+		opts.format = spec.FormatYAML
+		if filepath.Ext(opts.output) == ".json" {
+			opts.format = spec.FormatJSON
+		}
+	}
+	switch opts.format {
+	case spec.FormatYAML, spec.FormatJSON:
+	default:
+		return fmt.Errorf("invalid output format: %v", opts.format)
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	contents, err := opts.getContents()
+	if err != nil {
+		return fmt.Errorf("failed to read spec contents: %w", err)
+	}
+
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return fmt.Errorf("failed to parse CDI spec: %w", err)
+	}
+
+	if err := spec.Canonicalize(raw); err != nil {
+		return fmt.Errorf("failed to canonicalize CDI spec: %w", err)
+	}
+
+	s, err := spec.New(
+		spec.WithRawSpec(raw),
+		spec.WithFormat(opts.format),
+		spec.WithNoSimplify(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CDI spec: %w", err)
+	}
+
+	if opts.output == "" {
+		if _, err := s.WriteTo(os.Stdout); err != nil {
+			return fmt.Errorf("failed to write CDI spec to STDOUT: %w", err)
+		}
+		return nil
+	}
+	return s.Save(opts.output)
+}
+
+func (o options) getContents() ([]byte, error) {
+	if o.input == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(o.input)
+}
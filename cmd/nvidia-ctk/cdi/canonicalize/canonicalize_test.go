@@ -0,0 +1,114 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package canonicalize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	input := `cdiVersion: 0.5.0
+kind: example.com/device
+containerEdits:
+  mounts:
+  - hostPath: /usr/lib/b.so
+    containerPath: /usr/lib/b.so
+  - hostPath: /usr/lib/a.so
+    containerPath: /usr/lib/a.so
+  - hostPath: /usr/lib/a.so
+    containerPath: /usr/lib/a.so
+devices:
+- name: "1"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/gpu1
+    - path: /dev/ctl
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/gpu0
+    - path: /dev/ctl
+`
+
+	testCases := []struct {
+		description string
+		output      string
+		expected    string
+	}{
+		{
+			description: "yaml output",
+			output:      "output.yaml",
+			expected: `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/ctl
+            - path: /dev/gpu0
+    - name: "1"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/ctl
+            - path: /dev/gpu1
+containerEdits:
+    mounts:
+        - hostPath: /usr/lib/a.so
+          containerPath: /usr/lib/a.so
+        - hostPath: /usr/lib/b.so
+          containerPath: /usr/lib/b.so
+`,
+		},
+		{
+			description: "json output is inferred from the file name",
+			output:      "output.json",
+			expected: `{"cdiVersion":"0.5.0","kind":"example.com/device","devices":[` +
+				`{"name":"0","containerEdits":{"deviceNodes":[{"path":"/dev/ctl"},{"path":"/dev/gpu0"}]}},` +
+				`{"name":"1","containerEdits":{"deviceNodes":[{"path":"/dev/ctl"},{"path":"/dev/gpu1"}]}}],` +
+				`"containerEdits":{"mounts":[{"hostPath":"/usr/lib/a.so","containerPath":"/usr/lib/a.so"},` +
+				`{"hostPath":"/usr/lib/b.so","containerPath":"/usr/lib/b.so"}]}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			inputFile := filepath.Join(dir, "input.yaml")
+			require.NoError(t, os.WriteFile(inputFile, []byte(input), 0600))
+
+			c := command{logger: logger}
+			opts := &options{
+				input:  inputFile,
+				output: filepath.Join(dir, tc.output),
+			}
+			require.NoError(t, c.validateFlags(opts))
+			require.NoError(t, c.run(opts))
+
+			contents, err := os.ReadFile(opts.output)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(contents))
+		})
+	}
+}
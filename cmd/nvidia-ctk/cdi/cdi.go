@@ -19,9 +19,14 @@ package cdi
 import (
 	"github.com/urfave/cli/v3"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/canonicalize"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/generate"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/inspect"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/list"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/merge"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/prune"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/verify"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -46,9 +51,14 @@ func (m command) build() *cli.Command {
 		Name:  "cdi",
 		Usage: "Provide tools for interacting with Container Device Interface specifications",
 		Commands: []*cli.Command{
+			canonicalize.NewCommand(m.logger),
 			generate.NewCommand(m.logger, m.configFilePath),
+			inspect.NewCommand(m.logger),
 			list.NewCommand(m.logger),
+			merge.NewCommand(m.logger),
+			prune.NewCommand(m.logger),
 			transform.NewCommand(m.logger),
+			verify.NewCommand(m.logger),
 		},
 	}
 
@@ -0,0 +1,86 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// defaultAdditionalMountOptions are the options applied to an additional
+// mount if no options are specified. These match the options used for
+// discovered driver files.
+var defaultAdditionalMountOptions = []string{
+	"ro",
+	"nosuid",
+	"nodev",
+	"rbind",
+	"rprivate",
+}
+
+// getAdditionalMounts returns a discoverer for the additional mounts that
+// were requested.
+func (o *options) getAdditionalMounts() (discover.Discover, error) {
+	var mounts []discover.Discover
+	for _, value := range o.additionalMounts {
+		mount, err := parseAdditionalMount(value)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, mount)
+	}
+	return discover.Merge(mounts...), nil
+}
+
+// parseAdditionalMount parses a mount specified as host:container[:options]
+// where options is a comma-separated list of mount options. The host path
+// must exist.
+func parseAdditionalMount(value string) (*discover.Mount, error) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid additional mount %q: expected host:container[:options]", value)
+	}
+	hostPath, containerPath := parts[0], parts[1]
+	if !filepath.IsAbs(hostPath) || !filepath.IsAbs(containerPath) {
+		return nil, fmt.Errorf("invalid additional mount %q: paths must be absolute", value)
+	}
+	if _, err := os.Stat(hostPath); err != nil {
+		return nil, fmt.Errorf("invalid additional mount %q: %w", value, err)
+	}
+
+	options := defaultAdditionalMountOptions
+	if len(parts) == 3 {
+		options = nil
+		for _, option := range strings.Split(parts[2], ",") {
+			if option == "" {
+				return nil, fmt.Errorf("invalid additional mount %q: empty mount option", value)
+			}
+			options = append(options, option)
+		}
+	}
+
+	m := discover.Mount{
+		HostPath: hostPath,
+		Path:     containerPath,
+		Options:  options,
+	}
+	return &m, nil
+}
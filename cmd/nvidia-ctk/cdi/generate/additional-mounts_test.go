@@ -0,0 +1,88 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestParseAdditionalMount(t *testing.T) {
+	hostPath := t.TempDir()
+
+	testCases := []struct {
+		description   string
+		value         string
+		expectedMount *discover.Mount
+		expectedError bool
+	}{
+		{
+			description: "default options",
+			value:       hostPath + ":/etc/license",
+			expectedMount: &discover.Mount{
+				HostPath: hostPath,
+				Path:     "/etc/license",
+				Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+			},
+		},
+		{
+			description: "explicit options",
+			value:       hostPath + ":/etc/license:rw,bind",
+			expectedMount: &discover.Mount{
+				HostPath: hostPath,
+				Path:     "/etc/license",
+				Options:  []string{"rw", "bind"},
+			},
+		},
+		{
+			description:   "missing container path",
+			value:         hostPath,
+			expectedError: true,
+		},
+		{
+			description:   "relative container path",
+			value:         hostPath + ":etc/license",
+			expectedError: true,
+		},
+		{
+			description:   "empty option",
+			value:         hostPath + ":/etc/license:ro,,bind",
+			expectedError: true,
+		},
+		{
+			description:   "host path does not exist",
+			value:         filepath.Join(hostPath, "missing") + ":/etc/license",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mount, err := parseAdditionalMount(tc.value)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMount, mount)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxAnnotationKeyNameLength   = 63
+	maxAnnotationKeyPrefixLength = 253
+)
+
+var (
+	annotationKeyNameRegexp   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	annotationKeyPrefixRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// A userAnnotation is an annotation requested on the command line. If a device
+// name is specified, the annotation is added to the named device instead of to
+// the spec.
+type userAnnotation struct {
+	device string
+	key    string
+	value  string
+}
+
+// getAnnotations returns the annotations that were requested.
+func (o *options) getAnnotations() ([]userAnnotation, error) {
+	var annotations []userAnnotation
+	for _, value := range o.annotations {
+		annotation, err := parseAnnotation(value)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, *annotation)
+	}
+	return annotations, nil
+}
+
+// parseAnnotation parses an annotation specified as [device-name:]key=value.
+// Since device names may contain colons (e.g. MIG devices), the device name is
+// separated from the key at the last colon.
+func parseAnnotation(value string) (*userAnnotation, error) {
+	name, annotationValue, found := strings.Cut(value, "=")
+	if !found {
+		return nil, fmt.Errorf("invalid annotation %q: expected [device-name:]key=value", value)
+	}
+
+	var device string
+	key := name
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		device, key = name[:i], name[i+1:]
+		if device == "" {
+			return nil, fmt.Errorf("invalid annotation %q: empty device name", value)
+		}
+	}
+	if err := validateAnnotationKey(key); err != nil {
+		return nil, fmt.Errorf("invalid annotation %q: %w", value, err)
+	}
+
+	a := userAnnotation{
+		device: device,
+		key:    key,
+		value:  annotationValue,
+	}
+	return &a, nil
+}
+
+// validateAnnotationKey checks that the specified key is a valid CDI annotation
+// key. These follow the Kubernetes annotation key format consisting of an
+// optional DNS subdomain prefix and a name separated by a slash.
+func validateAnnotationKey(key string) error {
+	name := key
+	if prefix, suffix, hasPrefix := strings.Cut(key, "/"); hasPrefix {
+		if len(prefix) > maxAnnotationKeyPrefixLength || !annotationKeyPrefixRegexp.MatchString(prefix) {
+			return fmt.Errorf("key prefix %q must be a DNS subdomain of at most %d characters", prefix, maxAnnotationKeyPrefixLength)
+		}
+		name = suffix
+	}
+	if name == "" || len(name) > maxAnnotationKeyNameLength || !annotationKeyNameRegexp.MatchString(name) {
+		return fmt.Errorf("key name %q must consist of at most %d alphanumeric characters, '-', '_' or '.' and must start and end with an alphanumeric character", name, maxAnnotationKeyNameLength)
+	}
+	return nil
+}
+
+// annotateSpecs adds the requested annotations to the specified specs. The
+// names of devices that were not found in any of the specs are returned.
+func (o *options) annotateSpecs(specs []generatedSpecs) ([]string, error) {
+	annotations, err := o.getAnnotations()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	for _, spec := range specs {
+		raw := spec.Raw()
+		for _, a := range annotations {
+			if a.device == "" {
+				if raw.Annotations == nil {
+					raw.Annotations = make(map[string]string)
+				}
+				raw.Annotations[a.key] = a.value
+				continue
+			}
+			for i := range raw.Devices {
+				device := &raw.Devices[i]
+				if device.Name != a.device {
+					continue
+				}
+				if device.Annotations == nil {
+					device.Annotations = make(map[string]string)
+				}
+				device.Annotations[a.key] = a.value
+				found[a.device] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, a := range annotations {
+		if a.device == "" || found[a.device] {
+			continue
+		}
+		found[a.device] = true
+		missing = append(missing, a.device)
+	}
+	return missing, nil
+}
@@ -0,0 +1,152 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestParseAnnotation(t *testing.T) {
+	testCases := []struct {
+		description        string
+		value              string
+		expectedAnnotation *userAnnotation
+		expectedError      bool
+	}{
+		{
+			description:        "spec annotation",
+			value:              "example.com/cost-center=1234",
+			expectedAnnotation: &userAnnotation{key: "example.com/cost-center", value: "1234"},
+		},
+		{
+			description:        "annotation without prefix",
+			value:              "node-pool=gpu",
+			expectedAnnotation: &userAnnotation{key: "node-pool", value: "gpu"},
+		},
+		{
+			description:        "empty value",
+			value:              "example.com/empty=",
+			expectedAnnotation: &userAnnotation{key: "example.com/empty"},
+		},
+		{
+			description:        "value containing equals",
+			value:              "example.com/selector=a=b",
+			expectedAnnotation: &userAnnotation{key: "example.com/selector", value: "a=b"},
+		},
+		{
+			description:        "device annotation",
+			value:              "gpu0:example.com/rack=r1",
+			expectedAnnotation: &userAnnotation{device: "gpu0", key: "example.com/rack", value: "r1"},
+		},
+		{
+			description:        "MIG device annotation",
+			value:              "0:1:example.com/rack=r1",
+			expectedAnnotation: &userAnnotation{device: "0:1", key: "example.com/rack", value: "r1"},
+		},
+		{
+			description:   "missing value",
+			value:         "example.com/cost-center",
+			expectedError: true,
+		},
+		{
+			description:   "empty device name",
+			value:         ":example.com/rack=r1",
+			expectedError: true,
+		},
+		{
+			description:   "empty key",
+			value:         "=value",
+			expectedError: true,
+		},
+		{
+			description:   "invalid prefix",
+			value:         "Example_com/rack=r1",
+			expectedError: true,
+		},
+		{
+			description:   "empty prefix",
+			value:         "/rack=r1",
+			expectedError: true,
+		},
+		{
+			description:   "invalid name",
+			value:         "example.com/-rack=r1",
+			expectedError: true,
+		},
+		{
+			description:   "name too long",
+			value:         "example.com/" + strings.Repeat("a", 64) + "=r1",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			annotation, err := parseAnnotation(tc.value)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedAnnotation, annotation)
+		})
+	}
+}
+
+func TestAnnotateSpecs(t *testing.T) {
+	s, err := spec.New(
+		spec.WithFormat(spec.FormatJSON),
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: "0",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	opts := options{
+		annotations: []string{
+			"example.com/cost-center=1234",
+			"0:example.com/rack=r1",
+			"1:example.com/rack=r2",
+		},
+	}
+	missing, err := opts.annotateSpecs([]generatedSpecs{{Interface: s}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, missing)
+
+	var buf bytes.Buffer
+	_, err = s.WriteTo(&buf)
+	require.NoError(t, err)
+
+	var raw specs.Spec
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	require.Equal(t, "0.6.0", raw.Version)
+	require.Equal(t, map[string]string{"example.com/cost-center": "1234"}, raw.Annotations)
+	require.Equal(t, map[string]string{"example.com/rack": "r1"}, raw.Devices[0].Annotations)
+}
@@ -0,0 +1,68 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// getApplicationClocks returns the application clocks to set for each device
+// if the clock hook was requested. The clocks are specified as
+// MEMORY,GRAPHICS in MHz as for nvidia-smi --applications-clocks.
+func (o *options) getApplicationClocks() (*nvcdi.ApplicationClocks, error) {
+	if !o.emitClockHook {
+		if o.applicationClocks != "" {
+			return nil, fmt.Errorf("--application-clocks requires --emit-clock-hook")
+		}
+		return nil, nil
+	}
+	if o.applicationClocks == "" {
+		return nil, fmt.Errorf("--emit-clock-hook requires --application-clocks")
+	}
+
+	memory, graphics, ok := strings.Cut(o.applicationClocks, ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid application clocks %q: expected MEMORY,GRAPHICS", o.applicationClocks)
+	}
+	memoryClock, err := parseClock(memory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory clock: %w", err)
+	}
+	graphicsClock, err := parseClock(graphics)
+	if err != nil {
+		return nil, fmt.Errorf("invalid graphics clock: %w", err)
+	}
+	return &nvcdi.ApplicationClocks{
+		Memory:   memoryClock,
+		Graphics: graphicsClock,
+	}, nil
+}
+
+func parseClock(value string) (uint32, error) {
+	clock, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if clock == 0 {
+		return 0, fmt.Errorf("clock must be greater than zero")
+	}
+	return uint32(clock), nil
+}
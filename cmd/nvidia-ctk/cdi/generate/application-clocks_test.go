@@ -0,0 +1,89 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+func TestGetApplicationClocks(t *testing.T) {
+	testCases := []struct {
+		description       string
+		emitClockHook     bool
+		applicationClocks string
+		expectedClocks    *nvcdi.ApplicationClocks
+		expectedError     bool
+	}{
+		{
+			description: "no hook requested",
+		},
+		{
+			description:       "clocks without hook is an error",
+			applicationClocks: "1215,1410",
+			expectedError:     true,
+		},
+		{
+			description:   "hook without clocks is an error",
+			emitClockHook: true,
+			expectedError: true,
+		},
+		{
+			description:       "valid clocks",
+			emitClockHook:     true,
+			applicationClocks: "1215, 1410",
+			expectedClocks:    &nvcdi.ApplicationClocks{Memory: 1215, Graphics: 1410},
+		},
+		{
+			description:       "single clock is an error",
+			emitClockHook:     true,
+			applicationClocks: "1215",
+			expectedError:     true,
+		},
+		{
+			description:       "zero clock is an error",
+			emitClockHook:     true,
+			applicationClocks: "1215,0",
+			expectedError:     true,
+		},
+		{
+			description:       "non-numeric clock is an error",
+			emitClockHook:     true,
+			applicationClocks: "max,1410",
+			expectedError:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &options{
+				emitClockHook:     tc.emitClockHook,
+				applicationClocks: tc.applicationClocks,
+			}
+			clocks, err := opts.getApplicationClocks()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedClocks, clocks)
+		})
+	}
+}
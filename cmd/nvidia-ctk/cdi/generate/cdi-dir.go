@@ -0,0 +1,54 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// setOutputFromCDIDir sets the output path if a CDI spec directory is
+// specified using --cdi-dir. The file name follows the convention used by
+// CDI-enabled runtimes such as Podman and is derived from the kind of the
+// spec, e.g. /etc/cdi/nvidia.com-gpu.yaml. The directory is created when the
+// spec is saved if it does not exist.
+func (o *options) setOutputFromCDIDir() error {
+	if o.cdiDir == "" {
+		return nil
+	}
+	if o.output != "" {
+		return fmt.Errorf("the --cdi-dir and --output flags are mutually exclusive")
+	}
+	if o.format == formatYAMLStream {
+		return fmt.Errorf("the --cdi-dir flag is not supported for format %q", formatYAMLStream)
+	}
+
+	name := cdi.GenerateSpecName(o.vendor, o.class)
+	if name != filepath.Base(name) {
+		return fmt.Errorf("cannot derive a file name from the kind %s/%s", o.vendor, o.class)
+	}
+	ext := ".yaml"
+	if o.format == spec.FormatJSON {
+		ext = ".json"
+	}
+	o.output = filepath.Join(o.cdiDir, name+ext)
+	return nil
+}
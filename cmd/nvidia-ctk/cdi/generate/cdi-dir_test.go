@@ -0,0 +1,104 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOutputFromCDIDir(t *testing.T) {
+	testCases := []struct {
+		description    string
+		options        options
+		expectedOutput string
+		expectedError  bool
+	}{
+		{
+			description: "no cdi dir",
+			options: options{
+				output: "/tmp/nvidia.yaml",
+			},
+			expectedOutput: "/tmp/nvidia.yaml",
+		},
+		{
+			description: "yaml file name is derived from kind",
+			options: options{
+				cdiDir: "/etc/cdi",
+				vendor: "nvidia.com",
+				class:  "gpu",
+				format: "yaml",
+			},
+			expectedOutput: "/etc/cdi/nvidia.com-gpu.yaml",
+		},
+		{
+			description: "json file name is derived from kind",
+			options: options{
+				cdiDir: "/var/run/cdi",
+				vendor: "example.com",
+				class:  "device",
+				format: "json",
+			},
+			expectedOutput: "/var/run/cdi/example.com-device.json",
+		},
+		{
+			description: "output is rejected",
+			options: options{
+				cdiDir: "/etc/cdi",
+				output: "/tmp/nvidia.yaml",
+				vendor: "nvidia.com",
+				class:  "gpu",
+				format: "yaml",
+			},
+			expectedError: true,
+		},
+		{
+			description: "yaml-stream is rejected",
+			options: options{
+				cdiDir: "/etc/cdi",
+				vendor: "nvidia.com",
+				class:  "gpu",
+				format: formatYAMLStream,
+			},
+			expectedError: true,
+		},
+		{
+			description: "kind with path separator is rejected",
+			options: options{
+				cdiDir:     "/etc/cdi",
+				vendor:     "nvidia.com",
+				class:      "../gpu",
+				format:     "yaml",
+				unsafeKind: true,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.options.setOutputFromCDIDir()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, tc.options.output)
+		})
+	}
+}
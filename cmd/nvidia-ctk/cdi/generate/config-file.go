@@ -0,0 +1,124 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	generateConfigFlagName = "generate-config"
+)
+
+// applyConfigFile sets the flags of the command from the values in the
+// specified config file. The keys of the file are the flag names and the values
+// of flags that were specified on the command line or through environment
+// variables are not overridden.
+func applyConfigFile(cmd *cli.Command, filename string) error {
+	if filename == "" {
+		return nil
+	}
+	values, err := loadConfigFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to load config file %v: %w", filename, err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if !hasFlag(cmd, name) || name == generateConfigFlagName || name == dumpSchemaFlagName {
+			return fmt.Errorf("invalid config file %v: unsupported option %q", filename, name)
+		}
+		if cmd.IsSet(name) {
+			continue
+		}
+		args, err := configValueAsArgs(values[name])
+		if err != nil {
+			return fmt.Errorf("invalid config file %v: invalid value for option %q: %w", filename, name, err)
+		}
+		for _, arg := range args {
+			if err := cmd.Set(name, arg); err != nil {
+				return fmt.Errorf("invalid config file %v: invalid value for option %q: %w", filename, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// loadConfigFile loads the values from the specified TOML, YAML, or JSON
+// config file. The format is determined from the file extension with YAML
+// being assumed for extensions other than .toml. Since JSON is a subset of
+// YAML, JSON files are also supported.
+func loadConfigFile(filename string) (map[string]any, error) {
+	if strings.ToLower(filepath.Ext(filename)) == ".toml" {
+		tree, err := toml.LoadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		return tree.ToMap(), nil
+	}
+
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	if err := yaml.Unmarshal(contents, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// configValueAsArgs converts a value from a config file to the arguments used
+// to set the corresponding flag. Each element of a list is returned as a
+// separate argument so that slice flags are set to all elements.
+func configValueAsArgs(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, fmt.Errorf("a value is required")
+	case []any:
+		var args []string
+		for _, element := range v {
+			switch element.(type) {
+			case []any, map[string]any:
+				return nil, fmt.Errorf("nested values are not supported")
+			}
+			args = append(args, fmt.Sprint(element))
+		}
+		return args, nil
+	case map[string]any:
+		return nil, fmt.Errorf("nested values are not supported")
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
+
+func hasFlag(cmd *cli.Command, name string) bool {
+	for _, flag := range cmd.Flags {
+		if slices.Contains(flag.Names(), name) {
+			return true
+		}
+	}
+	return false
+}
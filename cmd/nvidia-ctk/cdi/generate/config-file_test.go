@@ -0,0 +1,163 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestApplyConfigFile(t *testing.T) {
+	testCases := []struct {
+		description       string
+		filename          string
+		contents          string
+		args              []string
+		env               map[string]string
+		expectedOutput    string
+		expectedDeviceIDs []string
+		expectedOverwrite bool
+		expectedError     bool
+	}{
+		{
+			description: "yaml values are applied",
+			filename:    "generate.yaml",
+			contents: `
+output: /etc/cdi/nvidia.yaml
+device-id:
+  - 0
+  - 1
+overwrite: false
+`,
+			expectedOutput:    "/etc/cdi/nvidia.yaml",
+			expectedDeviceIDs: []string{"0", "1"},
+		},
+		{
+			description: "toml values are applied",
+			filename:    "generate.toml",
+			contents: `
+output = "/etc/cdi/nvidia.yaml"
+device-id = ["all"]
+`,
+			expectedOutput:    "/etc/cdi/nvidia.yaml",
+			expectedDeviceIDs: []string{"all"},
+			expectedOverwrite: true,
+		},
+		{
+			description:       "json values are applied",
+			filename:          "generate.json",
+			contents:          `{"output": "/etc/cdi/nvidia.json"}`,
+			expectedOutput:    "/etc/cdi/nvidia.json",
+			expectedDeviceIDs: []string{"all"},
+			expectedOverwrite: true,
+		},
+		{
+			description:       "command line flags take precedence",
+			filename:          "generate.yaml",
+			contents:          "output: /etc/cdi/nvidia.yaml\ndevice-id: [0]\n",
+			args:              []string{"--output=/var/run/cdi/nvidia.yaml"},
+			expectedOutput:    "/var/run/cdi/nvidia.yaml",
+			expectedDeviceIDs: []string{"0"},
+			expectedOverwrite: true,
+		},
+		{
+			description:       "environment variables take precedence",
+			filename:          "generate.yaml",
+			contents:          "output: /etc/cdi/nvidia.yaml\n",
+			env:               map[string]string{"TEST_OUTPUT": "/var/run/cdi/nvidia.yaml"},
+			expectedOutput:    "/var/run/cdi/nvidia.yaml",
+			expectedDeviceIDs: []string{"all"},
+			expectedOverwrite: true,
+		},
+		{
+			description:       "command line flags take precedence over environment variables",
+			filename:          "generate.yaml",
+			contents:          "output: /etc/cdi/nvidia.yaml\n",
+			args:              []string{"--output=/run/cdi/nvidia.yaml"},
+			env:               map[string]string{"TEST_OUTPUT": "/var/run/cdi/nvidia.yaml"},
+			expectedOutput:    "/run/cdi/nvidia.yaml",
+			expectedDeviceIDs: []string{"all"},
+			expectedOverwrite: true,
+		},
+		{
+			description:   "unknown option is an error",
+			filename:      "generate.yaml",
+			contents:      "unknown: value\n",
+			expectedError: true,
+		},
+		{
+			description:   "nested value is an error",
+			filename:      "generate.yaml",
+			contents:      "output:\n  path: /etc/cdi/nvidia.yaml\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			filename := filepath.Join(t.TempDir(), tc.filename)
+			require.NoError(t, os.WriteFile(filename, []byte(tc.contents), 0600))
+
+			var output string
+			var deviceIDs []string
+			var overwrite bool
+			cmd := &cli.Command{
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "output",
+						Destination: &output,
+						Sources:     cli.EnvVars("TEST_OUTPUT"),
+					},
+					&cli.StringSliceFlag{
+						Name:        "device-id",
+						Value:       []string{"all"},
+						Destination: &deviceIDs,
+					},
+					&cli.BoolFlag{
+						Name:        "overwrite",
+						Value:       true,
+						Destination: &overwrite,
+					},
+				},
+				Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+					return ctx, applyConfigFile(cmd, filename)
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return nil
+				},
+			}
+
+			err := cmd.Run(context.Background(), append([]string{"generate"}, tc.args...))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, output)
+			require.Equal(t, tc.expectedDeviceIDs, deviceIDs)
+			require.Equal(t, tc.expectedOverwrite, overwrite)
+		})
+	}
+}
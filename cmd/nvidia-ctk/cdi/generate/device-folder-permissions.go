@@ -0,0 +1,66 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"io/fs"
+	"os/user"
+	"strconv"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// getDeviceFolderPermissionOptions returns the nvcdi options for the mode and
+// group that are applied to the parent folders of nested device nodes such as
+// /dev/dri. The mode must be specified as an octal value and the group either
+// as a numeric ID or as a group name that is resolved on the host.
+func (o *options) getDeviceFolderPermissionOptions() ([]nvcdi.Option, error) {
+	var cdiOptions []nvcdi.Option
+	if o.deviceFolderMode != "" {
+		mode, err := strconv.ParseUint(o.deviceFolderMode, 8, 32)
+		if err != nil || fs.FileMode(mode)&^(fs.ModePerm|0o7000) != 0 {
+			return nil, fmt.Errorf("invalid device folder mode %q: must be an octal file mode", o.deviceFolderMode)
+		}
+		cdiOptions = append(cdiOptions, nvcdi.WithDeviceFolderMode(fs.FileMode(mode)))
+	}
+	if o.deviceFolderGroup != "" {
+		gid, err := resolveGroupID(o.deviceFolderGroup)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device folder group %q: %w", o.deviceFolderGroup, err)
+		}
+		cdiOptions = append(cdiOptions, nvcdi.WithDeviceFolderGroup(gid))
+	}
+	return cdiOptions, nil
+}
+
+// resolveGroupID returns the numeric ID for the specified group. Numeric IDs
+// are used as is and group names are looked up on the host.
+func resolveGroupID(group string) (uint32, error) {
+	if gid, err := strconv.ParseUint(group, 10, 31); err == nil {
+		return uint32(gid), nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return 0, err
+	}
+	gid, err := strconv.ParseUint(g.Gid, 10, 31)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected group ID %q: %w", g.Gid, err)
+	}
+	return uint32(gid), nil
+}
@@ -0,0 +1,94 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDeviceFolderPermissionOptions(t *testing.T) {
+	testCases := []struct {
+		description     string
+		mode            string
+		group           string
+		expectedOptions int
+		expectedError   bool
+	}{
+		{
+			description: "defaults",
+		},
+		{
+			description:     "octal mode",
+			mode:            "750",
+			expectedOptions: 1,
+		},
+		{
+			description:   "non-octal mode is rejected",
+			mode:          "789",
+			expectedError: true,
+		},
+		{
+			description:   "mode with file type bits is rejected",
+			mode:          "40755",
+			expectedError: true,
+		},
+		{
+			description:     "numeric group",
+			group:           "44",
+			expectedOptions: 1,
+		},
+		{
+			description:     "group name is resolved",
+			mode:            "0770",
+			group:           "root",
+			expectedOptions: 2,
+		},
+		{
+			description:   "unknown group is rejected",
+			group:         "no-such-group-for-testing",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &options{
+				deviceFolderMode:  tc.mode,
+				deviceFolderGroup: tc.group,
+			}
+			cdiOptions, err := opts.getDeviceFolderPermissionOptions()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, cdiOptions, tc.expectedOptions)
+		})
+	}
+}
+
+func TestResolveGroupID(t *testing.T) {
+	gid, err := resolveGroupID("44")
+	require.NoError(t, err)
+	require.EqualValues(t, 44, gid)
+
+	gid, err = resolveGroupID("root")
+	require.NoError(t, err)
+	require.EqualValues(t, 0, gid)
+}
@@ -0,0 +1,126 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// isDevicePattern checks whether the specified device ID is a glob pattern
+// that is matched against the names of the generated devices.
+func isDevicePattern(id string) bool {
+	return strings.ContainsAny(id, "*?[")
+}
+
+// validateDevicePatterns checks that the device IDs that are glob patterns
+// are valid.
+func validateDevicePatterns(deviceIDs []string) error {
+	for _, id := range deviceIDs {
+		if !isDevicePattern(id) {
+			continue
+		}
+		if _, err := filepath.Match(id, ""); err != nil {
+			return fmt.Errorf("invalid device pattern %q: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// getDeviceSpecs returns the device specs for the specified device IDs. Device
+// IDs that are glob patterns (e.g. gpu* or mig0:*) are matched against the
+// names assigned to all devices by the configured device namers. The devices
+// matching a pattern are included in addition to the devices that are
+// requested explicitly. The devices that do not match are reported using the
+// specified logf function.
+func (m command) getDeviceSpecs(cdilib nvcdi.Interface, deviceIDs []string, logf func(string, ...any)) ([]specs.Device, error) {
+	var ids, patterns []string
+	for _, id := range deviceIDs {
+		if isDevicePattern(id) {
+			patterns = append(patterns, id)
+		} else {
+			ids = append(ids, id)
+		}
+	}
+	if len(patterns) == 0 {
+		return cdilib.GetDeviceSpecsByID(ids...)
+	}
+
+	// In best-effort mode, a partial discovery error is returned along with
+	// the specs for the remaining devices.
+	allDeviceSpecs, partialErr := cdilib.GetDeviceSpecsByID("all")
+	if partialErr != nil && !errors.As(partialErr, new(*nvcdi.PartialDiscoveryError)) {
+		return nil, partialErr
+	}
+
+	var selected []specs.Device
+	if len(ids) > 0 {
+		var err error
+		selected, err = cdilib.GetDeviceSpecsByID(ids...)
+		if err != nil && !errors.As(err, new(*nvcdi.PartialDiscoveryError)) {
+			return nil, err
+		}
+		if partialErr == nil {
+			partialErr = err
+		}
+	}
+
+	included := make(map[string]bool)
+	for _, d := range selected {
+		included[d.Name] = true
+	}
+	for _, pattern := range patterns {
+		var matched bool
+		for _, d := range allDeviceSpecs {
+			if match, _ := filepath.Match(pattern, d.Name); !match {
+				continue
+			}
+			matched = true
+			if included[d.Name] {
+				continue
+			}
+			included[d.Name] = true
+			selected = append(selected, d)
+		}
+		if !matched {
+			m.logger.Warningf("No devices match the device pattern %q", pattern)
+		}
+	}
+	for _, d := range allDeviceSpecs {
+		if !included[d.Name] {
+			logf("Skipping device %q: the name does not match the requested devices", d.Name)
+		}
+	}
+	return selected, partialErr
+}
+
+// logDeviceSelection returns the function used to log whether a device is
+// included in the generated spec. Messages are logged at info level if
+// --verbose is specified and at debug level otherwise.
+func (o *options) logDeviceSelection(logger logger.Interface) func(string, ...any) {
+	if o.verbose {
+		return logger.Infof
+	}
+	return logger.Debugf
+}
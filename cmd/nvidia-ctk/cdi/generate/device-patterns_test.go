@@ -0,0 +1,105 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// deviceSpecsByName returns the device specs for devices with the requested
+// names, or for all devices if "all" is requested.
+type deviceSpecsByName struct {
+	nvcdi.Interface
+	names []string
+}
+
+func (l deviceSpecsByName) GetDeviceSpecsByID(ids ...string) ([]specs.Device, error) {
+	var deviceSpecs []specs.Device
+	for _, name := range l.names {
+		for _, id := range ids {
+			if id == "all" || id == name {
+				deviceSpecs = append(deviceSpecs, specs.Device{Name: name})
+				break
+			}
+		}
+	}
+	return deviceSpecs, nil
+}
+
+func TestGetDeviceSpecs(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{logger: logger}
+	cdilib := deviceSpecsByName{
+		names: []string{"gpu0", "gpu1", "mig0:0", "mig0:1", "mig1:0"},
+	}
+
+	testCases := []struct {
+		description   string
+		deviceIDs     []string
+		expectedNames []string
+	}{
+		{
+			description:   "explicit device IDs",
+			deviceIDs:     []string{"gpu1"},
+			expectedNames: []string{"gpu1"},
+		},
+		{
+			description:   "pattern matches full GPUs",
+			deviceIDs:     []string{"gpu*"},
+			expectedNames: []string{"gpu0", "gpu1"},
+		},
+		{
+			description:   "pattern matches MIG devices of a GPU",
+			deviceIDs:     []string{"mig0:*"},
+			expectedNames: []string{"mig0:0", "mig0:1"},
+		},
+		{
+			description:   "patterns are combined with explicit IDs without duplicates",
+			deviceIDs:     []string{"mig1:0", "mig?:0"},
+			expectedNames: []string{"mig1:0", "mig0:0"},
+		},
+		{
+			description: "pattern matching no devices",
+			deviceIDs:   []string{"nvswitch*"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.NoError(t, validateDevicePatterns(tc.deviceIDs))
+
+			deviceSpecs, err := c.getDeviceSpecs(cdilib, tc.deviceIDs, logger.Debugf)
+			require.NoError(t, err)
+
+			var names []string
+			for _, d := range deviceSpecs {
+				names = append(names, d.Name)
+			}
+			require.EqualValues(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestValidateDevicePatterns(t *testing.T) {
+	require.Error(t, validateDevicePatterns([]string{"gpu[0"}))
+}
@@ -0,0 +1,154 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// A deviceInventory collects the attributes of the devices for which CDI
+// device specs are generated. A nil inventory records nothing.
+type deviceInventory struct {
+	sync.Mutex
+	devices []nvcdi.DeviceInfo
+	byUUID  map[string]int
+	// renamed maps the generated device names to the names in the output
+	// specs if these were changed, for example by adding a prefix.
+	renamed map[string]string
+}
+
+// An inventoryDevice is the description of a device in the --devices-json
+// output.
+type inventoryDevice struct {
+	Names             []string `json:"names"`
+	UUID              string   `json:"uuid"`
+	ParentUUID        string   `json:"parentUUID,omitempty"`
+	MemoryBytes       uint64   `json:"memoryBytes,omitempty"`
+	MIGProfile        string   `json:"migProfile,omitempty"`
+	ComputeCapability string   `json:"computeCapability,omitempty"`
+}
+
+func newDeviceInventory() *deviceInventory {
+	return &deviceInventory{
+		byUUID:  make(map[string]int),
+		renamed: make(map[string]string),
+	}
+}
+
+// recorder returns the function used to record device attributes during spec
+// generation. Nil is returned for a nil inventory so that the attributes are
+// not queried.
+func (i *deviceInventory) recorder() nvcdi.DeviceInfoRecorder {
+	if i == nil {
+		return nil
+	}
+	return i.record
+}
+
+// record adds the specified device to the inventory. Since the specs for a
+// device may be generated more than once, for example when device patterns
+// are used, a device that was already recorded is replaced.
+func (i *deviceInventory) record(info nvcdi.DeviceInfo) {
+	i.Lock()
+	defer i.Unlock()
+	if index, ok := i.byUUID[info.UUID]; ok {
+		i.devices[index] = info
+		return
+	}
+	i.byUUID[info.UUID] = len(i.devices)
+	i.devices = append(i.devices, info)
+}
+
+// rename records the names of the output devices corresponding to the
+// specified generated names. The two lists are expected to be in the same
+// order.
+func (i *deviceInventory) rename(generated []string, devices []specs.Device) {
+	if i == nil {
+		return
+	}
+	i.Lock()
+	defer i.Unlock()
+	for j, name := range generated {
+		if j < len(devices) && devices[j].Name != name {
+			i.renamed[name] = devices[j].Name
+		}
+	}
+}
+
+// getDevices returns the inventory of the devices that are included in the
+// specified specs in the order in which they were generated.
+func (i *deviceInventory) getDevices(generated []generatedSpecs) []inventoryDevice {
+	i.Lock()
+	defer i.Unlock()
+
+	included := make(map[string]bool)
+	for _, s := range generated {
+		for _, device := range s.Raw().Devices {
+			included[device.Name] = true
+		}
+	}
+
+	inventory := []inventoryDevice{}
+	for _, info := range i.devices {
+		var names []string
+		for _, name := range info.Names {
+			if renamed, ok := i.renamed[name]; ok {
+				name = renamed
+			}
+			if included[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		d := inventoryDevice{
+			Names:       names,
+			UUID:        info.UUID,
+			ParentUUID:  info.ParentUUID,
+			MemoryBytes: info.MemoryBytes,
+			MIGProfile:  info.MIGProfile,
+		}
+		if info.ComputeCapability != nil {
+			d.ComputeCapability = info.ComputeCapability.String()
+		}
+		inventory = append(inventory, d)
+	}
+	return inventory
+}
+
+// writeDevicesJSON writes the inventory of the generated devices to the
+// requested file as JSON.
+func (o *options) writeDevicesJSON(specs []generatedSpecs) error {
+	if o.devicesJSON == "" || o.inventory == nil {
+		return nil
+	}
+	contents, err := json.MarshalIndent(map[string]any{"devices": o.inventory.getDevices(specs)}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal device inventory: %w", err)
+	}
+	if err := o.writeFileAtomic(o.devicesJSON, append(contents, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write device inventory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestWriteDevicesJSON(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+	uuid, ret := server.Devices[0].GetUUID()
+	require.Equal(t, nvml.SUCCESS, ret)
+
+	output := filepath.Join(t.TempDir(), "devices.json")
+	opts := &options{
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "example.com",
+		class:                "device",
+		deviceIDs:            []string{"all"},
+		deviceNameStrategies: []string{"index", "uuid"},
+		devicePrefix:         "gpu",
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		nvmllib:              server,
+		devicesJSON:          output,
+		inventory:            newDeviceInventory(),
+	}
+	generated, err := c.generateSpecs(opts)
+	require.NoError(t, err)
+	require.NoError(t, opts.writeDevicesJSON(generated))
+
+	contents, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "devices": [
+    {
+      "names": ["gpu-0", "gpu-`+uuid+`"],
+      "uuid": "`+uuid+`",
+      "memoryBytes": 42949672960,
+      "computeCapability": "8.0"
+    }
+  ]
+}`, string(contents))
+}
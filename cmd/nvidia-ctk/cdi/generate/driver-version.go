@@ -0,0 +1,101 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// A driverVersionRequirement is the driver version that is required for a
+// spec to be generated.
+type driverVersionRequirement struct {
+	version *semver.Version
+	// atLeast indicates that newer driver versions are also accepted.
+	atLeast bool
+}
+
+// getRequiredDriverVersion parses the required driver version. This is
+// specified as either an exact version (e.g. 550.54.15) or a minimum version
+// (e.g. >=550.54.15). If no version is required, nil is returned.
+func (o *options) getRequiredDriverVersion() (*driverVersionRequirement, error) {
+	if o.requiredDriverVersion == "" {
+		return nil, nil
+	}
+	value, atLeast := strings.CutPrefix(o.requiredDriverVersion, ">=")
+	version, err := semver.NewVersion(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid required driver version %q: %w", o.requiredDriverVersion, err)
+	}
+	r := driverVersionRequirement{
+		version: version,
+		atLeast: atLeast,
+	}
+	return &r, nil
+}
+
+// checkDriverVersion queries the driver version using NVML and checks that
+// this matches the required driver version.
+func (o *options) checkDriverVersion(nvmllib nvml.Interface) error {
+	required, err := o.getRequiredDriverVersion()
+	if err != nil || required == nil {
+		return err
+	}
+
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+	doneInit := o.timings.track(nvcdi.PhaseNVMLInit)
+	r := nvmllib.Init()
+	doneInit()
+	if r != nvml.SUCCESS {
+		return fmt.Errorf("failed to initialize NVML to check the driver version: %v", r)
+	}
+	defer func() {
+		_ = nvmllib.Shutdown()
+	}()
+
+	driverVersion, r := nvmllib.SystemGetDriverVersion()
+	if r != nvml.SUCCESS {
+		return fmt.Errorf("failed to get driver version: %v", r)
+	}
+	return required.check(driverVersion)
+}
+
+// check returns an error if the specified driver version does not satisfy the
+// requirement.
+func (r *driverVersionRequirement) check(driverVersion string) error {
+	version, err := semver.NewVersion(driverVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse driver version %q: %w", driverVersion, err)
+	}
+	if r.atLeast {
+		if version.LessThan(r.version) {
+			return fmt.Errorf("driver version %v is older than the required version %v", driverVersion, r.version.Original())
+		}
+		return nil
+	}
+	if !version.Equal(r.version) {
+		return fmt.Errorf("driver version %v does not match the required version %v", driverVersion, r.version.Original())
+	}
+	return nil
+}
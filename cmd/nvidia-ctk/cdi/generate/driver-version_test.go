@@ -0,0 +1,101 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDriverVersion(t *testing.T) {
+	testCases := []struct {
+		description           string
+		requiredDriverVersion string
+		driverVersion         string
+		expectedError         bool
+	}{
+		{
+			description:   "no required version",
+			driverVersion: "550.54.15",
+		},
+		{
+			description:           "exact version matches",
+			requiredDriverVersion: "550.54.15",
+			driverVersion:         "550.54.15",
+		},
+		{
+			description:           "exact version does not match",
+			requiredDriverVersion: "550.54.15",
+			driverVersion:         "550.54.14",
+			expectedError:         true,
+		},
+		{
+			description:           "version with leading zeros matches",
+			requiredDriverVersion: "535.104.05",
+			driverVersion:         "535.104.05",
+		},
+		{
+			description:           "minimum version matches newer driver",
+			requiredDriverVersion: ">=535.104.05",
+			driverVersion:         "550.54.15",
+		},
+		{
+			description:           "minimum version matches equal driver",
+			requiredDriverVersion: ">=550.54.15",
+			driverVersion:         "550.54.15",
+		},
+		{
+			description:           "minimum version does not match older driver",
+			requiredDriverVersion: ">=550.54.15",
+			driverVersion:         "535.104.05",
+			expectedError:         true,
+		},
+		{
+			description:           "invalid required version",
+			requiredDriverVersion: ">=latest",
+			driverVersion:         "550.54.15",
+			expectedError:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			nvmllib := &mock.Interface{
+				InitFunc: func() nvml.Return {
+					return nvml.SUCCESS
+				},
+				ShutdownFunc: func() nvml.Return {
+					return nvml.SUCCESS
+				},
+				SystemGetDriverVersionFunc: func() (string, nvml.Return) {
+					return tc.driverVersion, nvml.SUCCESS
+				},
+			}
+			opts := &options{requiredDriverVersion: tc.requiredDriverVersion}
+
+			err := opts.checkDriverVersion(nvmllib)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// A plannedOutput describes a destination that the generated specs would be
+// written to.
+type plannedOutput struct {
+	destination string
+	// exists indicates whether the destination already exists. This is
+	// always false for STDOUT.
+	exists bool
+	format string
+	size   int
+}
+
+func (p plannedOutput) String() string {
+	var state string
+	switch {
+	case p.destination == "STDOUT":
+	case p.exists:
+		state = " (exists)"
+	default:
+		state = " (new)"
+	}
+	return fmt.Sprintf("%v%v: %v, %d bytes", p.destination, state, p.format, p.size)
+}
+
+// getVulkanManifestDir returns the directory in which rewritten Vulkan
+// manifests are created. For a dry run no manifests are created.
+func (o *options) getVulkanManifestDir() string {
+	if o.dryRun {
+		return ""
+	}
+	return o.vulkanManifestDir
+}
+
+// logPlannedOutputs logs the destinations that the specified specs would be
+// written to for a dry run. Nothing is written to these destinations.
+func (m command) logPlannedOutputs(opts *options, specs []generatedSpecs) error {
+	planned, err := opts.getPlannedOutputs(specs)
+	if err != nil {
+		return err
+	}
+	for _, p := range planned {
+		m.logger.Infof("Dry run: would write %v", p)
+	}
+	return nil
+}
+
+// getPlannedOutputs returns the destinations that the specified specs would
+// be written to along with the format and size of the contents for each.
+func (o *options) getPlannedOutputs(specs []generatedSpecs) ([]plannedOutput, error) {
+	if path := o.getUnixSocketOutput(); path != "" {
+		size, err := getContentsSize(specs...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = os.Stat(path)
+		return []plannedOutput{{destination: o.output, exists: err == nil, format: o.specFormat(), size: size}}, nil
+	}
+
+	if o.format == formatYAMLStream {
+		size, err := getContentsSize(specs...)
+		if err != nil {
+			return nil, err
+		}
+		p, err := newPlannedOutput(o.output, formatYAMLStream, size)
+		if err != nil {
+			return nil, err
+		}
+		return []plannedOutput{*p}, nil
+	}
+
+	var planned []plannedOutput
+	for _, spec := range specs {
+		size, err := getContentsSize(spec)
+		if err != nil {
+			return nil, err
+		}
+		p, err := newPlannedOutput(spec.updateFilename(o.output), o.specFormat(), size)
+		if err != nil {
+			return nil, err
+		}
+		planned = append(planned, *p)
+	}
+	return planned, nil
+}
+
+// newPlannedOutput creates a planned output for the specified file. An empty
+// filename indicates that the output is written to STDOUT.
+func newPlannedOutput(filename string, format string, size int) (*plannedOutput, error) {
+	if filename == "" {
+		return &plannedOutput{destination: "STDOUT", format: format, size: size}, nil
+	}
+	_, err := os.Lstat(filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to check output file %v: %w", filename, err)
+	}
+	return &plannedOutput{destination: filename, exists: err == nil, format: format, size: size}, nil
+}
+
+// getContentsSize returns the number of bytes that are written for the
+// specified specs.
+func getContentsSize(specs ...generatedSpecs) (int, error) {
+	var contents bytes.Buffer
+	for _, spec := range specs {
+		if _, err := spec.WriteTo(&contents); err != nil {
+			return 0, fmt.Errorf("failed to write CDI spec: %w", err)
+		}
+	}
+	return contents.Len(), nil
+}
@@ -0,0 +1,126 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestGetPlannedOutputs(t *testing.T) {
+	s, err := spec.New(
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: "0",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	var contents bytes.Buffer
+	_, err = s.WriteTo(&contents)
+	require.NoError(t, err)
+	size := contents.Len()
+
+	outputDir := t.TempDir()
+	existing := filepath.Join(outputDir, "existing.yaml")
+	require.NoError(t, os.WriteFile(existing, nil, 0600))
+
+	testCases := []struct {
+		description     string
+		options         options
+		specs           []generatedSpecs
+		expectedOutputs []plannedOutput
+	}{
+		{
+			description: "stdout",
+			options:     options{format: spec.FormatYAML},
+			specs:       []generatedSpecs{{Interface: s}},
+			expectedOutputs: []plannedOutput{
+				{destination: "STDOUT", format: spec.FormatYAML, size: size},
+			},
+		},
+		{
+			description: "existing and new files",
+			options: options{
+				output: existing,
+				format: spec.FormatYAML,
+			},
+			specs: []generatedSpecs{{Interface: s}, {Interface: s, filenameInfix: ".display"}},
+			expectedOutputs: []plannedOutput{
+				{destination: existing, exists: true, format: spec.FormatYAML, size: size},
+				{destination: filepath.Join(outputDir, "existing.display.yaml"), format: spec.FormatYAML, size: size},
+			},
+		},
+		{
+			description: "stream is a single output",
+			options: options{
+				output: filepath.Join(outputDir, "stream.yaml"),
+				format: formatYAMLStream,
+			},
+			specs: []generatedSpecs{{Interface: s}, {Interface: s, filenameInfix: ".display"}},
+			expectedOutputs: []plannedOutput{
+				{destination: filepath.Join(outputDir, "stream.yaml"), format: formatYAMLStream, size: 2 * size},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			planned, err := tc.options.getPlannedOutputs(tc.specs)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutputs, planned)
+		})
+	}
+}
+
+func TestWriteSpecsDryRun(t *testing.T) {
+	logger, hook := testlog.NewNullLogger()
+
+	s, err := spec.New(
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: "0",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	output := filepath.Join(t.TempDir(), "nvidia.yaml")
+	opts := &options{
+		output:    output,
+		format:    spec.FormatYAML,
+		overwrite: true,
+		dryRun:    true,
+	}
+	require.NoError(t, command{logger: logger}.writeSpecs(opts, []generatedSpecs{{Interface: s}}))
+	require.NoFileExists(t, output)
+	require.Contains(t, hook.LastEntry().Message, "Dry run: would write "+output+" (new): yaml")
+}
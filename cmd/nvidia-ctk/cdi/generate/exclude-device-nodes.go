@@ -0,0 +1,106 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// requiredDeviceNodePatterns match the device nodes that are typically
+// required by CUDA applications. Excluding these is allowed, but a warning is
+// logged.
+var requiredDeviceNodePatterns = []string{
+	"/dev/nvidiactl",
+	"/dev/nvidia-uvm",
+	"/dev/nvidia[0-9]*",
+}
+
+// validateExcludedDeviceNodes checks that the specified exclusion patterns are
+// valid globs.
+func (o *options) validateExcludedDeviceNodes() error {
+	for _, pattern := range o.excludedDeviceNodes {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid device node exclusion %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// isExcludedDeviceNode checks whether the container path of the specified
+// device node matches one of the exclusion patterns.
+func (o *options) isExcludedDeviceNode(path string) bool {
+	for _, pattern := range o.excludedDeviceNodes {
+		if match, _ := filepath.Match(pattern, path); match {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeDeviceNodes removes the device nodes matching the requested exclusion
+// patterns from the specified common edits and device specs. A device is
+// rejected if this leaves it without any container edits.
+func (m command) excludeDeviceNodes(opts *options, commonEdits *specs.ContainerEdits, devices []specs.Device) error {
+	if len(opts.excludedDeviceNodes) == 0 {
+		return nil
+	}
+
+	excluded := make(map[string]bool)
+	filter := func(edits *specs.ContainerEdits) {
+		edits.DeviceNodes = slices.DeleteFunc(edits.DeviceNodes, func(d *specs.DeviceNode) bool {
+			if !opts.isExcludedDeviceNode(d.Path) {
+				return false
+			}
+			excluded[d.Path] = true
+			return true
+		})
+	}
+
+	if commonEdits != nil {
+		filter(commonEdits)
+	}
+	for i := range devices {
+		filter(&devices[i].ContainerEdits)
+		if isEmptyContainerEdits(&devices[i].ContainerEdits) {
+			return fmt.Errorf("excluding device nodes leaves device %q without container edits", devices[i].Name)
+		}
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(excluded)) {
+		m.logger.Debugf("Excluding device node %v", path)
+		if isRequiredDeviceNode(path) {
+			m.logger.Warningf("Excluding device node %v, which is typically required by CUDA applications", path)
+		}
+	}
+	return nil
+}
+
+// isRequiredDeviceNode checks whether the specified device node is typically
+// required by CUDA applications.
+func isRequiredDeviceNode(path string) bool {
+	for _, pattern := range requiredDeviceNodePatterns {
+		if match, _ := filepath.Match(pattern, path); match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestExcludeDeviceNodes(t *testing.T) {
+	newDevice := func(name string, paths ...string) specs.Device {
+		d := specs.Device{Name: name}
+		for _, path := range paths {
+			d.ContainerEdits.DeviceNodes = append(d.ContainerEdits.DeviceNodes, &specs.DeviceNode{Path: path})
+		}
+		return d
+	}
+	newEdits := func(paths ...string) *specs.ContainerEdits {
+		d := newDevice("", paths...)
+		return &d.ContainerEdits
+	}
+
+	testCases := []struct {
+		description         string
+		excluded            []string
+		commonEdits         *specs.ContainerEdits
+		devices             []specs.Device
+		expectedCommonEdits *specs.ContainerEdits
+		expectedDevices     []specs.Device
+		expectedWarnings    int
+		expectedError       bool
+	}{
+		{
+			description:         "no exclusions",
+			commonEdits:         newEdits("/dev/nvidiactl", "/dev/nvidia-uvm"),
+			devices:             []specs.Device{newDevice("0", "/dev/nvidia0")},
+			expectedCommonEdits: newEdits("/dev/nvidiactl", "/dev/nvidia-uvm"),
+			expectedDevices:     []specs.Device{newDevice("0", "/dev/nvidia0")},
+		},
+		{
+			description:         "common device nodes are excluded",
+			excluded:            []string{"/dev/nvidia-uvm*"},
+			commonEdits:         newEdits("/dev/nvidiactl", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"),
+			devices:             []specs.Device{newDevice("0", "/dev/nvidia0")},
+			expectedCommonEdits: newEdits("/dev/nvidiactl"),
+			expectedDevices:     []specs.Device{newDevice("0", "/dev/nvidia0")},
+			expectedWarnings:    1,
+		},
+		{
+			description:         "device nodes are excluded",
+			excluded:            []string{"/dev/nvidia-caps/*"},
+			commonEdits:         newEdits("/dev/nvidiactl"),
+			devices:             []specs.Device{newDevice("0:0", "/dev/nvidia0", "/dev/nvidia-caps/nvidia-cap12", "/dev/nvidia-caps/nvidia-cap13")},
+			expectedCommonEdits: newEdits("/dev/nvidiactl"),
+			expectedDevices:     []specs.Device{newDevice("0:0", "/dev/nvidia0")},
+		},
+		{
+			description:   "device without container edits is an error",
+			excluded:      []string{"/dev/nvidia0"},
+			commonEdits:   newEdits("/dev/nvidiactl"),
+			devices:       []specs.Device{newDevice("0", "/dev/nvidia0")},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+			c := command{logger: logger}
+			opts := &options{excludedDeviceNodes: tc.excluded}
+			require.NoError(t, opts.validateExcludedDeviceNodes())
+
+			err := c.excludeDeviceNodes(opts, tc.commonEdits, tc.devices)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedCommonEdits, tc.commonEdits)
+			require.EqualValues(t, tc.expectedDevices, tc.devices)
+
+			var warnings int
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warnings++
+				}
+			}
+			require.Equal(t, tc.expectedWarnings, warnings)
+		})
+	}
+}
+
+func TestValidateExcludedDeviceNodes(t *testing.T) {
+	opts := &options{excludedDeviceNodes: []string{"/dev/nvidia["}}
+	require.Error(t, opts.validateExcludedDeviceNodes())
+}
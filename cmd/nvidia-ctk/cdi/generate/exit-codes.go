@@ -0,0 +1,71 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+// The following exit codes are used by the generate command to allow callers
+// to distinguish between different classes of failures. Any other error
+// results in an exit code of 1.
+const (
+	// ExitCodeDiscoveryError indicates that the entities to include in the
+	// CDI specification could not be discovered. This includes failures to
+	// initialize or query NVML, for example if the driver is not yet ready.
+	ExitCodeDiscoveryError = 2
+	// ExitCodeOutputError indicates that the generated CDI specification could
+	// not be written to the requested output.
+	ExitCodeOutputError = 3
+	// ExitCodeValidationError indicates that the specified command line
+	// arguments are invalid.
+	ExitCodeValidationError = 4
+	// ExitCodePartialDiscoveryError indicates that the CDI specification was
+	// written in best-effort mode but that some devices could not be included.
+	ExitCodePartialDiscoveryError = 5
+)
+
+// The following components are reported for errors when the --error-format
+// json flag is specified. Failures for individual devices are reported using
+// the errorformat.DevicesComponent component.
+const (
+	componentValidation  = "validation"
+	componentCommonEdits = "common-edits"
+	componentOutput      = "output"
+)
+
+// An exitError associates an exit code with an error.
+// It implements the cli.ExitCoder interface.
+type exitError struct {
+	error
+	code int
+}
+
+// withExitCode associates the specified exit code with an error.
+// If the error is nil, nil is returned.
+func withExitCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{error: err, code: code}
+}
+
+// ExitCode returns the exit code associated with the error.
+func (e *exitError) ExitCode() int {
+	return e.code
+}
+
+// Unwrap returns the wrapped error.
+func (e *exitError) Unwrap() error {
+	return e.error
+}
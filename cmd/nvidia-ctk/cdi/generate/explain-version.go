@@ -0,0 +1,77 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// logGeneratedSpec logs the version of the specified generated spec. The
+// minimum CDI spec version required by each device is logged at the debug
+// level and, if --explain-version is specified, the devices and features that
+// require the minimum version of the spec are also logged.
+func (m command) logGeneratedSpec(opts *options, generated generatedSpecs) {
+	raw := generated.Raw()
+	// We query the raw spec version after the spec has been written since
+	// this may update the spec version to the minimum required version.
+	m.logger.Infof("Generated CDI spec with version %v", raw.Version)
+
+	requirements, err := spec.GetVersionRequirements(raw)
+	if err != nil {
+		m.logger.Warningf("Failed to determine CDI spec version requirements: %v", err)
+		return
+	}
+
+	var minVersion string
+	for _, requirement := range requirements {
+		m.logger.Debugf("%v requires CDI spec version %v", describeRequirement(requirement), requirement.Version)
+		if minVersion == "" || semver.Compare("v"+requirement.Version, "v"+minVersion) > 0 {
+			minVersion = requirement.Version
+		}
+	}
+
+	if !opts.explainVersion {
+		return
+	}
+
+	var drivers []string
+	for _, requirement := range requirements {
+		if requirement.Version != minVersion || len(requirement.Features) == 0 {
+			continue
+		}
+		drivers = append(drivers, fmt.Sprintf("%v (%v)", describeRequirement(requirement), strings.Join(requirement.Features, ", ")))
+	}
+	if len(drivers) == 0 {
+		m.logger.Infof("CDI spec %v requires no features beyond the earliest supported version %v", raw.Kind, minVersion)
+		return
+	}
+	m.logger.Infof("CDI spec %v requires version %v for: %v", raw.Kind, minVersion, strings.Join(drivers, "; "))
+}
+
+// describeRequirement returns a description of the part of the spec that a
+// version requirement applies to.
+func describeRequirement(requirement spec.VersionRequirement) string {
+	if requirement.Device == "" {
+		return "spec-level fields"
+	}
+	return fmt.Sprintf("device %q", requirement.Device)
+}
@@ -0,0 +1,119 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestLogGeneratedSpec(t *testing.T) {
+	testCases := []struct {
+		description      string
+		explainVersion   bool
+		devices          []specs.Device
+		expectedMessages []string
+	}{
+		{
+			description: "version is not explained by default",
+			devices: []specs.Device{
+				{
+					Name: "gpu0",
+					ContainerEdits: specs.ContainerEdits{
+						AdditionalGIDs: []uint32{44},
+					},
+				},
+			},
+			expectedMessages: []string{
+				"Generated CDI spec with version 0.7.0",
+			},
+		},
+		{
+			description:    "device and feature are explained",
+			explainVersion: true,
+			devices: []specs.Device{
+				{
+					Name: "gpu0",
+					ContainerEdits: specs.ContainerEdits{
+						Env: []string{"FOO=bar"},
+					},
+				},
+				{
+					Name: "gpu1",
+					ContainerEdits: specs.ContainerEdits{
+						AdditionalGIDs: []uint32{44},
+					},
+				},
+			},
+			expectedMessages: []string{
+				"Generated CDI spec with version 0.7.0",
+				`CDI spec example.com/device requires version 0.7.0 for: device "gpu1" (containerEdits.additionalGids)`,
+			},
+		},
+		{
+			description:    "earliest version is explained",
+			explainVersion: true,
+			devices: []specs.Device{
+				{
+					Name: "gpu0",
+					ContainerEdits: specs.ContainerEdits{
+						Env: []string{"FOO=bar"},
+					},
+				},
+			},
+			expectedMessages: []string{
+				"Generated CDI spec with version 0.3.0",
+				"CDI spec example.com/device requires no features beyond the earliest supported version 0.3.0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+			m := command{logger: logger}
+
+			s, err := spec.New(
+				spec.WithVendor("example.com"),
+				spec.WithClass("device"),
+				spec.WithFormat(spec.FormatYAML),
+				spec.WithDeviceSpecs(tc.devices),
+			)
+			require.NoError(t, err)
+			generated := generatedSpecs{Interface: s}
+			_, err = generated.WriteTo(new(bytes.Buffer))
+			require.NoError(t, err)
+
+			m.logGeneratedSpec(&options{explainVersion: tc.explainVersion}, generated)
+
+			var messages []string
+			for _, entry := range hook.AllEntries() {
+				if entry.Level <= logrus.InfoLevel {
+					messages = append(messages, entry.Message)
+				}
+			}
+			require.EqualValues(t, tc.expectedMessages, messages)
+		})
+	}
+}
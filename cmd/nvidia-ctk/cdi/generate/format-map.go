@@ -0,0 +1,94 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// getFormatMap returns the additional mapping of file extensions to output
+// formats specified using the --format-map flag. The extensions are
+// normalized to lowercase and include the leading '.'.
+func (o *options) getFormatMap() (map[string]string, error) {
+	formatMap := make(map[string]string)
+	for _, value := range o.formatMap {
+		ext, format, found := strings.Cut(value, "=")
+		if !found || ext == "" || format == "" {
+			return nil, fmt.Errorf("invalid format mapping %q: expected EXT=FORMAT", value)
+		}
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		if formatFromFilename(ext) != "" {
+			return nil, fmt.Errorf("invalid format mapping %q: the format of %v files cannot be changed", value, ext)
+		}
+		format = strings.ToLower(format)
+		if format != spec.FormatJSON && format != spec.FormatYAML {
+			return nil, fmt.Errorf("invalid format mapping %q: unsupported format %q", value, format)
+		}
+		formatMap[ext] = format
+	}
+	return formatMap, nil
+}
+
+// outputFormatFromFilename returns the output format implied by the specified
+// file name. The mapping specified using --format-map is consulted before the
+// default mapping.
+func (o *options) outputFormatFromFilename(filename string) string {
+	if format := o.mappedFormat(filename); format != "" {
+		return format
+	}
+	return formatFromFilename(filename)
+}
+
+// mappedFormat returns the format for the specified file name using only the
+// mapping specified using --format-map.
+func (o *options) mappedFormat(filename string) string {
+	formatMap, err := o.getFormatMap()
+	if err != nil {
+		return ""
+	}
+	return formatMap[strings.ToLower(filepath.Ext(filename))]
+}
+
+// saveSpec saves the specified spec to the configured output. Since spec.Save
+// appends a .yaml or .json extension to any other file name, a spec that is
+// output to a file with an extension specified using --format-map is written
+// to the file directly.
+func (o *options) saveSpec(s generatedSpecs) error {
+	filename := s.updateFilename(o.output)
+	if filename == "" || o.mappedFormat(filename) == "" {
+		return s.Save(o.output)
+	}
+
+	doneMarshal := o.timings.track(phaseMarshal)
+	var buf bytes.Buffer
+	if _, err := s.WriteTo(&buf); err != nil {
+		return fmt.Errorf("failed to write CDI spec: %w", err)
+	}
+	doneMarshal()
+	if err := o.writeFileAtomic(filename, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write CDI spec: %w", err)
+	}
+	return nil
+}
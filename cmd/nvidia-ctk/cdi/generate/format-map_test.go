@@ -0,0 +1,123 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestGetFormatMap(t *testing.T) {
+	testCases := []struct {
+		description       string
+		formatMap         []string
+		expectedFormatMap map[string]string
+		expectedError     bool
+	}{
+		{
+			description:       "empty mapping",
+			expectedFormatMap: map[string]string{},
+		},
+		{
+			description:       "extensions are normalized",
+			formatMap:         []string{"conf=json", ".CDI=YAML"},
+			expectedFormatMap: map[string]string{".conf": "json", ".cdi": "yaml"},
+		},
+		{
+			description:   "missing format",
+			formatMap:     []string{"conf"},
+			expectedError: true,
+		},
+		{
+			description:   "unsupported format",
+			formatMap:     []string{"conf=yaml-stream"},
+			expectedError: true,
+		},
+		{
+			description:   "default extensions cannot be remapped",
+			formatMap:     []string{"yaml=json"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := options{formatMap: tc.formatMap}
+			formatMap, err := opts.getFormatMap()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFormatMap, formatMap)
+		})
+	}
+}
+
+func TestSaveSpecWithMappedFormat(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	output := filepath.Join(t.TempDir(), "nvidia.conf")
+	opts := options{
+		output:    output,
+		format:    "yaml",
+		formatMap: []string{"conf=json"},
+		mode:      "nvml",
+		vendor:    "example.com",
+		class:     "device",
+	}
+	require.NoError(t, c.validateFlags(&cli.Command{}, &opts))
+	require.Equal(t, spec.FormatJSON, opts.format)
+
+	s, err := spec.New(
+		spec.WithVendor(opts.vendor),
+		spec.WithClass(opts.class),
+		spec.WithFormat(opts.specFormat()),
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: "0",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, opts.saveSpec(generatedSpecs{Interface: s}))
+
+	contents, err := os.ReadFile(output)
+	require.NoError(t, err)
+	var raw specs.Spec
+	require.NoError(t, json.Unmarshal(contents, &raw))
+	require.Equal(t, "example.com/device", raw.Kind)
+
+	_, err = os.Stat(output + ".json")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
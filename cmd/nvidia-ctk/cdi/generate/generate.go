@@ -17,6 +17,7 @@
 package generate
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -24,15 +25,23 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
 	cdi "tags.cncf.io/container-device-interface/pkg/parser"
 	"tags.cncf.io/container-device-interface/specs-go"
 
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	signing "github.com/NVIDIA/nvidia-container-toolkit/internal/cdi-signing"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/errorformat"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/fsutil"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/tegra/csv"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
@@ -42,8 +51,27 @@ import (
 
 const (
 	allDeviceName = "all"
+
+	minYAMLIndent = 2
+	maxYAMLIndent = 8
+
+	displayAnnotation = "gpu.nvidia.com/display"
+
+	// formatYAMLStream indicates that a standalone spec is generated for each
+	// device and that these are output as a single multi-document YAML stream.
+	formatYAMLStream = "yaml-stream"
 )
 
+// validPlatforms lists the platforms that can be requested when the discovery
+// mode is auto-detected.
+var validPlatforms = []string{
+	string(info.PlatformAuto),
+	string(info.PlatformNVML),
+	string(info.PlatformTegra),
+	string(info.PlatformWSL),
+	string(nvcdi.PlatformVGPU),
+}
+
 type command struct {
 	logger logger.Interface
 
@@ -51,35 +79,144 @@ type command struct {
 }
 
 type options struct {
+	generateConfig       string
 	output               string
+	cdiDir               string
 	format               string
+	formatMap            []string
+	yamlIndent           int
+	noYAMLSeparator      bool
+	explainVersion       bool
+	jsonIndent           bool
 	deviceNameStrategies []string
 	driverRoot           string
 	devRoot              string
 	nvidiaCDIHookPath    string
 	ldconfigPath         string
+	nvidiaSMIPath        string
+	mountBinaries        []string
+	vulkanManifestDir    string
+	emitClockHook        bool
+	applicationClocks    string
+	emitWaitHook         bool
+	waitHookDeviceNodes  []string
+	waitHookTimeout      time.Duration
 	mode                 string
+	platform             string
 	vendor               string
 	class                string
+	displayClass         string
+	migClass             string
+	unsafeKind           bool
 
 	configSearchPaths  []string
 	librarySearchPaths []string
 	disabledHooks      []string
 	enabledHooks       []string
+	noHooks            bool
+
+	deviceFolderMode  string
+	deviceFolderGroup string
 
 	featureFlags []string
 
+	additionalMounts []string
+	mergeEditsFrom   []string
+	mountToolkit     bool
+	noCTKCheck       bool
+	// containerHookPath is the container-internal path of the hook executable
+	// that is referenced by the generated hooks.
+	containerHookPath string
+
+	annotations []string
+
+	driverCapabilities string
+	probeCapabilities  bool
+
+	dumpSchema bool
+
+	timeout      time.Duration
+	printTimings bool
+
+	verbose bool
+
+	dumpDiscovered bool
+
+	fromSnapshot string
+	saveSnapshot string
+
 	csv struct {
 		files               []string
 		ignorePatterns      []string
 		CompatContainerRoot string
 	}
 
-	noAllDevice bool
-	deviceIDs   []string
+	noAllDevice      bool
+	devicePrefix     string
+	strict           bool
+	strictValidation bool
+
+	listQualifiedNames string
+
+	devicesJSON string
+	inventory   *deviceInventory
+
+	watch         bool
+	watchInterval time.Duration
+
+	targetArch string
+
+	probe      bool
+	deviceIDs  []string
+	allowEmpty bool
+	bestEffort bool
+	overwrite  bool
+	dryRun     bool
+	tempDir    string
+
+	requiredDriverVersion string
+
+	headerComment string
+	headerFile    string
+
+	forImage       string
+	forImageRootfs string
+
+	pciBusIDs           []string
+	excludedPCIBusIDs   []string
+	excludedDeviceNodes []string
+	relativeTo          string
+	maxDevices          int
+
+	devicesFromPlugin string
+
+	emitCgroupRules bool
+
+	disableNUMAAnnotations      bool
+	disablePCISerialAnnotations bool
+	noDedupLibraries            bool
+	cudaCompat                  bool
+	nvswitch                    bool
+	persistenced                bool
+	onlyMIGParents              bool
+
+	excludeMIGParentDevices bool
+
+	compatWithLegacyHook bool
+
+	updateContainerEdits bool
+	preserveNamesFrom    string
+
+	signKey string
 
 	// the following are used for dependency injection during spec generation.
 	nvmllib nvml.Interface
+
+	signer *signing.Signer
+
+	// timings records the duration of the phases of spec generation if
+	// printTimings is set.
+	timings *timings
 }
 
 // NewCommand constructs a generate-cdi command with the specified logger
@@ -102,12 +239,30 @@ func (m command) build() *cli.Command {
 		UseShortOptionHandling: true,
 		EnableShellCompletion:  true,
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
-			return ctx, m.validateFlags(cmd, &opts)
+			if opts.dumpSchema {
+				return ctx, nil
+			}
+			if err := applyConfigFile(cmd, opts.generateConfig); err != nil {
+				return ctx, withExitCode(err, ExitCodeValidationError)
+			}
+			return ctx, withExitCode(errorformat.WithComponent(componentValidation, m.validateFlags(cmd, &opts)), ExitCodeValidationError)
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return m.run(&opts)
+			if opts.dumpSchema {
+				return dumpSchema(cmd, os.Stdout)
+			}
+			return m.run(ctx, &opts)
 		},
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: generateConfigFlagName,
+				Usage: "Specify a TOML, YAML, or JSON file from which the options of the generate command are loaded. " +
+					"The keys of the file are the flag names. " +
+					"Flags specified on the command line take precedence over environment variables, " +
+					"which in turn take precedence over the values in the file.",
+				Destination: &opts.generateConfig,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONFIG"),
+			},
 			&cli.StringSliceFlag{
 				Name:        "config-search-path",
 				Usage:       "Specify the path to search for config files when discovering the entities that should be included in the CDI specification.",
@@ -115,18 +270,60 @@ func (m command) build() *cli.Command {
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONFIG_SEARCH_PATHS"),
 			},
 			&cli.StringFlag{
-				Name:        "output",
-				Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
+				Name: "output",
+				Usage: "Specify the file to output the generated CDI specification to. Environment variables such as ${NODE_NAME} are expanded, with unset variables expanding to an empty string. If this is '' the specification is output to STDOUT. " +
+					"If this is of the form unix:///path/to.sock, the specification is written to the specified unix socket instead of a file.",
 				Destination: &opts.output,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_OUTPUT_FILE_PATH"),
 			},
+			&cli.StringFlag{
+				Name: "cdi-dir",
+				Usage: "Specify a CDI spec directory, such as /etc/cdi or /var/run/cdi, to write the generated CDI specification to. " +
+					"The file name is derived from the kind of the specification (e.g. nvidia.com-gpu.yaml) and the directory is created if it does not exist. " +
+					"This cannot be combined with --output.",
+				Destination: &opts.cdiDir,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CDI_DIR"),
+			},
 			&cli.StringFlag{
 				Name:        "format",
-				Usage:       "The output format for the generated spec [json | yaml]. This overrides the format defined by the output file extension (if specified).",
+				Usage:       "The output format for the generated spec [json | yaml | yaml-stream]. This overrides the format defined by the output file extension (if specified). If yaml-stream is specified, a standalone spec is generated for each device and these are output as a single YAML stream.",
 				Value:       spec.FormatYAML,
 				Destination: &opts.format,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_OUTPUT_FORMAT"),
 			},
+			&cli.StringSliceFlag{
+				Name: "format-map",
+				Usage: "Specify an additional mapping of an output file extension to a format [json | yaml] as EXT=FORMAT. " +
+					"For example, specifying conf=json causes an output file with a .conf extension to be written as JSON. " +
+					"The format of .json, .yaml, and .yml files cannot be changed.",
+				Destination: &opts.formatMap,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_FORMAT_MAP"),
+			},
+			&cli.IntFlag{
+				Name:        "yaml-indent",
+				Usage:       "Specify the number of spaces to use for indentation when the CDI specification is output as YAML. This must be between 2 and 8. If this is not specified, an indentation of 4 spaces is used.",
+				Destination: &opts.yamlIndent,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_YAML_INDENT"),
+			},
+			&cli.BoolFlag{
+				Name:        "no-yaml-separator",
+				Usage:       "Omit the leading document separator (---) when the CDI specification is output as YAML. This is not supported for the yaml-stream format.",
+				Destination: &opts.noYAMLSeparator,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NO_YAML_SEPARATOR"),
+			},
+			&cli.BoolFlag{
+				Name:        "explain-version",
+				Usage:       "Log the devices and features that require the minimum CDI specification version of each generated specification.",
+				Destination: &opts.explainVersion,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EXPLAIN_VERSION"),
+			},
+			&cli.BoolFlag{
+				Name:        "json-indent",
+				Aliases:     []string{"pretty"},
+				Usage:       "Indent the generated CDI specification using two spaces when it is output as JSON. If this is not specified, compact JSON is output.",
+				Destination: &opts.jsonIndent,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_JSON_INDENT"),
+			},
 			&cli.StringFlag{
 				Name:    "mode",
 				Aliases: []string{"discovery-mode"},
@@ -139,22 +336,33 @@ func (m command) build() *cli.Command {
 					cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_MODE"),
 				),
 			},
+			&cli.StringFlag{
+				Name: "platform",
+				Usage: "The platform to assume when the discovery mode is 'auto'. " +
+					"One of [" + strings.Join(validPlatforms, " | ") + "]. " +
+					"If platform is set to 'auto' the platform is detected based on the system configuration. " +
+					"Specifying 'tegra' selects CSV-based discovery of the iGPU device nodes and libraries. " +
+					"Specifying 'vgpu' additionally includes the files specific to the GRID guest driver in vGPU virtual machines.",
+				Value:       string(info.PlatformAuto),
+				Destination: &opts.platform,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_PLATFORM"),
+			},
 			&cli.StringFlag{
 				Name:        "dev-root",
-				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed. When set, this takes precedence over the driver-root for locating device nodes.",
 				Destination: &opts.devRoot,
 				Sources:     cli.EnvVars("NVIDIA_CTK_DEV_ROOT"),
 			},
 			&cli.StringSliceFlag{
 				Name:        "device-name-strategy",
-				Usage:       "Specify the strategy for generating device names. If this is specified multiple times, the devices will be duplicated for each strategy. One of [index | uuid | type-index]",
+				Usage:       "Specify the strategy for generating device names. If this is specified multiple times, the devices will be duplicated for each strategy. One of [index | uuid | type-index | mig-profile]",
 				Value:       []string{nvcdi.DeviceNameStrategyIndex, nvcdi.DeviceNameStrategyUUID},
 				Destination: &opts.deviceNameStrategies,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_NAME_STRATEGIES"),
 			},
 			&cli.StringFlag{
 				Name:        "driver-root",
-				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be included in the CDI specification.",
+				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be included in the CDI specification. This root is used to locate driver libraries, binaries, firmware, and IPC sockets. For driver container installations this is typically /run/nvidia/driver.",
 				Destination: &opts.driverRoot,
 				Sources: cli.NewValueSourceChain(
 					cli.EnvVar("NVIDIA_CTK_DRIVER_ROOT"),
@@ -184,6 +392,66 @@ func (m command) build() *cli.Command {
 					cli.EnvVar("NVIDIA_CTK_CDI_GENERATE_LDCONFIG_PATH"),
 				),
 			},
+			&cli.StringFlag{
+				Name: "nvidia-smi-path",
+				Usage: "Specify the path to the nvidia-smi executable to include in the generated CDI specification. " +
+					"This is resolved relative to the driver root and mounted at /usr/bin/nvidia-smi in the container. " +
+					"If this is not specified, nvidia-smi is located in the PATH. " +
+					"nvidia-smi is only included if the utility driver capability is requested.",
+				Destination: &opts.nvidiaSMIPath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NVIDIA_SMI_PATH"),
+			},
+			&cli.StringSliceFlag{
+				Name: "mount-binaries",
+				Usage: "Specify the names of additional host binaries, such as nvidia-persistenced or nv-fabricmanager, to include in the generated CDI specification. " +
+					"The binaries are located in the PATH of the driver root and the libraries that these depend on are also included. " +
+					"Binaries that are not found are skipped with a warning.",
+				Destination: &opts.mountBinaries,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MOUNT_BINARIES"),
+			},
+			&cli.StringFlag{
+				Name: "vulkan-manifest-dir",
+				Usage: "Specify the directory in which copies of the Vulkan ICD and layer manifests are created if their library_path is not valid in the container. " +
+					"The copies reference the library paths in the container and are mounted instead of the original manifests. " +
+					"Since the generated spec refers to these copies, a persistent location such as /var/lib/nvidia-container-toolkit/vulkan should be used. " +
+					"If this is not specified, the manifests are always mounted as is.",
+				Destination: &opts.vulkanManifestDir,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_VULKAN_MANIFEST_DIR"),
+			},
+			&cli.BoolFlag{
+				Name: "emit-clock-hook",
+				Usage: "Include a hook for each full GPU that sets the application clocks specified by --application-clocks when a container is started. " +
+					"Failures to set the clocks, for example due to insufficient permissions, are logged as warnings.",
+				Destination: &opts.emitClockHook,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EMIT_CLOCK_HOOK"),
+			},
+			&cli.StringFlag{
+				Name:        "application-clocks",
+				Usage:       "Specify the application clocks to set as MEMORY,GRAPHICS in MHz. This requires --emit-clock-hook.",
+				Destination: &opts.applicationClocks,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_APPLICATION_CLOCKS"),
+			},
+			&cli.BoolFlag{
+				Name: "emit-wait-hook",
+				Usage: "Include a hook that waits for the device nodes specified by --wait-hook-device-node to exist when a container is started. " +
+					"This is useful on systems where device nodes such as /dev/nvidia-uvm are created lazily.",
+				Destination: &opts.emitWaitHook,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EMIT_WAIT_HOOK"),
+			},
+			&cli.StringSliceFlag{
+				Name:        "wait-hook-device-node",
+				Usage:       "Specify the path of a device node in the container that the wait hook waits for. This can be specified multiple times.",
+				Value:       []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+				Destination: &opts.waitHookDeviceNodes,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WAIT_HOOK_DEVICE_NODES"),
+			},
+			&cli.DurationFlag{
+				Name:        "wait-hook-timeout",
+				Usage:       "Specify the maximum time that the wait hook waits for the device nodes to exist before failing the container start.",
+				Value:       10 * time.Second,
+				Destination: &opts.waitHookTimeout,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WAIT_HOOK_TIMEOUT"),
+			},
 			&cli.StringFlag{
 				Name:        "vendor",
 				Aliases:     []string{"cdi-vendor"},
@@ -200,6 +468,29 @@ func (m command) build() *cli.Command {
 				Destination: &opts.class,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CLASS"),
 			},
+			&cli.BoolFlag{
+				Name: "unsafe-kind",
+				Usage: "Skip the validation of the vendor and class of the generated CDI specification. " +
+					"This allows experimental kinds to be generated for local testing. All other validation is still performed.",
+				Destination: &opts.unsafeKind,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_UNSAFE_KIND"),
+			},
+			&cli.StringFlag{
+				Name: "display-class",
+				Usage: "the class string to use for GPUs that have a display attached. " +
+					"If this is specified, these GPUs are partitioned into a separate CDI specification " +
+					"using this class instead of the class specified by --class.",
+				Destination: &opts.displayClass,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DISPLAY_CLASS"),
+			},
+			&cli.StringFlag{
+				Name: "mig-class",
+				Usage: "the class string to use for MIG devices. " +
+					"If this is specified, MIG devices are partitioned into a separate CDI specification " +
+					"using this class instead of the class specified by --class.",
+				Destination: &opts.migClass,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MIG_CLASS"),
+			},
 			&cli.StringSliceFlag{
 				Name:        "csv.file",
 				Usage:       "The path to the list of CSV files to use when generating the CDI specification in CSV mode.",
@@ -236,6 +527,28 @@ func (m command) build() *cli.Command {
 				Destination: &opts.enabledHooks,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_ENABLED_HOOKS"),
 			},
+			&cli.StringFlag{
+				Name: "device-folder-mode",
+				Usage: "Specify the octal file mode that is applied to the parent folders of nested device nodes such as /dev/dri and /dev/nvidia-caps in the container. " +
+					"Specifying a mode enables the chmod hook. If this is not specified, a mode of 755 is used when the chmod hook is enabled.",
+				Destination: &opts.deviceFolderMode,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_FOLDER_MODE"),
+			},
+			&cli.StringFlag{
+				Name: "device-folder-group",
+				Usage: "Specify the group (name or numeric ID) that is assigned to the parent folders of nested device nodes such as /dev/dri and /dev/nvidia-caps in the container. " +
+					"Group names are resolved to an ID on the host when the specification is generated. Specifying a group enables the chmod hook.",
+				Destination: &opts.deviceFolderGroup,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_FOLDER_GROUP"),
+			},
+			&cli.BoolFlag{
+				Name: "no-hooks",
+				Usage: "Generate a CDI specification that only includes device nodes, mounts, and environment variables. " +
+					"This is required for OCI runtimes that do not support CDI hooks and takes precedence over explicitly enabled hooks. " +
+					"Note that features that rely on hooks, such as updating the ldcache in the container, are not applied.",
+				Destination: &opts.noHooks,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NO_HOOKS"),
+			},
 			&cli.StringSliceFlag{
 				Name:        "feature-flag",
 				Aliases:     []string{"feature-flags"},
@@ -249,14 +562,412 @@ func (m command) build() *cli.Command {
 				Destination: &opts.noAllDevice,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NO_ALL_DEVICE"),
 			},
+			&cli.BoolFlag{
+				Name: "strict",
+				Usage: "Treat conflicting environment variables in the devices that are merged into the `all` device as an error. " +
+					"By default, a warning listing the conflicts is logged.",
+				Destination: &opts.strict,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_STRICT"),
+			},
+			&cli.BoolFlag{
+				Name: "strict-validation",
+				Usage: "Validate the generated CDI specifications before writing them and report all validation errors. " +
+					"No output is written if a specification is invalid.",
+				Destination: &opts.strictValidation,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_STRICT_VALIDATION"),
+			},
+			&cli.StringFlag{
+				Name: "device-prefix",
+				Usage: "Specify a prefix to add to the name of each generated device. " +
+					"The prefix is separated from the device name by a '-'; for example, the all device is named `PREFIX-all`.",
+				Destination: &opts.devicePrefix,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_PREFIX"),
+			},
+			&cli.BoolFlag{
+				Name: "probe",
+				Usage: "Generate a minimal spec that only records the number of GPUs and their UUIDs as annotations. " +
+					"No discovery of device nodes or driver files is performed. " +
+					"This can be used as a lightweight check that the GPUs on the system can be enumerated.",
+				Destination: &opts.probe,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_PROBE"),
+			},
+			&cli.StringFlag{
+				Name: "target-arch",
+				Usage: "Specify the architecture that injected libraries are expected to match. " +
+					"A warning is logged for each library in the generated spec with a different ELF machine type. " +
+					"If not specified, the architecture of the host is used.",
+				Destination: &opts.targetArch,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_TARGET_ARCH"),
+			},
+			&cli.StringFlag{
+				Name: "list-qualified-names",
+				Usage: "Write the fully-qualified CDI names (e.g. nvidia.com/gpu=0) of the generated devices to the specified file, one per line. " +
+					"Specify '-' to write the names to STDOUT.",
+				Destination: &opts.listQualifiedNames,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_LIST_QUALIFIED_NAMES"),
+			},
+			&cli.StringFlag{
+				Name: "devices-json",
+				Usage: "Write a JSON inventory of the generated full GPU and MIG devices to the specified file. " +
+					"For each device the names, UUID, memory, MIG profile, and compute capability are included.",
+				Destination: &opts.devicesJSON,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICES_JSON"),
+			},
+			&cli.BoolFlag{
+				Name:        "overwrite",
+				Aliases:     []string{"force"},
+				Usage:       "Overwrite the output file if it already exists. Set to false to fail instead of replacing an existing file",
+				Value:       true,
+				Destination: &opts.overwrite,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_OVERWRITE"),
+			},
+			&cli.BoolFlag{
+				Name: "dry-run",
+				Usage: "Log the destinations that the generated CDI specifications would be written to, whether these exist, and the format and size of each " +
+					"without writing any files.",
+				Destination: &opts.dryRun,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DRY_RUN"),
+			},
+			&cli.StringFlag{
+				Name: "temp-dir",
+				Usage: "The directory in which temporary files are created before being renamed to the output. " +
+					"By default, these are created in the directory of the output so that the rename is atomic. " +
+					"If the specified directory is on a different filesystem, the files are copied to the output instead.",
+				Destination: &opts.tempDir,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_TEMP_DIR"),
+			},
+			&cli.BoolFlag{
+				Name: "emit-cgroup-rules",
+				Usage: "Include the device type for each device node in the generated CDI specification. " +
+					"Along with the major and minor numbers and permissions, this fully specifies the cgroup device rules for the devices " +
+					"so that these do not need to be derived from the device nodes when a container is created.",
+				Destination: &opts.emitCgroupRules,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EMIT_CGROUP_RULES"),
+			},
+			&cli.BoolFlag{
+				Name: "disable-numa-annotations",
+				Usage: "Do not annotate the generated device specifications with the NUMA node of each GPU. " +
+					"By default, the nvidia.com/numa-node annotation is added for devices where NVML reports a NUMA node.",
+				Destination: &opts.disableNUMAAnnotations,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DISABLE_NUMA_ANNOTATIONS"),
+			},
+			&cli.BoolFlag{
+				Name: "disable-pci-serial-annotations",
+				Usage: "Do not annotate the generated device specifications with the PCI address and board serial number of each GPU. " +
+					"By default, the nvidia.com/pci-address and nvidia.com/board-serial annotations are added for devices where NVML reports these values.",
+				Destination: &opts.disablePCISerialAnnotations,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DISABLE_PCI_SERIAL_ANNOTATIONS"),
+			},
+			&cli.BoolFlag{
+				Name: "no-dedup-libraries",
+				Usage: "Do not collapse driver library mounts that resolve to the same file on the host. " +
+					"By default, only a single mount is included for such libraries and the remaining paths are created as symlinks in the container.",
+				Destination: &opts.noDedupLibraries,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NO_DEDUP_LIBRARIES"),
+			},
+			&cli.BoolFlag{
+				Name: "cuda-compat",
+				Usage: "Include the CUDA forward compatibility libraries installed under the driver root (e.g. in /usr/local/cuda/compat). " +
+					"An enable-cuda-compat hook is added so that these take precedence over the driver libraries if they are newer than the host driver.",
+				Destination: &opts.cudaCompat,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CUDA_COMPAT"),
+			},
+			&cli.BoolFlag{
+				Name: "only-mig-parents",
+				Usage: "Only generate full GPU devices, even for GPUs with MIG mode enabled. " +
+					"MIG devices are not included in the generated spec.",
+				Destination: &opts.onlyMIGParents,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_ONLY_MIG_PARENTS"),
+			},
+			&cli.BoolFlag{
+				Name: "exclude-mig-parent-devices",
+				Usage: "Exclude GPUs with MIG mode enabled from the generated spec. Set to false to include a placeholder device " +
+					"for each such GPU alongside its MIG devices. A placeholder device is annotated with " +
+					nvcdi.AllocatableAnnotation + "=false, does not make the GPU available in a container, and is not included in the 'all' device.",
+				Value:       true,
+				Destination: &opts.excludeMIGParentDevices,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EXCLUDE_MIG_PARENT_DEVICES"),
+			},
+			&cli.BoolFlag{
+				Name: "nvswitch",
+				Usage: "Include the NVSwitch device nodes and the nvidia-fabricmanager socket in the common edits. " +
+					"This is required by multi-GPU collectives on NVSwitch-based systems and has no effect on other systems.",
+				Destination: &opts.nvswitch,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NVSWITCH"),
+			},
+			&cli.BoolFlag{
+				Name: "persistenced",
+				Usage: "Include the nvidia-persistenced socket in the common edits. " +
+					"This is required by workloads that use persistence mode. The socket is skipped if nvidia-persistenced is not running. " +
+					"Specify --persistenced=false to exclude the socket.",
+				Value:       true,
+				Destination: &opts.persistenced,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_PERSISTENCED"),
+			},
+			&cli.BoolFlag{
+				Name: "compat-with-legacy-hook",
+				Usage: "Include a marker environment variable in the generated CDI specification. " +
+					"The legacy NVIDIA Container Runtime Hook does not inject devices or driver files into containers with this marker set. " +
+					"This allows the hook and CDI to be used together during a migration without injecting the same entities twice.",
+				Destination: &opts.compatWithLegacyHook,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_COMPAT_WITH_LEGACY_HOOK"),
+			},
+			&cli.BoolFlag{
+				Name: "update-container-edits",
+				Usage: "Only regenerate the common container edits of the existing CDI specification at the output path. " +
+					"The devices in the existing specification are left unmodified. " +
+					"This allows a specification to be updated after a driver upgrade without changing the device names.",
+				Destination: &opts.updateContainerEdits,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_UPDATE_CONTAINER_EDITS"),
+			},
+			&cli.StringFlag{
+				Name: "preserve-names-from",
+				Usage: "Preserve the names of the devices in the specified previously generated CDI specification. " +
+					"Devices are matched by UUID so that a device keeps its name if its index changes. " +
+					"The UUID of each device is recorded in the " + nvcdi.UUIDAnnotation + " device annotation. " +
+					"If the specification does not exist, the generated names are used.",
+				Destination: &opts.preserveNamesFrom,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_PRESERVE_NAMES_FROM"),
+			},
+			&cli.StringFlag{
+				Name: "sign-key",
+				Usage: "Sign the generated CDI specification using the PEM-encoded Ed25519 private key in the specified file. " +
+					"A detached signature is written to a sidecar file with a .sig extension and the ID of the key is " +
+					"recorded in the " + signing.SigningKeyAnnotation + " spec annotation. This requires an output path to be specified.",
+				Destination: &opts.signKey,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_SIGN_KEY"),
+			},
+			&cli.BoolFlag{
+				Name:        "allow-empty",
+				Usage:       "Skip GPUs that would not result in any CDI devices (e.g. GPUs with MIG mode enabled but no MIG devices configured) with a warning instead of failing",
+				Destination: &opts.allowEmpty,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_ALLOW_EMPTY"),
+			},
+			&cli.BoolFlag{
+				Name: "best-effort",
+				Usage: "Skip devices for which the CDI device specifications cannot be generated instead of failing. " +
+					"The specification is written for the remaining devices and the failures are reported with an exit code of 5.",
+				Destination: &opts.bestEffort,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_BEST_EFFORT"),
+			},
+			&cli.StringFlag{
+				Name:    "capabilities",
+				Aliases: []string{"driver-capabilities"},
+				Usage: "Specify a comma-separated list of driver capabilities that the driver libraries, binaries, and device nodes " +
+					"included in the generated CDI specification are restricted to. " +
+					"This uses the same values as the NVIDIA_DRIVER_CAPABILITIES environment variable " +
+					"[compute | compat32 | graphics | utility | video | display | ngx | all].",
+				Value:       string(image.DriverCapabilityAll),
+				Destination: &opts.driverCapabilities,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DRIVER_CAPABILITIES"),
+			},
+			&cli.BoolFlag{
+				Name: "probe-capabilities",
+				Usage: "Probe the devices using NVML and only include driver libraries for optional hardware engines if at least one device supports the engine. " +
+					"For example, the NVENC encode library is excluded if no device has an NVENC engine.",
+				Destination: &opts.probeCapabilities,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_PROBE_CAPABILITIES"),
+			},
+			&cli.StringSliceFlag{
+				Name: "additional-mount",
+				Usage: "Specify an additional mount to include in the common edits of the generated CDI specification. " +
+					"This is specified as host:container[:options] where options is a comma-separated list of mount options. " +
+					"If no options are specified, the mount is read-only. This can be specified multiple times.",
+				Destination: &opts.additionalMounts,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_ADDITIONAL_MOUNTS"),
+			},
+			&cli.BoolFlag{
+				Name: "mount-toolkit",
+				Usage: "Mount the nvidia-ctk and nvidia-cdi-hook binaries into the container. " +
+					"The binaries are located in the directory of the running nvidia-ctk executable and are mounted at the nvidia-cdi-hook path referenced by the generated hooks. " +
+					"This allows the toolkit to be used in containers where the base image does not include it.",
+				Destination: &opts.mountToolkit,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MOUNT_TOOLKIT"),
+			},
+			&cli.BoolFlag{
+				Name: "no-ctk-check",
+				Usage: "Skip checking that the nvidia-cdi-hook or nvidia-ctk executable referenced by the generated hooks exists. " +
+					"By default, the command fails if the executable is not found so that containers do not fail when the hooks are run.",
+				Destination: &opts.noCTKCheck,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_NO_CTK_CHECK"),
+			},
+			&cli.StringFlag{
+				Name: "container-hook-path",
+				Usage: "Specify a container-internal path of the nvidia-ctk or nvidia-cdi-hook executable to reference in the generated hooks. " +
+					"This implies --mount-toolkit so that the binaries are present at the specified path. " +
+					"The path must be absolute and cannot be combined with --nvidia-cdi-hook-path.",
+				Destination: &opts.containerHookPath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_CONTAINER_HOOK_PATH"),
+			},
+			&cli.StringSliceFlag{
+				Name: "merge-edits-from",
+				Usage: "Specify a YAML or JSON file containing container edits to include in the common edits of the generated CDI specification. " +
+					"Entries that match discovered entries are skipped. This can be specified multiple times.",
+				Destination: &opts.mergeEditsFrom,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MERGE_EDITS_FROM"),
+			},
 			&cli.StringSliceFlag{
-				Name:        "device-id",
-				Aliases:     []string{"device-ids", "device", "devices"},
-				Usage:       "Restrict generation to the specified device identifiers",
+				Name:    "annotation",
+				Aliases: []string{"label"},
+				Usage: "Specify an annotation to add to the generated CDI specification as key=value. " +
+					"An annotation is added to a single device instead by specifying it as device-name:key=value. " +
+					"Keys must be valid CDI annotation keys. This can be specified multiple times.",
+				Destination: &opts.annotations,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_ANNOTATIONS"),
+			},
+			&cli.DurationFlag{
+				Name: "timeout",
+				Usage: "Specify the maximum duration for discovering the entities to include in the generated CDI specification " +
+					"(e.g. 30s or 2m). If this is exceeded, the command fails. A value of 0 disables the timeout. " +
+					"This cannot be combined with --watch.",
+				Destination: &opts.timeout,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_TIMEOUT"),
+			},
+			&cli.BoolFlag{
+				Name: "timings",
+				Usage: "Print the duration of each phase of spec generation (NVML initialization, device discovery, MIG discovery, " +
+					"common discovery, marshaling, and writing) to STDERR as JSON.",
+				Destination: &opts.printTimings,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_TIMINGS"),
+			},
+			&cli.BoolFlag{
+				Name: "verbose",
+				Usage: "Log whether each enumerated device is included in the generated spec or skipped, and why, at info level. " +
+					"If this is not specified, these messages are logged at debug level.",
+				Destination: &opts.verbose,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_VERBOSE"),
+			},
+			&cli.BoolFlag{
+				Name: "watch",
+				Usage: "Run as a long-lived process that regenerates the CDI specification at the interval specified by --watch-interval. " +
+					"The output file is only rewritten if the generated specification changes. This requires --output to be specified.",
+				Destination: &opts.watch,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WATCH"),
+			},
+			&cli.DurationFlag{
+				Name:        "watch-interval",
+				Usage:       "Specify the interval at which the CDI specification is regenerated if --watch is specified.",
+				Value:       defaultWatchInterval,
+				Destination: &opts.watchInterval,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_WATCH_INTERVAL"),
+			},
+			&cli.BoolFlag{
+				Name:        "dump-discovered",
+				Usage:       "Log the entities found by each discoverer before these are converted to CDI edits. This requires debug logging to be enabled.",
+				Destination: &opts.dumpDiscovered,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DUMP_DISCOVERED"),
+			},
+			&cli.StringFlag{
+				Name: "from-snapshot",
+				Usage: "Generate the CDI specification from the devices recorded in the specified NVML snapshot file instead of querying NVML. " +
+					"This allows a specification to be generated on a system where the devices are not present.",
+				Destination: &opts.fromSnapshot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_FROM_SNAPSHOT"),
+			},
+			&cli.StringFlag{
+				Name:        "save-snapshot",
+				Usage:       "Save a snapshot of the devices reported by NVML to the specified file. This can be used with --from-snapshot.",
+				Destination: &opts.saveSnapshot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_SAVE_SNAPSHOT"),
+			},
+			&cli.BoolFlag{
+				Name:        dumpSchemaFlagName,
+				Usage:       "Output a JSON description of the flags supported by this command and exit",
+				Hidden:      true,
+				Destination: &opts.dumpSchema,
+			},
+			&cli.StringSliceFlag{
+				Name:    "device-id",
+				Aliases: []string{"device-ids", "device", "devices"},
+				Usage: "Restrict generation to the specified device identifiers. " +
+					"Device indices, GPU UUIDs, and MIG UUIDs (MIG-<uuid>) are supported. " +
+					"Glob patterns (e.g. gpu* or mig0:*) are matched against the generated device names.",
 				Value:       []string{"all"},
 				Destination: &opts.deviceIDs,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICE_IDS"),
 			},
+			&cli.StringFlag{
+				Name: "devices-from-plugin",
+				Usage: "Generate device specifications for exactly the devices listed in the specified Kubernetes device plugin device list file. " +
+					"The file contains either a JSON array of device IDs or one device ID per line. " +
+					"Device UUIDs and indices are supported and replica suffixes (e.g. GPU-<uuid>::1) are ignored. " +
+					"Generation fails if a listed device cannot be resolved. This cannot be combined with --device-id.",
+				Destination: &opts.devicesFromPlugin,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_DEVICES_FROM_PLUGIN"),
+			},
+			&cli.StringSliceFlag{
+				Name: "pci-bus-id",
+				Usage: "Restrict the devices included in the generated CDI specification to those with the specified PCI bus IDs " +
+					"(e.g. 0000:07:00.0). If a device with one of the specified bus IDs is not found, the command fails. " +
+					"This can be specified multiple times.",
+				Destination: &opts.pciBusIDs,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_PCI_BUS_IDS"),
+			},
+			&cli.StringSliceFlag{
+				Name:        "exclude-pci-bus-id",
+				Usage:       "Exclude the devices with the specified PCI bus IDs from the generated CDI specification. This can be specified multiple times.",
+				Destination: &opts.excludedPCIBusIDs,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EXCLUDE_PCI_BUS_IDS"),
+			},
+			&cli.StringFlag{
+				Name: "header-comment",
+				Usage: "Include the specified comment, such as a license notice, in the generated CDI specifications. " +
+					"For YAML specifications this is written as leading comment lines. " +
+					"For JSON specifications this is added as the cdi.nvidia.com/header-comment annotation.",
+				Destination: &opts.headerComment,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_HEADER_COMMENT"),
+			},
+			&cli.StringFlag{
+				Name:        "header-file",
+				Usage:       "Read the header comment to include in the generated CDI specifications from the specified file. This cannot be combined with --header-comment.",
+				Destination: &opts.headerFile,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_HEADER_FILE"),
+			},
+			&cli.StringFlag{
+				Name: "for-image",
+				Usage: "Record the specified container image reference (e.g. registry.example.com/app@sha256:<digest>) " +
+					"in the generated CDI specifications as the cdi.nvidia.com/image annotation.",
+				Destination: &opts.forImage,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_FOR_IMAGE"),
+			},
+			&cli.StringFlag{
+				Name: "for-image-rootfs",
+				Usage: "Specify the path to the unpacked or mounted root filesystem of the image specified by --for-image. " +
+					"If set, the C library used by the image is detected and recorded as the cdi.nvidia.com/image-libc annotation.",
+				Destination: &opts.forImageRootfs,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_FOR_IMAGE_ROOTFS"),
+			},
+			&cli.StringFlag{
+				Name: "require-driver-version",
+				Usage: "Fail if the driver version reported by NVML does not match the specified version. " +
+					"Specify an exact version (e.g. 550.54.15) or a minimum version (e.g. >=550.54.15).",
+				Destination: &opts.requiredDriverVersion,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_REQUIRE_DRIVER_VERSION"),
+			},
+			&cli.StringSliceFlag{
+				Name: "exclude-device-node",
+				Usage: "Exclude the device nodes whose container path matches the specified glob (e.g. /dev/nvidia-uvm*) from the " +
+					"generated CDI specification. This applies to the device nodes of the individual devices and those common to all devices. " +
+					"This can be specified multiple times.",
+				Destination: &opts.excludedDeviceNodes,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_EXCLUDE_DEVICE_NODES"),
+			},
+			&cli.StringFlag{
+				Name: "relative-to",
+				Usage: "Rewrite the host paths of mounts below the specified base (e.g. the driver root) to be relative to the base. " +
+					"The relative paths are re-anchored at the driver root of the node by the NVIDIA Container Runtime when a container is started. " +
+					"Since the relative paths are not resolved by runtimes with native CDI support, the generated spec is only usable with the NVIDIA Container Runtime.",
+				Destination: &opts.relativeTo,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_RELATIVE_TO"),
+			},
+			&cli.IntFlag{
+				Name: "max-devices",
+				Usage: "Limit the number of GPUs included in the generated CDI specification to the first N GPUs " +
+					"(after PCI bus ID filtering). The limit counts physical GPUs; all MIG devices of an included GPU are also included. " +
+					"This only applies when generating devices for all GPUs.",
+				Destination: &opts.maxDevices,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_GENERATE_MAX_DEVICES"),
+			},
 		},
 	}
 
@@ -268,15 +979,49 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 	switch opts.format {
 	case spec.FormatJSON:
 	case spec.FormatYAML:
+	case formatYAMLStream:
 	default:
 		return fmt.Errorf("invalid output format: %v", opts.format)
 	}
 
+	if _, err := opts.getApplicationClocks(); err != nil {
+		return err
+	}
+
+	if _, err := opts.getDeviceNodeWait(); err != nil {
+		return err
+	}
+
+	if opts.tempDir != "" {
+		info, err := os.Stat(opts.tempDir)
+		if err != nil {
+			return fmt.Errorf("invalid temporary directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("invalid temporary directory: %v is not a directory", opts.tempDir)
+		}
+	}
+
+	if opts.yamlIndent != 0 && (opts.yamlIndent < minYAMLIndent || opts.yamlIndent > maxYAMLIndent) {
+		return fmt.Errorf("invalid YAML indentation %d: must be between %d and %d", opts.yamlIndent, minYAMLIndent, maxYAMLIndent)
+	}
+
+	if opts.noYAMLSeparator && opts.format == formatYAMLStream {
+		return fmt.Errorf("the --no-yaml-separator flag is not supported for format %q", formatYAMLStream)
+	}
+
 	opts.mode = strings.ToLower(opts.mode)
 	if !nvcdi.IsValidMode(opts.mode) {
 		return fmt.Errorf("invalid discovery mode: %v", opts.mode)
 	}
 
+	if opts.platform != "" && !slices.Contains(validPlatforms, opts.platform) {
+		return fmt.Errorf("invalid platform: %v", opts.platform)
+	}
+	if opts.platform != "" && opts.platform != string(info.PlatformAuto) && opts.mode != string(nvcdi.ModeAuto) {
+		return fmt.Errorf("the --platform flag can only be specified with --mode=%v", nvcdi.ModeAuto)
+	}
+
 	for _, strategy := range opts.deviceNameStrategies {
 		_, err := nvcdi.NewDeviceNamer(strategy)
 		if err != nil {
@@ -284,22 +1029,135 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		}
 	}
 
-	opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
+	if opts.containerHookPath != "" {
+		if err := validateContainerHookPath(opts.containerHookPath); err != nil {
+			return err
+		}
+		if opts.nvidiaCDIHookPath != "" {
+			return fmt.Errorf("--container-hook-path cannot be specified with --nvidia-cdi-hook-path")
+		}
+		// The container-internal path is used as is since the executable is
+		// not expected to exist on the host.
+		opts.nvidiaCDIHookPath = opts.containerHookPath
+	} else {
+		opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
+	}
+
+	// We expand environment variables in the output path to allow for it to
+	// be templated without requiring a shell. Unset variables expand to an
+	// empty string.
+	opts.output = os.ExpandEnv(opts.output)
+
+	if err := opts.setOutputFromCDIDir(); err != nil {
+		return err
+	}
 
-	if outputFileFormat := formatFromFilename(opts.output); outputFileFormat != "" {
+	if _, err := opts.getFormatMap(); err != nil {
+		return err
+	}
+
+	if err := opts.validateUnixSocketOutput(); err != nil {
+		return err
+	}
+
+	if outputFileFormat := opts.outputFormatFromFilename(opts.output); outputFileFormat != "" && !opts.isUnixSocketOutput() {
 		m.logger.Debugf("Inferred output format as %q from output file name", outputFileFormat)
 		if !c.IsSet("format") {
 			opts.format = outputFileFormat
-		} else if outputFileFormat != opts.format {
+		} else if opts.format == formatYAMLStream && outputFileFormat == spec.FormatJSON {
+			return fmt.Errorf("output format %q is not supported for JSON output files", opts.format)
+		} else if outputFileFormat != opts.specFormat() {
 			m.logger.Warningf("Requested output format %q does not match format implied by output file name: %q", opts.format, outputFileFormat)
 		}
 	}
 
-	if err := cdi.ValidateVendorName(opts.vendor); err != nil {
-		return fmt.Errorf("invalid CDI vendor name: %v", err)
+	if opts.unsafeKind {
+		m.logger.Warningf("Skipping validation of the CDI vendor and class; the generated specification may not be usable by CDI-enabled runtimes")
+	} else {
+		if err := cdi.ValidateVendorName(opts.vendor); err != nil {
+			return fmt.Errorf("invalid CDI vendor name: %v", err)
+		}
+		if err := cdi.ValidateClassName(opts.class); err != nil {
+			return fmt.Errorf("invalid CDI class name: %v", err)
+		}
 	}
-	if err := cdi.ValidateClassName(opts.class); err != nil {
-		return fmt.Errorf("invalid CDI class name: %v", err)
+	if opts.maxDevices < 0 || (opts.maxDevices == 0 && c != nil && c.IsSet("max-devices")) {
+		return fmt.Errorf("invalid value for --max-devices %d: must be positive", opts.maxDevices)
+	}
+
+	if err := validateDevicePatterns(opts.deviceIDs); err != nil {
+		return err
+	}
+
+	if opts.devicesFromPlugin != "" {
+		if c != nil && c.IsSet("device-id") {
+			return fmt.Errorf("the --devices-from-plugin and --device-id flags are mutually exclusive")
+		}
+		if _, err := opts.getDeviceIDs(); err != nil {
+			return err
+		}
+	}
+
+	if opts.probe && opts.updateContainerEdits {
+		return fmt.Errorf("the --probe and --update-container-edits flags are mutually exclusive")
+	}
+
+	if opts.onlyMIGParents && !opts.excludeMIGParentDevices {
+		return fmt.Errorf("the --only-mig-parents flag cannot be used with --exclude-mig-parent-devices=false")
+	}
+
+	if err := opts.validateRelativeTo(); err != nil {
+		return err
+	}
+
+	if err := opts.validateWatch(); err != nil {
+		return err
+	}
+
+	if opts.devicesJSON != "" && opts.updateContainerEdits {
+		return fmt.Errorf("the --update-container-edits and --devices-json flags are mutually exclusive")
+	}
+
+	if opts.preserveNamesFrom != "" {
+		if opts.probe {
+			return fmt.Errorf("the --probe and --preserve-names-from flags are mutually exclusive")
+		}
+		if opts.updateContainerEdits {
+			return fmt.Errorf("the --update-container-edits and --preserve-names-from flags are mutually exclusive")
+		}
+	}
+
+	if _, err := opts.getTargetArch(); err != nil {
+		return err
+	}
+
+	if opts.listQualifiedNames == qualifiedNamesStdout && opts.output == "" {
+		return fmt.Errorf("the qualified device names cannot be written to STDOUT when the CDI spec is written to STDOUT")
+	}
+
+	if opts.devicePrefix != "" {
+		if err := cdi.ValidateDeviceName(opts.devicePrefix); err != nil {
+			return fmt.Errorf("invalid device prefix: %v", err)
+		}
+	}
+	if opts.displayClass != "" {
+		if err := cdi.ValidateClassName(opts.displayClass); err != nil && !opts.unsafeKind {
+			return fmt.Errorf("invalid CDI display class name: %v", err)
+		}
+		if opts.displayClass == opts.class {
+			return fmt.Errorf("the display class must differ from the class %q", opts.class)
+		}
+	}
+	if opts.migClass != "" {
+		if err := cdi.ValidateClassName(opts.migClass); err != nil && !opts.unsafeKind {
+			return fmt.Errorf("invalid CDI MIG class name: %v", err)
+		}
+		if opts.migClass == opts.class || opts.migClass == opts.displayClass {
+			return fmt.Errorf("the MIG class must differ from the class %q and the display class", opts.class)
+		}
+		if opts.format == formatYAMLStream {
+			return fmt.Errorf("the --mig-class flag is not supported for format %q", formatYAMLStream)
+		}
 	}
 
 	for _, hook := range opts.enabledHooks {
@@ -308,6 +1166,92 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 		}
 	}
 
+	if opts.noHooks {
+		if len(opts.enabledHooks) > 0 {
+			return fmt.Errorf("the --no-hooks and --enable-hook flags are mutually exclusive")
+		}
+		if opts.deviceFolderMode != "" || opts.deviceFolderGroup != "" {
+			return fmt.Errorf("the --no-hooks flag cannot be used with --device-folder-mode or --device-folder-group")
+		}
+		m.logger.Warningf("Generating a CDI specification without hooks; " +
+			"the ldcache in the container will not be updated and no symlinks will be created for the injected libraries")
+	}
+
+	for _, capability := range image.NewDriverCapabilities(opts.driverCapabilities).List() {
+		if capability == string(image.DriverCapabilityAll) {
+			continue
+		}
+		if !image.SupportedDriverCapabilities.Has(image.DriverCapability(capability)) {
+			return fmt.Errorf("invalid driver capability: %v", capability)
+		}
+	}
+
+	if _, err := opts.getAnnotations(); err != nil {
+		return err
+	}
+
+	if _, err := opts.getAdditionalMounts(); err != nil {
+		return err
+	}
+
+	if _, err := opts.getMergedEdits(); err != nil {
+		return err
+	}
+
+	if err := opts.validateExcludedDeviceNodes(); err != nil {
+		return err
+	}
+
+	if err := opts.validateMountBinaries(); err != nil {
+		return err
+	}
+
+	if _, err := opts.getDeviceFolderPermissionOptions(); err != nil {
+		return err
+	}
+
+	if _, err := opts.getRequiredDriverVersion(); err != nil {
+		return err
+	}
+
+	if _, err := opts.getHeaderComment(); err != nil {
+		return err
+	}
+
+	if err := m.validateForImage(opts); err != nil {
+		return err
+	}
+
+	if opts.fromSnapshot != "" && opts.saveSnapshot != "" {
+		return fmt.Errorf("the --from-snapshot and --save-snapshot flags are mutually exclusive")
+	}
+
+	if opts.dryRun {
+		if opts.watch {
+			return fmt.Errorf("the --dry-run and --watch flags are mutually exclusive")
+		}
+		if opts.saveSnapshot != "" {
+			return fmt.Errorf("the --dry-run and --save-snapshot flags are mutually exclusive")
+		}
+	}
+
+	if opts.updateContainerEdits {
+		if opts.output == "" {
+			return fmt.Errorf("the --update-container-edits flag requires an output path")
+		}
+		if opts.format == formatYAMLStream {
+			return fmt.Errorf("the --update-container-edits flag is not supported for format %q", formatYAMLStream)
+		}
+	}
+
+	if opts.signKey != "" {
+		signer, err := opts.getSigner()
+		if err != nil {
+			return err
+		}
+		opts.signer = signer
+	}
+
 	if slices.Contains(opts.deviceIDs, "none") && !opts.noAllDevice {
 		m.logger.Warningf("Disabling generation of 'all' device")
 		opts.noAllDevice = true
@@ -315,21 +1259,256 @@ func (m command) validateFlags(c *cli.Command, opts *options) error {
 	return nil
 }
 
-func (m command) run(opts *options) error {
-	specs, err := m.generateSpecs(opts)
+func (m command) run(ctx context.Context, opts *options) error {
+	if opts.printTimings {
+		opts.timings = newTimings()
+		defer func() {
+			if err := opts.timings.writeJSON(os.Stderr); err != nil {
+				m.logger.Warningf("%v", err)
+			}
+		}()
+	}
+
+	if err := opts.checkHookExecutable(); err != nil {
+		return withExitCode(err, ExitCodeValidationError)
+	}
+
+	if opts.watch {
+		return m.watch(ctx, opts)
+	}
+
+	_, err := m.generateAndWrite(ctx, opts, nil)
+	return err
+}
+
+// generateAndWrite generates the CDI specs and writes them to the configured
+// output. When watching for changes, the output is not rewritten if the
+// generated specs match the specified previous state and the output files of
+// the previous state that are no longer generated are removed. The state of the
+// specs that were written is returned.
+func (m command) generateAndWrite(ctx context.Context, opts *options, previous *watchState) (*watchState, error) {
+	if opts.devicesJSON != "" {
+		opts.inventory = newDeviceInventory()
+	}
+
+	specs, err := m.generateSpecsWithTimeout(ctx, opts)
+	var partialErr *nvcdi.PartialDiscoveryError
+	if errors.As(err, &partialErr) {
+		for _, deviceErr := range partialErr.Errors {
+			m.logger.Warningf("Skipping device %v: %v", deviceErr.ID, deviceErr.Err)
+		}
+	} else if err != nil {
+		return nil, withExitCode(fmt.Errorf("failed to generate CDI spec: %w", err), ExitCodeDiscoveryError)
+	}
+
+	m.warnOnLibraryArchMismatch(opts, specs)
+
+	var state *watchState
+	if opts.watch {
+		state, err = opts.getWatchState(specs)
+		if err != nil {
+			return nil, withExitCode(err, ExitCodeOutputError)
+		}
+		if previous.isUnchanged(state) {
+			m.logger.Debugf("CDI spec is unchanged; skipping write")
+			return state, nil
+		}
+	}
+
+	if err := m.writeSpecs(opts, specs); err != nil {
+		return nil, errorformat.WithComponent(componentOutput, err)
+	}
+	if opts.watch {
+		// The output files written by a previous iteration of the watcher
+		// are replaced even if overwriting existing files is disabled.
+		opts.overwrite = true
+		m.removeStaleOutputs(previous, state)
+	}
+
+	if partialErr != nil {
+		return state, withExitCode(fmt.Errorf("generated a partial CDI spec: %w", partialErr), ExitCodePartialDiscoveryError)
+	}
+	return state, nil
+}
+
+// writeSpecs writes the specified specs to the configured output.
+func (m command) writeSpecs(opts *options, specs []generatedSpecs) error {
+	if err := opts.checkOverwrite(specs); err != nil {
+		return withExitCode(err, ExitCodeOutputError)
+	}
+
+	missing, err := opts.annotateSpecs(specs)
 	if err != nil {
-		return fmt.Errorf("failed to generate CDI spec: %v", err)
+		return withExitCode(err, ExitCodeOutputError)
+	}
+	for _, device := range missing {
+		m.logger.Warningf("Ignoring annotations for device %q: no such device", device)
+	}
+	m.annotateImage(opts, specs)
+	opts.annotateSigningKey(specs)
+
+	if err := opts.validateSpecs(specs); err != nil {
+		return fmt.Errorf("strict validation failed: %w", err)
+	}
+
+	if opts.dryRun {
+		return withExitCode(m.logPlannedOutputs(opts, specs), ExitCodeOutputError)
+	}
+
+	if path := opts.getUnixSocketOutput(); path != "" {
+		if err := m.writeToUnixSocket(opts, path, specs); err != nil {
+			return withExitCode(err, ExitCodeOutputError)
+		}
+		if err := opts.writeQualifiedNames(specs); err != nil {
+			return withExitCode(err, ExitCodeOutputError)
+		}
+		return withExitCode(opts.writeDevicesJSON(specs), ExitCodeOutputError)
 	}
 
+	if opts.format == formatYAMLStream {
+		if err := m.writeStream(opts, specs); err != nil {
+			return withExitCode(err, ExitCodeOutputError)
+		}
+		if err := opts.writeQualifiedNames(specs); err != nil {
+			return withExitCode(err, ExitCodeOutputError)
+		}
+		if err := opts.writeDevicesJSON(specs); err != nil {
+			return withExitCode(err, ExitCodeOutputError)
+		}
+		return withExitCode(opts.signOutput(specs), ExitCodeOutputError)
+	}
+
+	doneWrite := opts.timings.track(phaseWrite)
 	var errs error
 	for _, spec := range specs {
-		errs = errors.Join(errs, spec.Save(opts.output))
-		// We query the raw spec version after calling spec.Save since this may
-		// update the spec version to the minimum required version.
-		m.logger.Infof("Generated CDI spec with version %v", spec.Raw().Version)
+		errs = errors.Join(errs, opts.saveSpec(spec))
+		m.logGeneratedSpec(opts, spec)
+	}
+	doneWrite()
+	if errs != nil {
+		return withExitCode(errs, ExitCodeOutputError)
+	}
+
+	if err := opts.writeQualifiedNames(specs); err != nil {
+		return withExitCode(err, ExitCodeOutputError)
+	}
+
+	if err := opts.writeDevicesJSON(specs); err != nil {
+		return withExitCode(err, ExitCodeOutputError)
+	}
+
+	return withExitCode(opts.signOutput(specs), ExitCodeOutputError)
+}
+
+// generateSpecsWithTimeout generates the CDI specs, failing if this does not
+// complete before the configured timeout or before the context is cancelled.
+// Since NVML calls cannot be cancelled, the generation is performed in a
+// separate goroutine which is abandoned if the timeout is reached.
+func (m command) generateSpecsWithTimeout(ctx context.Context, opts *options) ([]generatedSpecs, error) {
+	if opts.timeout <= 0 {
+		return m.generateSpecs(opts)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	type result struct {
+		specs []generatedSpecs
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		specs, err := m.generateSpecs(opts)
+		done <- result{specs: specs, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.specs, r.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %v: %w", opts.timeout, ctx.Err())
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// writeStream writes the specified specs to the output as a single YAML
+// stream. Since each spec is written as a separate YAML document, readers that
+// support multi-document YAML are able to load all the specs.
+func (m command) writeStream(opts *options, specs []generatedSpecs) error {
+	doneMarshal := opts.timings.track(phaseMarshal)
+	var stream bytes.Buffer
+	for _, spec := range specs {
+		if _, err := spec.WriteTo(&stream); err != nil {
+			return fmt.Errorf("failed to write CDI spec to stream: %w", err)
+		}
+		m.logGeneratedSpec(opts, spec)
+	}
+	doneMarshal()
+
+	defer opts.timings.track(phaseWrite)()
+
+	if opts.output == "" {
+		if _, err := stream.WriteTo(os.Stdout); err != nil {
+			return fmt.Errorf("failed to write CDI spec stream to STDOUT: %v", err)
+		}
+		return nil
+	}
+
+	if err := opts.writeFileAtomic(opts.output, stream.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write CDI spec stream: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes the specified data to the specified file so that
+// readers never observe a partially written file. The configured temporary
+// directory is used if specified.
+func (o *options) writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	return fsutil.WriteFileAtomic(filename, o.tempDir, data, perm)
+}
+
+// checkOverwrite returns an error if overwriting existing files is disabled and
+// any of the files that the specified specs would be written to exist.
+// All files are checked before any spec is written so that a failure does not
+// result in a partially updated set of specs.
+func (o *options) checkOverwrite(specs []generatedSpecs) error {
+	if o.overwrite || o.output == "" || o.updateContainerEdits || o.isUnixSocketOutput() {
+		return nil
+	}
+
+	for _, filename := range o.outputFilenames(specs) {
+		_, err := os.Lstat(filename)
+		if err == nil {
+			return fmt.Errorf("output file %v already exists; use --overwrite to replace it", filename)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to check output file %v: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// outputFilenames returns the names of the files that the specified specs are
+// written to.
+func (o *options) outputFilenames(specs []generatedSpecs) []string {
+	if o.format == formatYAMLStream {
+		return []string{o.output}
+	}
+	var filenames []string
+	for _, spec := range specs {
+		filenames = append(filenames, spec.updateFilename(o.output))
 	}
+	return filenames
+}
 
-	return errs
+// specFormat returns the format to use for each generated spec.
+func (o *options) specFormat() string {
+	if o.format == formatYAMLStream {
+		return spec.FormatYAML
+	}
+	return o.format
 }
 
 func formatFromFilename(filename string) string {
@@ -372,6 +1551,10 @@ func (g generatedSpecs) updateFilename(filename string) string {
 }
 
 func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
+	if opts.probe {
+		return m.generateProbeSpecs(opts)
+	}
+
 	var deviceNamers []nvcdi.DeviceNamer
 	for _, strategy := range opts.deviceNameStrategies {
 		deviceNamer, err := nvcdi.NewDeviceNamer(strategy)
@@ -381,57 +1564,201 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 		deviceNamers = append(deviceNamers, deviceNamer)
 	}
 
+	nvmllib, err := m.getNvmlLib(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.checkDriverVersion(nvmllib); err != nil {
+		return nil, err
+	}
+
+	applicationClocks, err := opts.getApplicationClocks()
+	if err != nil {
+		return nil, err
+	}
+
+	deviceNodeWait, err := opts.getDeviceNodeWait()
+	if err != nil {
+		return nil, err
+	}
+
+	deviceFolderPermissionOptions, err := opts.getDeviceFolderPermissionOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	cdiOptions := []nvcdi.Option{
 		nvcdi.WithLogger(m.logger),
 		nvcdi.WithDriverRoot(opts.driverRoot),
 		nvcdi.WithDevRoot(opts.devRoot),
 		nvcdi.WithNVIDIACDIHookPath(opts.nvidiaCDIHookPath),
 		nvcdi.WithLdconfigPath(opts.ldconfigPath),
+		nvcdi.WithNvidiaSMIPath(opts.nvidiaSMIPath),
+		nvcdi.WithMountBinaries(opts.mountBinaries...),
+		nvcdi.WithVulkanManifestDir(opts.getVulkanManifestDir()),
+		nvcdi.WithApplicationClocks(applicationClocks),
+		nvcdi.WithDeviceNodeWait(deviceNodeWait),
 		nvcdi.WithDeviceNamers(deviceNamers...),
 		nvcdi.WithMode(opts.mode),
+		nvcdi.WithPlatform(opts.platform),
 		nvcdi.WithConfigSearchPaths(opts.configSearchPaths),
 		nvcdi.WithLibrarySearchPaths(opts.librarySearchPaths),
 		nvcdi.WithCSVFiles(opts.csv.files),
 		nvcdi.WithCSVIgnorePatterns(opts.csv.ignorePatterns),
 		nvcdi.WithCSVCompatContainerRoot(opts.csv.CompatContainerRoot),
-		nvcdi.WithDisabledHooks(opts.disabledHooks...),
+		nvcdi.WithDisabledHooks(opts.getDisabledHooks()...),
 		nvcdi.WithEnabledHooks(opts.enabledHooks...),
 		nvcdi.WithFeatureFlags(opts.featureFlags...),
+		nvcdi.WithFeatureFlags(opts.getRequiredFeatureFlags()...),
+		nvcdi.WithAllowEmpty(opts.allowEmpty),
+		nvcdi.WithBestEffort(opts.bestEffort),
+		nvcdi.WithDriverCapabilities(opts.driverCapabilities),
+		nvcdi.WithProbeCapabilities(opts.probeCapabilities),
+		nvcdi.WithDumpDiscovered(opts.dumpDiscovered),
+		nvcdi.WithPCIBusIDs(opts.pciBusIDs...),
+		nvcdi.WithExcludedPCIBusIDs(opts.excludedPCIBusIDs...),
+		nvcdi.WithMaxDevices(opts.maxDevices),
+		nvcdi.WithDurationRecorder(opts.timings.recorder()),
+		nvcdi.WithVerboseDeviceSelection(opts.verbose),
+		nvcdi.WithDeviceInfoRecorder(opts.inventory.recorder()),
 		// We set the following to allow for dependency injection:
-		nvcdi.WithNvmlLib(opts.nvmllib),
+		nvcdi.WithNvmlLib(nvmllib),
 	}
+	cdiOptions = append(cdiOptions, deviceFolderPermissionOptions...)
 
 	cdilib, err := nvcdi.New(cdiOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CDI library: %v", err)
 	}
 
-	allDeviceSpecs, err := cdilib.GetDeviceSpecsByID(opts.deviceIDs...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create device CDI specs: %v", err)
+	// In best-effort mode, a partial discovery error is returned along with
+	// the specs for the remaining devices.
+	var partialErr error
+	var allDeviceSpecs []specs.Device
+	if !opts.updateContainerEdits {
+		deviceIDs, err := opts.getDeviceIDs()
+		if err != nil {
+			return nil, err
+		}
+		doneDeviceDiscovery := opts.timings.track(phaseDeviceDiscovery)
+		allDeviceSpecs, err = m.getDeviceSpecs(cdilib, deviceIDs, opts.logDeviceSelection(m.logger))
+		doneDeviceDiscovery()
+		switch {
+		case errors.As(err, new(*nvcdi.PartialDiscoveryError)):
+			partialErr = err
+		case err != nil && opts.devicesFromPlugin != "":
+			return nil, errorformat.WithComponent(errorformat.DevicesComponent, fmt.Errorf("failed to resolve devices from device plugin device list %v: %w", opts.devicesFromPlugin, err))
+		case err != nil:
+			return nil, errorformat.WithComponent(errorformat.DevicesComponent, fmt.Errorf("failed to create device CDI specs: %v", err))
+		}
+		var generatedNames []string
+		for _, d := range allDeviceSpecs {
+			generatedNames = append(generatedNames, d.Name)
+		}
+		allDeviceSpecs = opts.prefixDeviceNames(allDeviceSpecs)
+		allDeviceSpecs, err = m.preserveDeviceNames(opts, allDeviceSpecs)
+		if err != nil {
+			return nil, err
+		}
+		opts.inventory.rename(generatedNames, allDeviceSpecs)
 	}
 
+	doneCommonDiscovery := opts.timings.track(phaseCommonDiscovery)
 	commonEdits, err := cdilib.GetCommonEdits()
+	doneCommonDiscovery()
+	if err != nil {
+		return nil, errorformat.WithComponent(componentCommonEdits, fmt.Errorf("failed to create edits common for entities: %v", err))
+	}
+
+	additional, err := opts.getAdditionalMounts()
+	if err != nil {
+		return nil, err
+	}
+	toolkitMounts, err := opts.getToolkitMounts()
+	if err != nil {
+		return nil, err
+	}
+	additional = discover.Merge(additional, toolkitMounts)
+	if opts.compatWithLegacyHook {
+		// The marker allows the legacy NVIDIA Container Runtime Hook to detect
+		// that the container has already been modified using CDI.
+		additional = discover.Merge(
+			additional,
+			discover.EnvVar{Name: image.EnvVarNvidiaCTKCDIInjected, Value: "true"},
+		)
+	}
+	additionalEdits, err := edits.NewFactory().FromDiscoverer(additional)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create additional edits: %v", err)
+	}
+	commonEdits.Append(additionalEdits)
+	edits.SortHooks(commonEdits, nil)
+
+	if err := opts.mergeEdits(commonEdits); err != nil {
+		return nil, err
+	}
+
+	if err := m.excludeDeviceNodes(opts, commonEdits.ContainerEdits, allDeviceSpecs); err != nil {
+		return nil, err
+	}
+
+	if err := m.makeMountsRelative(opts, commonEdits.ContainerEdits, allDeviceSpecs); err != nil {
+		return nil, fmt.Errorf("failed to make mounts relative to %v: %w", opts.relativeTo, err)
+	}
+
+	if opts.updateContainerEdits {
+		return opts.updateExistingSpec(*commonEdits.ContainerEdits)
+	}
+
+	headerComment, err := opts.getHeaderComment()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create edits common for entities: %v", err)
+		return nil, err
 	}
 
 	commonSpecOptions := []spec.Option{
 		spec.WithVendor(opts.vendor),
 		spec.WithEdits(*commonEdits.ContainerEdits),
-		spec.WithFormat(opts.format),
+		spec.WithFormat(opts.specFormat()),
 		spec.WithPermissions(0644),
+		spec.WithYAMLIndent(opts.yamlIndent),
+		spec.WithNoYAMLSeparator(opts.noYAMLSeparator),
+		spec.WithJSONIndent(opts.jsonIndent),
+		spec.WithUnsafeKind(opts.unsafeKind),
+		spec.WithTempDir(opts.tempDir),
+		spec.WithHeaderComment(headerComment),
+	}
+
+	if opts.format == formatYAMLStream {
+		perDeviceSpecs, err := newPerDeviceSpecs(commonSpecOptions, opts.class, allDeviceSpecs)
+		if err != nil {
+			return nil, err
+		}
+		return perDeviceSpecs, partialErr
 	}
 
 	if !opts.noAllDevice {
 		commonSpecOptions = append(commonSpecOptions,
 			spec.WithMergedDeviceOptions(
-				transform.WithName(allDeviceName),
+				transform.WithName(opts.prefixDeviceName(allDeviceName)),
 				transform.WithSkipIfExists(true),
+				transform.WithLogger(m.logger),
+				transform.WithStrict(opts.strict),
+				transform.WithExcludedDevices(nvcdi.IsNonAllocatable),
 			),
 		)
 	}
 
+	var migDeviceSpecs []specs.Device
+	if opts.migClass != "" {
+		allDeviceSpecs, migDeviceSpecs = (deviceSpecs)(allDeviceSpecs).partitionOnAnnotation(nvcdi.MIGAnnotation, "true")
+	}
+
+	var displayDeviceSpecs []specs.Device
+	if opts.displayClass != "" {
+		allDeviceSpecs, displayDeviceSpecs = (deviceSpecs)(allDeviceSpecs).partitionOnAnnotation(displayAnnotation, "true")
+	}
+
 	fullSpec, err := spec.New(
 		append(commonSpecOptions,
 			spec.WithClass(opts.class),
@@ -445,6 +1772,34 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 
 	allSpecs = append(allSpecs, generatedSpecs{Interface: fullSpec, filenameInfix: ""})
 
+	if len(displayDeviceSpecs) > 0 {
+		infix := "." + opts.displayClass
+		displaySpecs, err := spec.New(
+			append(commonSpecOptions,
+				spec.WithClass(opts.displayClass),
+				spec.WithDeviceSpecs(displayDeviceSpecs),
+			)...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allSpecs = append(allSpecs, generatedSpecs{Interface: displaySpecs, filenameInfix: infix})
+	}
+
+	if len(migDeviceSpecs) > 0 {
+		infix := "." + opts.migClass
+		migSpecs, err := spec.New(
+			append(commonSpecOptions,
+				spec.WithClass(opts.migClass),
+				spec.WithDeviceSpecs(migDeviceSpecs),
+			)...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		allSpecs = append(allSpecs, generatedSpecs{Interface: migSpecs, filenameInfix: infix})
+	}
+
 	deviceSpecsByDeviceCoherence := (deviceSpecs)(allDeviceSpecs).splitOnAnnotation("gpu.nvidia.com/coherent")
 
 	if coherentDeviceSpecs := deviceSpecsByDeviceCoherence["gpu.nvidia.com/coherent=true"]; len(coherentDeviceSpecs) > 0 {
@@ -476,11 +1831,123 @@ func (m command) generateSpecs(opts *options) ([]generatedSpecs, error) {
 		allSpecs = append(allSpecs, generatedSpecs{Interface: noncoherentSpecs, filenameInfix: infix})
 	}
 
-	return allSpecs, nil
+	return allSpecs, partialErr
+}
+
+// prefixDeviceName returns the device name with the requested device prefix
+// applied. Since both the prefix and the device name are valid CDI device
+// names, the result is also a valid CDI device name.
+func (o *options) prefixDeviceName(name string) string {
+	if o.devicePrefix == "" {
+		return name
+	}
+	return o.devicePrefix + "-" + name
+}
+
+// prefixDeviceNames applies the requested device prefix to the names of the
+// specified device specs.
+func (o *options) prefixDeviceNames(devices []specs.Device) []specs.Device {
+	for i := range devices {
+		devices[i].Name = o.prefixDeviceName(devices[i].Name)
+	}
+	return devices
+}
+
+// newPerDeviceSpecs creates a standalone spec for each of the specified
+// devices. Each spec includes the common edits.
+func newPerDeviceSpecs(commonSpecOptions []spec.Option, class string, devices []specs.Device) ([]generatedSpecs, error) {
+	var perDeviceSpecs []generatedSpecs
+	for _, device := range devices {
+		deviceSpec, err := spec.New(
+			append(slices.Clone(commonSpecOptions),
+				spec.WithClass(class),
+				spec.WithDeviceSpecs([]specs.Device{device}),
+			)...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create spec for device %q: %w", device.Name, err)
+		}
+		perDeviceSpecs = append(perDeviceSpecs, generatedSpecs{Interface: deviceSpec})
+	}
+	return perDeviceSpecs, nil
+}
+
+// getDisabledHooks returns the hooks to disable. If no hooks are requested, all
+// hooks are disabled.
+func (o *options) getDisabledHooks() []string {
+	if o.noHooks {
+		return []string{string(nvcdi.AllHooks)}
+	}
+	return o.disabledHooks
+}
+
+// getRequiredFeatureFlags returns the feature flags that are implied by the
+// specified options.
+func (o *options) getRequiredFeatureFlags() []nvcdi.FeatureFlag {
+	var featureFlags []nvcdi.FeatureFlag
+	if o.displayClass != "" {
+		featureFlags = append(featureFlags, nvcdi.FeatureEnableDisplayAnnotations)
+	}
+	if o.migClass != "" {
+		featureFlags = append(featureFlags, nvcdi.FeatureEnableMIGAnnotations)
+	}
+	if o.emitCgroupRules {
+		featureFlags = append(featureFlags, nvcdi.FeatureEmitCgroupDeviceRules)
+	}
+	if o.disableNUMAAnnotations {
+		featureFlags = append(featureFlags, nvcdi.FeatureDisableNUMAAnnotations)
+	}
+	if o.disablePCISerialAnnotations {
+		featureFlags = append(featureFlags, nvcdi.FeatureDisablePCIAddressAndSerialAnnotations)
+	}
+	if o.noDedupLibraries {
+		featureFlags = append(featureFlags, nvcdi.FeatureDisableLibraryDeduplication)
+	}
+	if o.cudaCompat {
+		featureFlags = append(featureFlags, nvcdi.FeatureEnableCUDACompatLibraries)
+	}
+	if o.onlyMIGParents {
+		featureFlags = append(featureFlags, nvcdi.FeatureOnlyMIGParents)
+	}
+	if !o.excludeMIGParentDevices {
+		featureFlags = append(featureFlags, nvcdi.FeatureEnableMIGParentPlaceholders)
+	}
+	if o.nvswitch {
+		featureFlags = append(featureFlags, nvcdi.FeatureEnableNvSwitchDevices)
+	}
+	if !o.persistenced {
+		featureFlags = append(featureFlags, nvcdi.FeatureDisablePersistencedSocket)
+	}
+	if o.preserveNamesFrom != "" {
+		featureFlags = append(featureFlags, nvcdi.FeatureEnableUUIDAnnotations)
+	}
+	// The devices in a snapshot cannot be queried using nvsandboxutils.
+	if o.fromSnapshot != "" {
+		featureFlags = append(featureFlags, nvcdi.FeatureDisableNvsandboxUtils)
+	}
+	return featureFlags
 }
 
 type deviceSpecs []specs.Device
 
+// partitionOnAnnotation partitions the device specs into those where the
+// specified annotation has the specified value and the remaining device specs.
+// The annotation is removed from all device specs.
+func (d deviceSpecs) partitionOnAnnotation(key string, value string) ([]specs.Device, []specs.Device) {
+	var others []specs.Device
+	var matching []specs.Device
+	for _, deviceSpec := range d {
+		v, ok := deviceSpec.Annotations[key]
+		delete(deviceSpec.Annotations, key)
+		if ok && v == value {
+			matching = append(matching, deviceSpec)
+			continue
+		}
+		others = append(others, deviceSpec)
+	}
+	return others, matching
+}
+
 func (d deviceSpecs) splitOnAnnotation(key string) map[string][]specs.Device {
 	splitSpecs := make(map[string][]specs.Device)
 
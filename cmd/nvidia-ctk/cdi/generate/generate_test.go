@@ -18,20 +18,34 @@ package generate
 
 import (
 	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
 	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	cdi "tags.cncf.io/container-device-interface/pkg/parser"
 	"tags.cncf.io/container-device-interface/specs-go"
 
+	signing "github.com/NVIDIA/nvidia-container-toolkit/internal/cdi-signing"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/errorformat"
+	snapshot "github.com/NVIDIA/nvidia-container-toolkit/internal/nvml-snapshot"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
 )
 
 func TestGenerateSpec(t *testing.T) {
@@ -70,7 +84,7 @@ func TestGenerateSpec(t *testing.T) {
 				deviceIDs:         []string{"99"},
 				driverRoot:        driverRoot,
 			},
-			expectedError: fmt.Errorf("failed to create device CDI specs: failed to construct device spec generators: failed to get device handle from index: ERROR_INVALID_ARGUMENT"),
+			expectedError: errorformat.WithComponent(errorformat.DevicesComponent, fmt.Errorf("failed to create device CDI specs: failed to construct device spec generators: failed to get device handle from index: ERROR_INVALID_ARGUMENT")),
 		},
 		{
 			description: "default",
@@ -170,6 +184,101 @@ containerEdits:
             - nodev
             - rbind
             - rprivate
+`,
+		},
+		{
+			description: "yaml stream",
+			options: options{
+				format:     "yaml-stream",
+				mode:       "nvml",
+				vendor:     "example.com",
+				class:      "device",
+				driverRoot: driverRoot,
+			},
+			expectedOptions: options{
+				format:            "yaml-stream",
+				mode:              "nvml",
+				vendor:            "example.com",
+				class:             "device",
+				nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+				driverRoot:        driverRoot,
+			},
+			expectedSpec: `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: {{ .driverRoot }}/dev/nvidia0
+containerEdits:
+    env:
+        - NVIDIA_CTK_LIBCUDA_DIR=/lib/x86_64-linux-gnu
+        - NVIDIA_VISIBLE_DEVICES=void
+    deviceNodes:
+        - path: /dev/nvidiactl
+          hostPath: {{ .driverRoot }}/dev/nvidiactl
+    hooks:
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - create-symlinks
+            - --link
+            - libcuda.so.1::/lib/x86_64-linux-gnu/libcuda.so
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - enable-cuda-compat
+            - --host-driver-version=999.88.77
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - update-ldcache
+            - --folder
+            - /lib/x86_64-linux-gnu
+            - --folder
+            - /lib/x86_64-linux-gnu/vdpau
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - disable-device-node-modification
+          env:
+            - NVIDIA_CTK_DEBUG=false
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args:
+            - nvidia-cdi-hook
+            - update-application-profile
+          env:
+            - NVIDIA_CTK_DEBUG=false
+    mounts:
+        - hostPath: {{ .driverRoot }}/lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          containerPath: /lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
+        - hostPath: {{ .driverRoot }}/lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.999.88.77
+          containerPath: /lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.999.88.77
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
 `,
 		},
 		{
@@ -371,6 +480,65 @@ containerEdits:
 			expectedSpec: `---
 cdiVersion: 0.5.0
 kind: example.com/device
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: {{ .driverRoot }}/dev/nvidia0
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: {{ .driverRoot }}/dev/nvidia0
+containerEdits:
+    env:
+        - NVIDIA_CTK_LIBCUDA_DIR=/lib/x86_64-linux-gnu
+        - NVIDIA_VISIBLE_DEVICES=void
+    deviceNodes:
+        - path: /dev/nvidiactl
+          hostPath: {{ .driverRoot }}/dev/nvidiactl
+    mounts:
+        - hostPath: {{ .driverRoot }}/lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          containerPath: /lib/x86_64-linux-gnu/libcuda.so.999.88.77
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
+        - hostPath: {{ .driverRoot }}/lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.999.88.77
+          containerPath: /lib/x86_64-linux-gnu/vdpau/libvdpau_nvidia.so.999.88.77
+          options:
+            - ro
+            - nosuid
+            - nodev
+            - rbind
+            - rprivate
+`,
+		},
+		{
+			description: "noHooks",
+			options: options{
+				format:     "yaml",
+				mode:       "nvml",
+				vendor:     "example.com",
+				class:      "device",
+				driverRoot: driverRoot,
+				noHooks:    true,
+			},
+			expectedOptions: options{
+				format:            "yaml",
+				mode:              "nvml",
+				vendor:            "example.com",
+				class:             "device",
+				nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+				driverRoot:        driverRoot,
+				noHooks:           true,
+			},
+			expectedSpec: `---
+cdiVersion: 0.5.0
+kind: example.com/device
 devices:
     - name: "0"
       containerEdits:
@@ -527,6 +695,12 @@ containerEdits:
 				(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
 					return 0, nvml.SUCCESS
 				}
+				(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+					return 0, nvml.ERROR_NOT_SUPPORTED
+				}
+				(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+					return "", nvml.ERROR_NOT_SUPPORTED
+				}
 			}
 			tc.options.nvmllib = server
 
@@ -702,3 +876,1077 @@ func TestSplitOnAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestPartitionOnAnnotation(t *testing.T) {
+	testCases := []struct {
+		description      string
+		input            deviceSpecs
+		expectedOthers   []specs.Device
+		expectedMatching []specs.Device
+	}{
+		{
+			description: "no annotations",
+			input: deviceSpecs{
+				{Name: "0"},
+			},
+			expectedOthers: []specs.Device{
+				{Name: "0"},
+			},
+		},
+		{
+			description: "devices are partitioned on value",
+			input: deviceSpecs{
+				{Name: "0", Annotations: map[string]string{"key": "true"}},
+				{Name: "1", Annotations: map[string]string{"key": "false", "another": "foo"}},
+				{Name: "2"},
+			},
+			expectedOthers: []specs.Device{
+				{Name: "1", Annotations: map[string]string{"another": "foo"}},
+				{Name: "2"},
+			},
+			expectedMatching: []specs.Device{
+				{Name: "0", Annotations: map[string]string{}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			others, matching := tc.input.partitionOnAnnotation("key", "true")
+
+			require.EqualValues(t, tc.expectedOthers, others)
+			require.EqualValues(t, tc.expectedMatching, matching)
+		})
+	}
+}
+
+func TestGenerateSpecsTimeout(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	// We block NVML initialization to simulate a hung driver. Once the test
+	// completes, initialization fails so that the abandoned generation exits.
+	blocked := make(chan struct{})
+	t.Cleanup(func() { close(blocked) })
+
+	server := dgxa100.New()
+	server.InitFunc = func() nvml.Return {
+		<-blocked
+		return nvml.ERROR_UNKNOWN
+	}
+
+	c := command{
+		logger: logger,
+	}
+	opts := options{
+		mode:                 "nvml",
+		driverRoot:           t.TempDir(),
+		deviceNameStrategies: []string{"index"},
+		vendor:               "example.com",
+		class:                "device",
+		deviceIDs:            []string{"all"},
+		timeout:              10 * time.Millisecond,
+		nvmllib:              server,
+	}
+
+	_, err := c.generateSpecsWithTimeout(context.Background(), &opts)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGenerateSpecsFromSnapshot(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetEncoderCapacityFunc = func(nvml.EncoderType) (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetDecoderUtilizationFunc = func() (uint32, uint32, nvml.Return) {
+			return 0, 167000, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetDisplayModeFunc = func() (nvml.EnableState, nvml.Return) {
+			return nvml.FEATURE_DISABLED, nvml.SUCCESS
+		}
+	}
+
+	generate := func(opts options) string {
+		opts.format = "yaml"
+		opts.mode = "nvml"
+		opts.vendor = "example.com"
+		opts.class = "device"
+		opts.deviceNameStrategies = []string{"index", "uuid"}
+		opts.deviceIDs = []string{"all"}
+		opts.driverRoot = driverRoot
+		opts.nvidiaCDIHookPath = "/usr/bin/nvidia-cdi-hook"
+
+		specs, err := c.generateSpecs(&opts)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		for _, spec := range specs {
+			_, err = spec.WriteTo(&buf)
+			require.NoError(t, err)
+		}
+		return buf.String()
+	}
+
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.json")
+	fromNvml := generate(options{nvmllib: server, saveSnapshot: snapshotFile})
+	fromSnapshot := generate(options{fromSnapshot: snapshotFile})
+
+	require.Contains(t, fromNvml, "name: "+(server.Devices[0].(*mockserver.Device)).UUID)
+	require.Equal(t, fromNvml, fromSnapshot)
+}
+
+// TestGenerateSpecsFromSnapshotWithNvmlOptions ensures that the options that
+// query NVML for additional device information can be used when generating
+// specs from a snapshot.
+func TestGenerateSpecsFromSnapshotWithNvmlOptions(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "1234567890", nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetEncoderCapacityFunc = func(nvml.EncoderType) (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetDecoderUtilizationFunc = func() (uint32, uint32, nvml.Return) {
+			return 0, 167000, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetDisplayModeFunc = func() (nvml.EnableState, nvml.Return) {
+			return nvml.FEATURE_DISABLED, nvml.SUCCESS
+		}
+	}
+
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.json")
+	s, err := snapshot.Capture(server)
+	require.NoError(t, err)
+	require.NoError(t, s.Save(snapshotFile))
+
+	testCases := []struct {
+		description string
+		opts        options
+	}{
+		{
+			description: "probe capabilities",
+			opts:        options{probeCapabilities: true},
+		},
+		{
+			description: "application clocks hook",
+			opts:        options{emitClockHook: true, applicationClocks: "1215,1410"},
+		},
+		{
+			description: "cgroup rules",
+			opts:        options{emitCgroupRules: true},
+		},
+		{
+			description: "CUDA compat libraries",
+			opts:        options{cudaCompat: true},
+		},
+		{
+			description: "exclude MIG parent devices",
+			opts:        options{excludeMIGParentDevices: true},
+		},
+		{
+			description: "required driver version",
+			opts:        options{requiredDriverVersion: "999.88.77"},
+		},
+		{
+			description: "PCI bus ID selection",
+			opts:        options{pciBusIDs: []string{s.Devices[0].PCIBusID}},
+		},
+		{
+			description: "excluded PCI bus ID",
+			opts:        options{excludedPCIBusIDs: []string{s.Devices[0].PCIBusID}},
+		},
+		{
+			description: "max devices",
+			opts:        options{maxDevices: 2},
+		},
+		{
+			description: "all options",
+			opts: options{
+				probeCapabilities:       true,
+				emitClockHook:           true,
+				applicationClocks:       "1215,1410",
+				emitCgroupRules:         true,
+				cudaCompat:              true,
+				excludeMIGParentDevices: true,
+				requiredDriverVersion:   "999.88.77",
+				maxDevices:              2,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := tc.opts
+			opts.format = "yaml"
+			opts.mode = "nvml"
+			opts.vendor = "example.com"
+			opts.class = "device"
+			opts.deviceNameStrategies = []string{"index", "uuid"}
+			opts.deviceIDs = []string{"all"}
+			opts.driverRoot = driverRoot
+			opts.nvidiaCDIHookPath = "/usr/bin/nvidia-cdi-hook"
+			opts.fromSnapshot = snapshotFile
+
+			specs, err := c.generateSpecs(&opts)
+			require.NoError(t, err)
+			require.NotEmpty(t, specs)
+		})
+	}
+}
+
+func TestGenerateSpecsNoAllDevice(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	// Only a single device node is present in the test driver root.
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	testCases := []struct {
+		description         string
+		noAllDevice         bool
+		expectedDeviceCount int
+		expectAllDevice     bool
+	}{
+		{
+			description:         "all device is generated by default",
+			expectedDeviceCount: 2,
+			expectAllDevice:     true,
+		},
+		{
+			description:         "all device is not generated",
+			noAllDevice:         true,
+			expectedDeviceCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &options{
+				output:            filepath.Join(t.TempDir(), "nvidia.yaml"),
+				format:            "yaml",
+				mode:              "nvml",
+				vendor:            "example.com",
+				class:             "device",
+				deviceIDs:         []string{"all"},
+				driverRoot:        driverRoot,
+				nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+				nvmllib:           server,
+				noAllDevice:       tc.noAllDevice,
+			}
+
+			generated, err := c.generateSpecs(opts)
+			require.NoError(t, err)
+			require.Len(t, generated, 1)
+
+			var names []string
+			for _, d := range generated[0].Raw().Devices {
+				names = append(names, d.Name)
+			}
+			require.Len(t, names, tc.expectedDeviceCount)
+			require.Equal(t, tc.expectAllDevice, slices.Contains(names, "all"))
+
+			// The spec is validated when it is saved.
+			require.NoError(t, c.writeSpecs(opts, generated))
+		})
+	}
+}
+
+func TestGenerateSpecsDeterministicHookArgs(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	getHookArgs := func() [][]string {
+		opts := &options{
+			format:            "yaml",
+			mode:              "nvml",
+			vendor:            "example.com",
+			class:             "device",
+			deviceIDs:         []string{"all"},
+			driverRoot:        driverRoot,
+			nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+			nvmllib:           server,
+		}
+		generated, err := c.generateSpecs(opts)
+		require.NoError(t, err)
+		require.Len(t, generated, 1)
+
+		var hookArgs [][]string
+		for _, hook := range generated[0].Raw().ContainerEdits.Hooks {
+			hookArgs = append(hookArgs, hook.Args)
+		}
+		return hookArgs
+	}
+
+	first := getHookArgs()
+	require.NotEmpty(t, first)
+	require.Equal(t, first, getHookArgs())
+}
+
+func TestGenerateSpecsApplicationClocksHook(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+	uuid, ret := server.Devices[0].GetUUID()
+	require.Equal(t, nvml.SUCCESS, ret)
+
+	opts := &options{
+		format:            "yaml",
+		mode:              "nvml",
+		vendor:            "example.com",
+		class:             "device",
+		deviceIDs:         []string{"all"},
+		driverRoot:        driverRoot,
+		nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+		emitClockHook:     true,
+		applicationClocks: "1215,1410",
+		nvmllib:           server,
+	}
+	generated, err := c.generateSpecs(opts)
+	require.NoError(t, err)
+	require.Len(t, generated, 1)
+
+	deviceSpecs := generated[0].Raw().Devices
+	require.NotEmpty(t, deviceSpecs)
+	require.Equal(t, "0", deviceSpecs[0].Name)
+	require.Contains(t, deviceSpecs[0].ContainerEdits.Hooks, &specs.Hook{
+		HookName: "createContainer",
+		Path:     "/usr/bin/nvidia-cdi-hook",
+		Args:     []string{"nvidia-cdi-hook", "set-application-clocks", "--device-uuid=" + uuid, "--memory-clock=1215", "--graphics-clock=1410"},
+		Env:      []string{"NVIDIA_CTK_DEBUG=false"},
+	})
+}
+
+func TestGenerateSpecsUnsafeKind(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	testCases := []struct {
+		description           string
+		unsafeKind            bool
+		expectedValidateError string
+	}{
+		{
+			description:           "invalid class is rejected by default",
+			expectedValidateError: "invalid CDI class name",
+		},
+		{
+			description: "invalid class is allowed with unsafe kind",
+			unsafeKind:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "nvidia.yaml")
+			opts := &options{
+				format:            "yaml",
+				mode:              "nvml",
+				vendor:            "example.com",
+				class:             "experimental!",
+				deviceIDs:         []string{"all"},
+				driverRoot:        driverRoot,
+				nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+				unsafeKind:        tc.unsafeKind,
+			}
+
+			err := c.validateFlags(nil, opts)
+			if tc.expectedValidateError != "" {
+				require.ErrorContains(t, err, tc.expectedValidateError)
+				return
+			}
+			require.NoError(t, err)
+
+			opts.output = output
+			opts.nvmllib = server
+			generated, err := c.generateSpecs(opts)
+			require.NoError(t, err)
+			require.NoError(t, c.writeSpecs(opts, generated))
+
+			contents, err := os.ReadFile(output)
+			require.NoError(t, err)
+			require.Contains(t, string(contents), "kind: example.com/experimental!")
+		})
+	}
+}
+
+func TestGenerateSpecsUpdateContainerEdits(t *testing.T) {
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+
+	output := filepath.Join(t.TempDir(), "existing.yaml")
+	existing := `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+- name: custom-name
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+containerEdits:
+  env:
+  - STALE=true
+`
+	require.NoError(t, os.WriteFile(output, []byte(existing), 0644))
+
+	opts := &options{
+		output:               output,
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "example.com",
+		class:                "device",
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		nvmllib:              server,
+		updateContainerEdits: true,
+	}
+
+	specs, err := c.generateSpecs(opts)
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+
+	raw := specs[0].Raw()
+	require.Equal(t, "example.com/device", raw.Kind)
+	require.Len(t, raw.Devices, 1)
+	require.Equal(t, "custom-name", raw.Devices[0].Name)
+	require.NotContains(t, raw.ContainerEdits.Env, "STALE=true")
+	require.NotEmpty(t, raw.ContainerEdits.Mounts)
+
+	opts.class = "other"
+	_, err = c.generateSpecs(opts)
+	require.ErrorContains(t, err, `has kind "example.com/device"`)
+}
+
+func TestGenerateSignedSpec(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	outputDir := t.TempDir()
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	keyFile := filepath.Join(outputDir, "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	opts := &options{
+		output:               filepath.Join(outputDir, "spec.yaml"),
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "example.com",
+		class:                "device",
+		deviceNameStrategies: []string{"index"},
+		deviceIDs:            []string{"all"},
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		noCTKCheck:           true,
+		overwrite:            true,
+		signKey:              keyFile,
+		nvmllib:              server,
+	}
+	opts.signer, err = opts.getSigner()
+	require.NoError(t, err)
+
+	require.NoError(t, c.run(context.Background(), opts))
+
+	verifier, err := signing.NewVerifierFromFile(keyFile)
+	require.NoError(t, err)
+	require.NoError(t, verifier.VerifyFile(opts.output))
+
+	contents, err := os.ReadFile(opts.output)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), signing.SigningKeyAnnotation+": "+verifier.KeyID())
+}
+
+func TestGenerateSpecsBestEffort(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 2, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+	(server.Devices[1].(*mockserver.Device)).GetUUIDFunc = func() (string, nvml.Return) {
+		return "", nvml.ERROR_UNKNOWN
+	}
+
+	opts := options{
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "example.com",
+		class:                "device",
+		deviceNameStrategies: []string{"index"},
+		deviceIDs:            []string{"all"},
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		nvmllib:              server,
+	}
+
+	_, err = c.generateSpecs(&opts)
+	require.ErrorContains(t, err, "failed to get device UUID")
+	require.False(t, errors.As(err, new(*nvcdi.PartialDiscoveryError)))
+
+	opts.bestEffort = true
+	specs, err := c.generateSpecs(&opts)
+	var partialErr *nvcdi.PartialDiscoveryError
+	require.ErrorAs(t, err, &partialErr)
+	require.Len(t, partialErr.Errors, 1)
+	require.Equal(t, "1", partialErr.Errors[0].ID)
+
+	require.Len(t, specs, 1)
+	var names []string
+	for _, d := range specs[0].Raw().Devices {
+		names = append(names, d.Name)
+	}
+	require.Equal(t, []string{"0", "all"}, names)
+}
+
+func TestCheckOverwrite(t *testing.T) {
+	outputDir := t.TempDir()
+	existing := filepath.Join(outputDir, "existing.yaml")
+	require.NoError(t, os.WriteFile(existing, nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "existing.display.yaml"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "other.display.yaml"), nil, 0644))
+
+	testCases := []struct {
+		description   string
+		options       options
+		specs         []generatedSpecs
+		expectedError bool
+	}{
+		{
+			description: "overwrite allows existing file",
+			options: options{
+				output:    existing,
+				overwrite: true,
+			},
+			specs: []generatedSpecs{{}},
+		},
+		{
+			description: "stdout is always allowed",
+			options:     options{},
+			specs:       []generatedSpecs{{}},
+		},
+		{
+			description: "new file is allowed",
+			options: options{
+				output: filepath.Join(outputDir, "new.yaml"),
+			},
+			specs: []generatedSpecs{{}},
+		},
+		{
+			description: "existing file is rejected",
+			options: options{
+				output: existing,
+			},
+			specs:         []generatedSpecs{{}},
+			expectedError: true,
+		},
+		{
+			description: "existing file for additional spec is rejected",
+			options: options{
+				output: filepath.Join(outputDir, "other.yaml"),
+			},
+			specs:         []generatedSpecs{{}, {filenameInfix: ".display"}},
+			expectedError: true,
+		},
+		{
+			description: "existing infixed file is rejected",
+			options: options{
+				output: existing,
+			},
+			specs:         []generatedSpecs{{filenameInfix: ".display"}},
+			expectedError: true,
+		},
+		{
+			description: "existing stream file is rejected",
+			options: options{
+				output: existing,
+				format: formatYAMLStream,
+			},
+			specs:         []generatedSpecs{{filenameInfix: ".other"}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.options.checkOverwrite(tc.specs)
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateFlagsExpandsOutput(t *testing.T) {
+	t.Setenv("NODE_NAME", "node-1")
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		output         string
+		expectedOutput string
+		expectedFormat string
+	}{
+		{
+			description:    "variable is expanded",
+			output:         "/etc/cdi/${NODE_NAME}-gpu.json",
+			expectedOutput: "/etc/cdi/node-1-gpu.json",
+			expectedFormat: "json",
+		},
+		{
+			description:    "unset variable expands to empty",
+			output:         "/etc/cdi/${UNSET_NODE_NAME}gpu.yaml",
+			expectedOutput: "/etc/cdi/gpu.yaml",
+			expectedFormat: "yaml",
+		},
+		{
+			description:    "empty output is unchanged",
+			output:         "",
+			expectedOutput: "",
+			expectedFormat: "yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+			opts := options{
+				output: tc.output,
+				format: "yaml",
+				mode:   "nvml",
+				vendor: "example.com",
+				class:  "device",
+			}
+			err := c.validateFlags(&cli.Command{}, &opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, opts.output)
+			require.Equal(t, tc.expectedFormat, opts.format)
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "nvidia.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("existing"), 0600))
+
+	opts := &options{}
+	require.NoError(t, opts.writeFileAtomic(filename, []byte("updated"), 0644))
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "updated", string(contents))
+
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	err = opts.writeFileAtomic(filepath.Join(dir, "missing", "nvidia.yaml"), []byte("updated"), 0644)
+	require.Error(t, err)
+}
+
+func TestWriteFileAtomicTempDir(t *testing.T) {
+	dir := t.TempDir()
+	tempDir := t.TempDir()
+	filename := filepath.Join(dir, "nvidia.yaml")
+
+	opts := &options{tempDir: tempDir}
+	require.NoError(t, opts.writeFileAtomic(filename, []byte("updated"), 0644))
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, "updated", string(contents))
+
+	entries, err := os.ReadDir(tempDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestDevicePrefix(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description       string
+		devicePrefix      string
+		expectedError     error
+		expectedNames     []string
+		expectedAllDevice string
+	}{
+		{
+			description:       "no prefix",
+			expectedNames:     []string{"0", "GPU-1234", "0:1"},
+			expectedAllDevice: "all",
+		},
+		{
+			description:       "prefix is applied",
+			devicePrefix:      "node-1",
+			expectedNames:     []string{"node-1-0", "node-1-GPU-1234", "node-1-0:1"},
+			expectedAllDevice: "node-1-all",
+		},
+		{
+			description:   "invalid prefix",
+			devicePrefix:  "node-1/",
+			expectedError: errors.New("invalid device prefix: invalid name \"node-1/\", should end with a letter or digit"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := command{
+				logger: logger,
+			}
+			opts := options{
+				format:       "yaml",
+				mode:         "nvml",
+				vendor:       "example.com",
+				class:        "device",
+				devicePrefix: tc.devicePrefix,
+			}
+			err := c.validateFlags(&cli.Command{}, &opts)
+			if tc.expectedError != nil {
+				require.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			devices := opts.prefixDeviceNames([]specs.Device{{Name: "0"}, {Name: "GPU-1234"}, {Name: "0:1"}})
+			var names []string
+			for _, d := range devices {
+				require.NoError(t, cdi.ValidateDeviceName(d.Name))
+				names = append(names, d.Name)
+			}
+			require.Equal(t, tc.expectedNames, names)
+			require.Equal(t, tc.expectedAllDevice, opts.prefixDeviceName(allDeviceName))
+		})
+	}
+}
+
+func TestGenerateSpecsMIGClass(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	testCases := []struct {
+		description           string
+		migClass              string
+		displayClass          string
+		format                string
+		expectedValidateError string
+	}{
+		{
+			description: "MIG class",
+			migClass:    "mig",
+		},
+		{
+			description:           "invalid MIG class",
+			migClass:              "mig!",
+			expectedValidateError: "invalid CDI MIG class name",
+		},
+		{
+			description:           "MIG class matches class",
+			migClass:              "device",
+			expectedValidateError: "the MIG class must differ",
+		},
+		{
+			description:           "MIG class matches display class",
+			migClass:              "display",
+			displayClass:          "display",
+			expectedValidateError: "the MIG class must differ",
+		},
+		{
+			description:           "MIG class with YAML stream",
+			migClass:              "mig",
+			format:                formatYAMLStream,
+			expectedValidateError: "not supported for format",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			format := tc.format
+			if format == "" {
+				format = "yaml"
+			}
+			opts := &options{
+				format:            format,
+				mode:              "nvml",
+				vendor:            "example.com",
+				class:             "device",
+				migClass:          tc.migClass,
+				displayClass:      tc.displayClass,
+				deviceIDs:         []string{"all"},
+				driverRoot:        driverRoot,
+				nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+			}
+
+			err := c.validateFlags(nil, opts)
+			if tc.expectedValidateError != "" {
+				require.ErrorContains(t, err, tc.expectedValidateError)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, opts.getRequiredFeatureFlags(), nvcdi.FeatureEnableMIGAnnotations)
+
+			opts.output = filepath.Join(t.TempDir(), "nvidia.yaml")
+			opts.nvmllib = server
+			generated, err := c.generateSpecs(opts)
+			require.NoError(t, err)
+
+			// Since no MIG devices are present, no MIG spec is generated and
+			// the full GPUs are not annotated.
+			require.Len(t, generated, 1)
+			require.Equal(t, "example.com/device", generated[0].Raw().Kind)
+			for _, d := range generated[0].Raw().Devices {
+				require.NotContains(t, d.Annotations, nvcdi.MIGAnnotation)
+			}
+			require.NoError(t, c.writeSpecs(opts, generated))
+		})
+	}
+}
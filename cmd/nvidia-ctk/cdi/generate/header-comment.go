@@ -0,0 +1,38 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+)
+
+// getHeaderComment returns the header comment to include in the generated
+// specs. This is either specified directly or read from a file.
+func (o *options) getHeaderComment() (string, error) {
+	if o.headerComment != "" && o.headerFile != "" {
+		return "", fmt.Errorf("the --header-comment and --header-file flags are mutually exclusive")
+	}
+	if o.headerFile == "" {
+		return o.headerComment, nil
+	}
+	contents, err := os.ReadFile(o.headerFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read header file: %w", err)
+	}
+	return string(contents), nil
+}
@@ -0,0 +1,73 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHeaderComment(t *testing.T) {
+	headerFile := filepath.Join(t.TempDir(), "header.txt")
+	require.NoError(t, os.WriteFile(headerFile, []byte("SPDX-License-Identifier: Apache-2.0\n"), 0600))
+
+	testCases := []struct {
+		description   string
+		opts          options
+		expected      string
+		expectedError bool
+	}{
+		{
+			description: "no header comment",
+		},
+		{
+			description: "header comment",
+			opts:        options{headerComment: "Generated for example.com"},
+			expected:    "Generated for example.com",
+		},
+		{
+			description: "header file",
+			opts:        options{headerFile: headerFile},
+			expected:    "SPDX-License-Identifier: Apache-2.0\n",
+		},
+		{
+			description:   "missing header file",
+			opts:          options{headerFile: filepath.Join(t.TempDir(), "missing.txt")},
+			expectedError: true,
+		},
+		{
+			description:   "header comment and file are mutually exclusive",
+			opts:          options{headerComment: "Generated for example.com", headerFile: headerFile},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			comment, err := tc.opts.getHeaderComment()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, comment)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// checkHookExecutable checks that the nvidia-cdi-hook or nvidia-ctk executable
+// referenced by the generated hooks exists on the host. Since the hooks are
+// only run when a container is created, a missing executable would otherwise
+// cause every container using the generated spec to fail. The check is
+// skipped if --no-ctk-check is specified, if the executable is mounted into
+// the container, or if no hooks are generated.
+func (o *options) checkHookExecutable() error {
+	if o.noCTKCheck || o.mountToolkit || o.containerHookPath != "" {
+		return nil
+	}
+	if !o.generatesHooks() {
+		return nil
+	}
+
+	info, err := os.Stat(o.nvidiaCDIHookPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("the executable %v referenced by the generated hooks does not exist; "+
+			"install the NVIDIA Container Toolkit, specify the path using --nvidia-cdi-hook-path, or skip this check using --no-ctk-check", o.nvidiaCDIHookPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check the executable referenced by the generated hooks: %w", err)
+	}
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		return fmt.Errorf("the path %v referenced by the generated hooks is not an executable file", o.nvidiaCDIHookPath)
+	}
+	return nil
+}
+
+// generatesHooks returns whether the generated spec could include hooks. This
+// is not the case when probing devices or if all hooks are disabled and none
+// are explicitly enabled.
+func (o *options) generatesHooks() bool {
+	if o.probe {
+		return false
+	}
+	// A device folder mode or group enables the chmod hook.
+	if len(o.enabledHooks) > 0 || o.deviceFolderMode != "" || o.deviceFolderGroup != "" {
+		return true
+	}
+	return !slices.Contains(o.getDisabledHooks(), string(nvcdi.AllHooks))
+}
@@ -0,0 +1,106 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHookExecutable(t *testing.T) {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "nvidia-cdi-hook")
+	require.NoError(t, os.WriteFile(executable, nil, 0755))
+	notExecutable := filepath.Join(dir, "not-executable")
+	require.NoError(t, os.WriteFile(notExecutable, nil, 0644))
+	missing := filepath.Join(dir, "missing")
+
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError bool
+	}{
+		{
+			description: "executable exists",
+			options:     options{nvidiaCDIHookPath: executable},
+		},
+		{
+			description:   "missing executable is rejected",
+			options:       options{nvidiaCDIHookPath: missing},
+			expectedError: true,
+		},
+		{
+			description:   "non-executable file is rejected",
+			options:       options{nvidiaCDIHookPath: notExecutable},
+			expectedError: true,
+		},
+		{
+			description:   "directory is rejected",
+			options:       options{nvidiaCDIHookPath: dir},
+			expectedError: true,
+		},
+		{
+			description: "check can be skipped",
+			options:     options{nvidiaCDIHookPath: missing, noCTKCheck: true},
+		},
+		{
+			description: "mounted toolkit is not checked",
+			options:     options{nvidiaCDIHookPath: missing, mountToolkit: true},
+		},
+		{
+			description: "no hooks are not checked",
+			options:     options{nvidiaCDIHookPath: missing, noHooks: true},
+		},
+		{
+			description: "all hooks disabled are not checked",
+			options:     options{nvidiaCDIHookPath: missing, disabledHooks: []string{"all"}},
+		},
+		{
+			description:   "enabled hook with all hooks disabled is checked",
+			options:       options{nvidiaCDIHookPath: missing, disabledHooks: []string{"all"}, enabledHooks: []string{"update-ldcache"}},
+			expectedError: true,
+		},
+		{
+			description:   "device folder mode with all hooks disabled is checked",
+			options:       options{nvidiaCDIHookPath: missing, disabledHooks: []string{"all"}, deviceFolderMode: "0755"},
+			expectedError: true,
+		},
+		{
+			description:   "some hooks disabled is checked",
+			options:       options{nvidiaCDIHookPath: missing, disabledHooks: []string{"update-ldcache"}},
+			expectedError: true,
+		},
+		{
+			description: "probe is not checked",
+			options:     options{nvidiaCDIHookPath: missing, probe: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.options.checkHookExecutable()
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ldconfig"
+)
+
+const (
+	// imageAnnotation records the container image that a spec was generated
+	// for.
+	imageAnnotation = "cdi.nvidia.com/image"
+	// imageLibcAnnotation records the C library detected in the container
+	// image that a spec was generated for.
+	imageLibcAnnotation = "cdi.nvidia.com/image-libc"
+
+	imageLibcGlibc = "glibc"
+	imageLibcMusl  = "musl"
+)
+
+var imageDigestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// glibcDynamicLinkerPatterns match the glibc dynamic linker in a container
+// image for the supported architectures.
+var glibcDynamicLinkerPatterns = []string{
+	"lib*/ld-linux*.so.*",
+	"lib/*/ld-linux*.so.*",
+	"usr/lib*/ld-linux*.so.*",
+	"usr/lib/*/ld-linux*.so.*",
+}
+
+// validateForImage checks the image reference and the image root filesystem
+// that the spec is generated for. A warning is logged if the image is not
+// referenced by digest since tags may be updated.
+func (m command) validateForImage(opts *options) error {
+	if opts.forImage == "" {
+		if opts.forImageRootfs != "" {
+			return fmt.Errorf("the --for-image-rootfs flag requires --for-image")
+		}
+		return nil
+	}
+	if strings.ContainsAny(opts.forImage, " \t\n") {
+		return fmt.Errorf("invalid image reference %q", opts.forImage)
+	}
+	if _, digest, found := strings.Cut(opts.forImage, "@"); found {
+		if !imageDigestRegexp.MatchString(digest) {
+			return fmt.Errorf("invalid digest in image reference %q: expected sha256:<hex>", opts.forImage)
+		}
+	} else {
+		m.logger.Warningf("Image reference %v does not include a digest; the spec may not match later versions of the image", opts.forImage)
+	}
+
+	if opts.forImageRootfs != "" {
+		info, err := os.Stat(opts.forImageRootfs)
+		if err != nil {
+			return fmt.Errorf("invalid image root filesystem: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("invalid image root filesystem %v: not a directory", opts.forImageRootfs)
+		}
+	}
+	return nil
+}
+
+// detectImageLibc detects the C library used by the container image with the
+// specified root filesystem. An empty string is returned if this cannot be
+// determined.
+func detectImageLibc(rootfs string) string {
+	if ldconfig.IsMuslContainer(rootfs) {
+		return imageLibcMusl
+	}
+	for _, pattern := range glibcDynamicLinkerPatterns {
+		matches, _ := filepath.Glob(filepath.Join(rootfs, pattern))
+		if len(matches) > 0 {
+			return imageLibcGlibc
+		}
+	}
+	return ""
+}
+
+// annotateImage records the image that the specs were generated for in each
+// of the specified specs. If the root filesystem of the image is available,
+// the detected C library is also recorded. This is a no-op if no image was
+// specified.
+func (m command) annotateImage(opts *options, specs []generatedSpecs) {
+	if opts.forImage == "" {
+		return
+	}
+
+	annotations := map[string]string{
+		imageAnnotation: opts.forImage,
+	}
+	if opts.forImageRootfs != "" {
+		switch libc := detectImageLibc(opts.forImageRootfs); libc {
+		case "":
+			m.logger.Warningf("Could not detect the C library used by image %v", opts.forImage)
+		case imageLibcMusl:
+			m.logger.Warningf("Image %v uses musl; the injected driver libraries require glibc compatibility in the container", opts.forImage)
+			fallthrough
+		default:
+			annotations[imageLibcAnnotation] = libc
+		}
+	}
+
+	for _, spec := range specs {
+		raw := spec.Raw()
+		if raw.Annotations == nil {
+			raw.Annotations = make(map[string]string)
+		}
+		for key, value := range annotations {
+			raw.Annotations[key] = value
+		}
+	}
+}
@@ -0,0 +1,159 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestValidateForImage(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{logger: logger}
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	testCases := []struct {
+		description   string
+		opts          options
+		expectedError bool
+	}{
+		{
+			description: "no image",
+		},
+		{
+			description: "image with digest",
+			opts:        options{forImage: "registry.example.com/app@" + digest},
+		},
+		{
+			description: "image with tag",
+			opts:        options{forImage: "registry.example.com/app:1.0"},
+		},
+		{
+			description: "image with rootfs",
+			opts:        options{forImage: "registry.example.com/app@" + digest, forImageRootfs: t.TempDir()},
+		},
+		{
+			description:   "invalid digest",
+			opts:          options{forImage: "registry.example.com/app@sha256:1234"},
+			expectedError: true,
+		},
+		{
+			description:   "rootfs without image",
+			opts:          options{forImageRootfs: t.TempDir()},
+			expectedError: true,
+		},
+		{
+			description:   "missing rootfs",
+			opts:          options{forImage: "registry.example.com/app:1.0", forImageRootfs: filepath.Join(t.TempDir(), "missing")},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := c.validateForImage(&tc.opts)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAnnotateImage(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{logger: logger}
+
+	newRootfs := func(files ...string) string {
+		rootfs := t.TempDir()
+		for _, file := range files {
+			path := filepath.Join(rootfs, file)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, nil, 0600))
+		}
+		return rootfs
+	}
+
+	testCases := []struct {
+		description         string
+		opts                options
+		expectedAnnotations map[string]string
+	}{
+		{
+			description: "no image",
+		},
+		{
+			description: "image without rootfs",
+			opts:        options{forImage: "registry.example.com/app:1.0"},
+			expectedAnnotations: map[string]string{
+				imageAnnotation: "registry.example.com/app:1.0",
+			},
+		},
+		{
+			description: "glibc image",
+			opts:        options{forImage: "registry.example.com/app:1.0", forImageRootfs: newRootfs("lib64/ld-linux-x86-64.so.2")},
+			expectedAnnotations: map[string]string{
+				imageAnnotation:     "registry.example.com/app:1.0",
+				imageLibcAnnotation: "glibc",
+			},
+		},
+		{
+			description: "musl image",
+			opts:        options{forImage: "registry.example.com/app:1.0", forImageRootfs: newRootfs("etc/alpine-release")},
+			expectedAnnotations: map[string]string{
+				imageAnnotation:     "registry.example.com/app:1.0",
+				imageLibcAnnotation: "musl",
+			},
+		},
+		{
+			description: "unknown libc",
+			opts:        options{forImage: "registry.example.com/app:1.0", forImageRootfs: newRootfs()},
+			expectedAnnotations: map[string]string{
+				imageAnnotation: "registry.example.com/app:1.0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s, err := spec.New(
+				spec.WithDeviceSpecs([]specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+						},
+					},
+				}),
+			)
+			require.NoError(t, err)
+
+			c.annotateImage(&tc.opts, []generatedSpecs{{Interface: s}})
+			require.EqualValues(t, tc.expectedAnnotations, s.Raw().Annotations)
+		})
+	}
+}
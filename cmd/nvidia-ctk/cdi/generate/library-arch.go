@@ -0,0 +1,145 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"debug/elf"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// elfMachines maps the supported target architectures to the ELF machine
+// types of the libraries that can be loaded in a container for that
+// architecture. The first entry is the native machine type; subsequent entries
+// are for 32-bit compatibility libraries.
+var elfMachines = map[string][]elf.Machine{
+	"386":     {elf.EM_386},
+	"amd64":   {elf.EM_X86_64, elf.EM_386},
+	"arm":     {elf.EM_ARM},
+	"arm64":   {elf.EM_AARCH64, elf.EM_ARM},
+	"ppc64le": {elf.EM_PPC64},
+}
+
+// A libraryArchMismatch records an injected library whose ELF machine type
+// does not match the target architecture.
+type libraryArchMismatch struct {
+	path    string
+	machine elf.Machine
+}
+
+// getTargetArch returns the architecture that injected libraries are expected
+// to match. This defaults to the architecture of the host.
+func (o *options) getTargetArch() (string, error) {
+	arch := o.targetArch
+	if arch == "" {
+		arch = runtime.GOARCH
+	}
+	if _, ok := elfMachines[arch]; !ok {
+		return "", fmt.Errorf("unsupported target architecture %q; supported architectures are %v", arch, slices.Sorted(maps.Keys(elfMachines)))
+	}
+	return arch, nil
+}
+
+// checkLibraryArchitectures returns the libraries mounted by the specified
+// specs whose ELF machine type does not match the target architecture. Mounts
+// that are not shared libraries or that cannot be read as ELF files are
+// ignored.
+func (o *options) checkLibraryArchitectures(specs []generatedSpecs) ([]libraryArchMismatch, error) {
+	arch, err := o.getTargetArch()
+	if err != nil {
+		return nil, err
+	}
+	allowed := elfMachines[arch]
+
+	var mismatches []libraryArchMismatch
+	seen := make(map[string]bool)
+	for _, mount := range getSpecMounts(specs) {
+		if seen[mount.HostPath] || !isSharedLibrary(mount.HostPath) {
+			continue
+		}
+		seen[mount.HostPath] = true
+
+		machine, err := getELFMachine(mount.HostPath)
+		if err != nil {
+			continue
+		}
+		if !slices.Contains(allowed, machine) {
+			mismatches = append(mismatches, libraryArchMismatch{path: mount.HostPath, machine: machine})
+		}
+	}
+	return mismatches, nil
+}
+
+// warnOnLibraryArchMismatch logs a warning for each library in the specified
+// specs that does not match the target architecture. This allows
+// misconfigurations such as the injection of libraries from a different
+// architecture to be detected early.
+func (m command) warnOnLibraryArchMismatch(opts *options, specs []generatedSpecs) {
+	mismatches, err := opts.checkLibraryArchitectures(specs)
+	if err != nil {
+		m.logger.Warningf("Failed to check library architectures: %v", err)
+		return
+	}
+	for _, mismatch := range mismatches {
+		m.logger.Warningf("Library %v has ELF machine type %v which does not match the target architecture", mismatch.path, mismatch.machine)
+	}
+}
+
+// getSpecMounts returns the common and per-device mounts of the specified
+// specs.
+func getSpecMounts(generated []generatedSpecs) []*specs.Mount {
+	var mounts []*specs.Mount
+	for _, spec := range generated {
+		raw := spec.Raw()
+		mounts = append(mounts, raw.ContainerEdits.Mounts...)
+		for _, device := range raw.Devices {
+			mounts = append(mounts, device.ContainerEdits.Mounts...)
+		}
+	}
+	return mounts
+}
+
+// isSharedLibrary checks whether the specified path refers to a shared
+// library based on its name.
+func isSharedLibrary(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".so") || strings.Contains(base, ".so.")
+}
+
+// getELFMachine returns the ELF machine type of the specified file.
+func getELFMachine(path string) (elf.Machine, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	lib, err := elf.NewFile(f)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ELF file %v: %w", path, err)
+	}
+	defer lib.Close()
+
+	return lib.Machine, nil
+}
@@ -0,0 +1,116 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestCheckLibraryArchitectures(t *testing.T) {
+	if _, ok := elfMachines[runtime.GOARCH]; !ok {
+		t.Skipf("unsupported host architecture %v", runtime.GOARCH)
+	}
+
+	// The test binary is used as a library with the host architecture.
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	library := filepath.Join(dir, "libnvidia-ml.so.1")
+	require.NoError(t, os.Symlink(executable, library))
+	notELF := filepath.Join(dir, "libnotelf.so")
+	require.NoError(t, os.WriteFile(notELF, []byte("not an ELF file"), 0600))
+	notLibrary := filepath.Join(dir, "nvidia-smi")
+	require.NoError(t, os.Symlink(executable, notLibrary))
+
+	s, err := spec.New(
+		spec.WithEdits(specs.ContainerEdits{
+			Mounts: []*specs.Mount{
+				{HostPath: library, ContainerPath: library},
+				{HostPath: notELF, ContainerPath: notELF},
+				{HostPath: notLibrary, ContainerPath: notLibrary},
+				{HostPath: filepath.Join(dir, "libmissing.so.1"), ContainerPath: "/lib/libmissing.so.1"},
+			},
+		}),
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: "0",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+					Mounts: []*specs.Mount{
+						{HostPath: library, ContainerPath: library},
+					},
+				},
+			},
+		}),
+		spec.WithNoSimplify(true),
+	)
+	require.NoError(t, err)
+
+	otherArch := "arm64"
+	if runtime.GOARCH == otherArch {
+		otherArch = "amd64"
+	}
+
+	testCases := []struct {
+		description        string
+		targetArch         string
+		expectedMismatches []libraryArchMismatch
+		expectedError      bool
+	}{
+		{
+			description: "host architecture matches",
+		},
+		{
+			description: "explicit host architecture matches",
+			targetArch:  runtime.GOARCH,
+		},
+		{
+			description: "other architecture does not match",
+			targetArch:  otherArch,
+			expectedMismatches: []libraryArchMismatch{
+				{path: library, machine: elfMachines[runtime.GOARCH][0]},
+			},
+		},
+		{
+			description:   "unsupported architecture",
+			targetArch:    "mips",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := options{targetArch: tc.targetArch}
+			mismatches, err := opts.checkLibraryArchitectures([]generatedSpecs{{Interface: s}})
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedMismatches, mismatches)
+		})
+	}
+}
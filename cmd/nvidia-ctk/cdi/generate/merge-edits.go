@@ -0,0 +1,88 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+// getMergedEdits returns the container edits read from the files specified
+// using --merge-edits-from. The edits from each file are appended in the order
+// that the files were specified.
+func (o *options) getMergedEdits() (*cdi.ContainerEdits, error) {
+	merged := &cdi.ContainerEdits{ContainerEdits: &specs.ContainerEdits{}}
+	for _, filename := range o.mergeEditsFrom {
+		edits, err := loadContainerEdits(filename)
+		if err != nil {
+			return nil, err
+		}
+		merged.Append(edits)
+	}
+	return merged, nil
+}
+
+// loadContainerEdits reads a partial ContainerEdits document in YAML or JSON
+// format from the specified file. Unknown fields are rejected to catch typos
+// in the edits.
+func loadContainerEdits(filename string) (*cdi.ContainerEdits, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read container edits: %w", err)
+	}
+	var raw specs.ContainerEdits
+	if err := yaml.UnmarshalStrict(contents, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse container edits from %v: %w", filename, err)
+	}
+	edits := &cdi.ContainerEdits{ContainerEdits: &raw}
+	if err := edits.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid container edits in %v: %w", filename, err)
+	}
+	return edits, nil
+}
+
+// mergeEdits appends the edits read from the files specified using
+// --merge-edits-from to the specified common edits. Entries that are already
+// present in the common edits are skipped so that the discovered entries take
+// precedence.
+func (o *options) mergeEdits(commonEdits *cdi.ContainerEdits) error {
+	if len(o.mergeEditsFrom) == 0 {
+		return nil
+	}
+	merged, err := o.getMergedEdits()
+	if err != nil {
+		return err
+	}
+	commonEdits.Append(merged)
+
+	s := specs.Spec{ContainerEdits: *commonEdits.ContainerEdits}
+	dedupe, err := transform.NewDedupe()
+	if err != nil {
+		return err
+	}
+	if err := dedupe.Transform(&s); err != nil {
+		return fmt.Errorf("failed to deduplicate merged edits: %w", err)
+	}
+	*commonEdits.ContainerEdits = s.ContainerEdits
+	return nil
+}
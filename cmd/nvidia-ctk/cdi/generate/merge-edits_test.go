@@ -0,0 +1,116 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestLoadContainerEdits(t *testing.T) {
+	testCases := []struct {
+		description   string
+		contents      string
+		expectedEdits *specs.ContainerEdits
+		expectedError bool
+	}{
+		{
+			description: "YAML edits",
+			contents: `env:
+- CORP_PROXY=http://proxy:3128
+mounts:
+- hostPath: /etc/pki/ca-trust
+  containerPath: /etc/pki/ca-trust
+  options: [ro, bind]
+`,
+			expectedEdits: &specs.ContainerEdits{
+				Env: []string{"CORP_PROXY=http://proxy:3128"},
+				Mounts: []*specs.Mount{
+					{HostPath: "/etc/pki/ca-trust", ContainerPath: "/etc/pki/ca-trust", Options: []string{"ro", "bind"}},
+				},
+			},
+		},
+		{
+			description:   "JSON edits",
+			contents:      `{"env": ["FOO=bar"]}`,
+			expectedEdits: &specs.ContainerEdits{Env: []string{"FOO=bar"}},
+		},
+		{
+			description:   "unknown field",
+			contents:      "envs:\n- FOO=bar\n",
+			expectedError: true,
+		},
+		{
+			description:   "invalid edits",
+			contents:      "mounts:\n- hostPath: /etc/pki\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "edits.yaml")
+			require.NoError(t, os.WriteFile(filename, []byte(tc.contents), 0600))
+
+			edits, err := loadContainerEdits(filename)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedEdits, edits.ContainerEdits)
+		})
+	}
+}
+
+func TestMergeEdits(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "edits.yaml")
+	contents := `env:
+- NVIDIA_VISIBLE_DEVICES=void
+- CORP_PROXY=http://proxy:3128
+deviceNodes:
+- path: /dev/nvidiactl
+`
+	require.NoError(t, os.WriteFile(filename, []byte(contents), 0600))
+
+	commonEdits := &cdi.ContainerEdits{
+		ContainerEdits: &specs.ContainerEdits{
+			Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+			DeviceNodes: []*specs.DeviceNode{
+				{Path: "/dev/nvidiactl"},
+			},
+		},
+	}
+
+	opts := options{mergeEditsFrom: []string{filename}}
+	require.NoError(t, opts.mergeEdits(commonEdits))
+
+	require.EqualValues(t,
+		&specs.ContainerEdits{
+			Env: []string{"NVIDIA_VISIBLE_DEVICES=void", "CORP_PROXY=http://proxy:3128"},
+			DeviceNodes: []*specs.DeviceNode{
+				{Path: "/dev/nvidiactl"},
+			},
+		},
+		commonEdits.ContainerEdits,
+	)
+}
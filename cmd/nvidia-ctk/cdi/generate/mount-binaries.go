@@ -0,0 +1,34 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// validateMountBinaries checks that the binaries requested using the
+// --mount-binaries flag are specified as names and not as paths. The binaries
+// are located in the PATH of the driver root.
+func (o *options) validateMountBinaries() error {
+	for _, name := range o.mountBinaries {
+		if name == "" || name != filepath.Base(name) {
+			return fmt.Errorf("invalid binary %q: must be a name without a path", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMountBinaries(t *testing.T) {
+	testCases := []struct {
+		description   string
+		mountBinaries []string
+		expectedError bool
+	}{
+		{
+			description: "no binaries",
+		},
+		{
+			description:   "names are valid",
+			mountBinaries: []string{"nvidia-persistenced", "nv-fabricmanager"},
+		},
+		{
+			description:   "path is rejected",
+			mountBinaries: []string{"/usr/bin/nvidia-persistenced"},
+			expectedError: true,
+		},
+		{
+			description:   "empty name is rejected",
+			mountBinaries: []string{""},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &options{mountBinaries: tc.mountBinaries}
+			err := opts.validateMountBinaries()
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
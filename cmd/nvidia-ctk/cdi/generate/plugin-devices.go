@@ -0,0 +1,89 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// pluginReplicaSeparator separates the ID of a device from the replica index
+// in the device IDs advertised by the Kubernetes device plugin when time-slicing
+// or MPS sharing is configured (e.g. GPU-<uuid>::1).
+const pluginReplicaSeparator = "::"
+
+// getDeviceIDs returns the IDs of the devices to generate device specs for.
+// If a device plugin device list file is specified, the IDs are read from this
+// file. Otherwise the IDs specified using --device-id are returned.
+func (o *options) getDeviceIDs() ([]string, error) {
+	if o.devicesFromPlugin == "" {
+		return o.deviceIDs, nil
+	}
+	return getPluginDeviceIDs(o.devicesFromPlugin)
+}
+
+// getPluginDeviceIDs reads the device IDs from the specified device plugin
+// device list file. The file contains either a JSON array of device IDs or one
+// device ID per line, with empty lines and lines starting with # ignored.
+// Replica suffixes are stripped and duplicate IDs are removed.
+func getPluginDeviceIDs(filename string) ([]string, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device plugin device list: %w", err)
+	}
+
+	var entries []string
+	if trimmed := bytes.TrimSpace(contents); bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse device plugin device list %v: %w", filename, err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(contents))
+		for scanner.Scan() {
+			entries = append(entries, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read device plugin device list %v: %w", filename, err)
+		}
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		id := strings.TrimSpace(entry)
+		if id == "" || strings.HasPrefix(id, "#") {
+			continue
+		}
+		id, _, _ = strings.Cut(id, pluginReplicaSeparator)
+		if id == "all" || id == "none" {
+			return nil, fmt.Errorf("invalid device ID %q in device plugin device list %v", id, filename)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no devices found in device plugin device list %v", filename)
+	}
+	return ids, nil
+}
@@ -0,0 +1,172 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestGetPluginDeviceIDs(t *testing.T) {
+	testCases := []struct {
+		description   string
+		contents      string
+		expectedIDs   []string
+		expectedError string
+	}{
+		{
+			description: "one device ID per line",
+			contents:    "GPU-0\n\n# comment\nGPU-1\n",
+			expectedIDs: []string{"GPU-0", "GPU-1"},
+		},
+		{
+			description: "JSON array of device IDs",
+			contents:    `["GPU-0", "MIG-1"]`,
+			expectedIDs: []string{"GPU-0", "MIG-1"},
+		},
+		{
+			description: "replicas are deduplicated",
+			contents:    "GPU-0::0\nGPU-0::1\nGPU-1::0\n",
+			expectedIDs: []string{"GPU-0", "GPU-1"},
+		},
+		{
+			description:   "empty list is an error",
+			contents:      "# no devices\n",
+			expectedError: "no devices found in device plugin device list",
+		},
+		{
+			description:   "all is not a valid device ID",
+			contents:      "all\n",
+			expectedError: `invalid device ID "all" in device plugin device list`,
+		},
+		{
+			description:   "invalid JSON is an error",
+			contents:      `["GPU-0"`,
+			expectedError: "failed to parse device plugin device list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "devices")
+			require.NoError(t, os.WriteFile(filename, []byte(tc.contents), 0600))
+
+			ids, err := getPluginDeviceIDs(filename)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestGenerateSpecsDevicesFromPlugin(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).IsMigDeviceHandleFunc = func() (bool, nvml.Return) {
+			return false, nvml.SUCCESS
+		}
+	}
+	uuid, ret := server.Devices[0].GetUUID()
+	require.Equal(t, nvml.SUCCESS, ret)
+
+	testCases := []struct {
+		description         string
+		contents            string
+		expectedDeviceNames []string
+		expectedError       string
+	}{
+		{
+			description:         "listed devices are generated",
+			contents:            uuid + "::0\n" + uuid + "::1\n",
+			expectedDeviceNames: []string{"0", "all"},
+		},
+		{
+			description:   "unresolved device is an error",
+			contents:      "GPU-00000000-0000-0000-0000-000000000000\n",
+			expectedError: "failed to resolve devices from device plugin device list",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			pluginDevices := filepath.Join(t.TempDir(), "devices")
+			require.NoError(t, os.WriteFile(pluginDevices, []byte(tc.contents), 0600))
+
+			opts := &options{
+				format:               "yaml",
+				mode:                 "nvml",
+				vendor:               "example.com",
+				class:                "device",
+				deviceNameStrategies: []string{"index"},
+				devicesFromPlugin:    pluginDevices,
+				driverRoot:           driverRoot,
+				nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+				nvmllib:              server,
+			}
+
+			generated, err := c.generateSpecs(opts)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, generated, 1)
+
+			var names []string
+			for _, d := range generated[0].Raw().Devices {
+				names = append(names, d.Name)
+			}
+			require.Equal(t, tc.expectedDeviceNames, names)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// getPreviousDeviceNames returns the names of the devices in the spec
+// specified using --preserve-names-from indexed by the UUID recorded in the
+// nvcdi.UUIDAnnotation device annotation. Devices without this annotation,
+// such as the 'all' device, are ignored. A nil map is returned if the spec
+// does not exist.
+func (o *options) getPreviousDeviceNames() (map[string][]string, error) {
+	contents, err := os.ReadFile(o.preserveNamesFrom)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous spec: %w", err)
+	}
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse previous spec %v: %w", o.preserveNamesFrom, err)
+	}
+
+	names := make(map[string][]string)
+	for _, device := range raw.Devices {
+		uuid := device.Annotations[nvcdi.UUIDAnnotation]
+		if uuid == "" {
+			continue
+		}
+		names[uuid] = append(names[uuid], device.Name)
+	}
+	return names, nil
+}
+
+// preserveDeviceNames renames the specified devices so that a device that was
+// included in the previous spec keeps the name it had there, even if its
+// index has changed. Devices are matched by UUID. Devices that are not in the
+// previous spec keep their generated names unless these are now used by a
+// device with a preserved name. In this case an index name is replaced by the
+// lowest unused index and any other name is replaced by the device UUID.
+func (m command) preserveDeviceNames(opts *options, devices []specs.Device) ([]specs.Device, error) {
+	if opts.preserveNamesFrom == "" {
+		return devices, nil
+	}
+	previous, err := opts.getPreviousDeviceNames()
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		m.logger.Warningf("Previous spec %v does not exist; not preserving device names", opts.preserveNamesFrom)
+		return devices, nil
+	}
+
+	// We first determine the names of devices that were in the previous spec.
+	// Where a device has multiple names (e.g. an index and a UUID name), the
+	// generated names that were not used previously are replaced in order by
+	// the previous names that are no longer generated.
+	preserved := make([]bool, len(devices))
+	used := make(map[string]bool)
+	byUUID := make(map[string][]int)
+	var uuids []string
+	for i, device := range devices {
+		uuid := device.Annotations[nvcdi.UUIDAnnotation]
+		if uuid == "" {
+			continue
+		}
+		if _, ok := byUUID[uuid]; !ok {
+			uuids = append(uuids, uuid)
+		}
+		byUUID[uuid] = append(byUUID[uuid], i)
+	}
+	for _, uuid := range uuids {
+		previousNames, ok := previous[uuid]
+		if !ok {
+			continue
+		}
+		var generated []string
+		for _, i := range byUUID[uuid] {
+			generated = append(generated, devices[i].Name)
+		}
+		var available []string
+		for _, name := range previousNames {
+			if !slices.Contains(generated, name) {
+				available = append(available, name)
+			}
+		}
+		for _, i := range byUUID[uuid] {
+			name := devices[i].Name
+			if !slices.Contains(previousNames, name) {
+				if len(available) == 0 {
+					continue
+				}
+				name, available = available[0], available[1:]
+				m.logger.Infof("Preserving name %q for device %v (generated as %q)", name, uuid, devices[i].Name)
+			}
+			devices[i].Name = name
+			preserved[i] = true
+			used[name] = true
+		}
+	}
+
+	// The remaining devices keep their generated names unless these conflict
+	// with a preserved name.
+	for i, device := range devices {
+		if preserved[i] {
+			continue
+		}
+		name := device.Name
+		if used[name] {
+			var err error
+			name, err = opts.getUnusedDeviceName(device, used)
+			if err != nil {
+				return nil, err
+			}
+			m.logger.Infof("Renaming device %q to %q since its name is used by a device in the previous spec", device.Name, name)
+		}
+		devices[i].Name = name
+		used[name] = true
+	}
+	return devices, nil
+}
+
+// getUnusedDeviceName returns a name for the specified device that is not in
+// the specified set of used names. If the (unprefixed) generated name is an
+// index, the lowest unused index is returned. Otherwise the UUID of the device
+// is used.
+func (o *options) getUnusedDeviceName(device specs.Device, used map[string]bool) (string, error) {
+	name := device.Name
+	if o.devicePrefix != "" {
+		name = strings.TrimPrefix(name, o.devicePrefix+"-")
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		for index := 0; ; index++ {
+			candidate := o.prefixDeviceName(strconv.Itoa(index))
+			if !used[candidate] {
+				return candidate, nil
+			}
+		}
+	}
+	if uuid := device.Annotations[nvcdi.UUIDAnnotation]; uuid != "" {
+		if candidate := o.prefixDeviceName(uuid); !used[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("device name %q is used by a device in the previous spec %v", device.Name, o.preserveNamesFrom)
+}
@@ -0,0 +1,227 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+func TestPreserveDeviceNames(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	device := func(name string, uuid string) specs.Device {
+		d := specs.Device{
+			Name: name,
+			ContainerEdits: specs.ContainerEdits{
+				DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia" + name}},
+			},
+		}
+		if uuid != "" {
+			d.Annotations = map[string]string{nvcdi.UUIDAnnotation: uuid}
+		}
+		return d
+	}
+	names := func(devices []specs.Device) []string {
+		var names []string
+		for _, d := range devices {
+			names = append(names, d.Name)
+		}
+		return names
+	}
+
+	testCases := []struct {
+		description   string
+		previous      []specs.Device
+		devicePrefix  string
+		devices       []specs.Device
+		expectedNames []string
+		expectedError string
+	}{
+		{
+			description:   "previous spec does not exist",
+			devices:       []specs.Device{device("0", "GPU-B"), device("1", "GPU-A")},
+			expectedNames: []string{"0", "1"},
+		},
+		{
+			description:   "unchanged devices keep their names",
+			previous:      []specs.Device{device("0", "GPU-A"), device("1", "GPU-B"), device("all", "")},
+			devices:       []specs.Device{device("0", "GPU-A"), device("1", "GPU-B")},
+			expectedNames: []string{"0", "1"},
+		},
+		{
+			description:   "swapped devices keep their names",
+			previous:      []specs.Device{device("0", "GPU-A"), device("1", "GPU-B")},
+			devices:       []specs.Device{device("0", "GPU-B"), device("1", "GPU-A")},
+			expectedNames: []string{"1", "0"},
+		},
+		{
+			description:   "removed device",
+			previous:      []specs.Device{device("0", "GPU-A"), device("1", "GPU-B")},
+			devices:       []specs.Device{device("0", "GPU-B"), device("1", "GPU-C")},
+			expectedNames: []string{"1", "0"},
+		},
+		{
+			description:   "new device is assigned the lowest unused index",
+			previous:      []specs.Device{device("0", "GPU-A"), device("1", "GPU-B")},
+			devices:       []specs.Device{device("0", "GPU-C"), device("1", "GPU-A"), device("2", "GPU-B")},
+			expectedNames: []string{"2", "0", "1"},
+		},
+		{
+			description: "devices with multiple names",
+			previous: []specs.Device{
+				device("0", "GPU-A"), device("GPU-A", "GPU-A"),
+				device("1", "GPU-B"), device("GPU-B", "GPU-B"),
+			},
+			devices: []specs.Device{
+				device("0", "GPU-B"), device("GPU-B", "GPU-B"),
+				device("1", "GPU-A"), device("GPU-A", "GPU-A"),
+			},
+			expectedNames: []string{"1", "GPU-B", "0", "GPU-A"},
+		},
+		{
+			description:   "device prefix",
+			devicePrefix:  "node",
+			previous:      []specs.Device{device("node-0", "GPU-A"), device("node-1", "GPU-B")},
+			devices:       []specs.Device{device("node-0", "GPU-C"), device("node-1", "GPU-A")},
+			expectedNames: []string{"node-1", "node-0"},
+		},
+		{
+			description:   "conflicting non-index name is replaced by UUID",
+			previous:      []specs.Device{device("gpu-a", "GPU-A")},
+			devices:       []specs.Device{device("gpu-a", "GPU-C"), device("gpu-b", "GPU-A")},
+			expectedNames: []string{"GPU-C", "gpu-a"},
+		},
+		{
+			description:   "conflicting name without UUID",
+			previous:      []specs.Device{device("gpu-a", "GPU-A")},
+			devices:       []specs.Device{device("gpu-a", ""), device("gpu-b", "GPU-A")},
+			expectedError: `device name "gpu-a" is used by a device in the previous spec`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			previousSpec := filepath.Join(t.TempDir(), "nvidia.yaml")
+			if tc.previous != nil {
+				contents := "cdiVersion: 0.5.0\nkind: example.com/device\ndevices:\n"
+				for _, d := range tc.previous {
+					contents += "- name: " + d.Name + "\n"
+					if uuid := d.Annotations[nvcdi.UUIDAnnotation]; uuid != "" {
+						contents += "  annotations:\n    " + nvcdi.UUIDAnnotation + ": " + uuid + "\n"
+					}
+					contents += "  containerEdits:\n    deviceNodes:\n    - path: /dev/nvidia0\n"
+				}
+				require.NoError(t, os.WriteFile(previousSpec, []byte(contents), 0600))
+			}
+
+			opts := &options{
+				devicePrefix:      tc.devicePrefix,
+				preserveNamesFrom: previousSpec,
+			}
+			devices, err := command{logger: logger}.preserveDeviceNames(opts, tc.devices)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedNames, names(devices))
+		})
+	}
+}
+
+func TestGenerateSpecsPreserveNames(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+	uuid := server.Devices[0].(*mockserver.Device).UUID
+
+	output := filepath.Join(t.TempDir(), "nvidia.yaml")
+	opts := &options{
+		format:            "yaml",
+		mode:              "nvml",
+		vendor:            "example.com",
+		class:             "device",
+		deviceIDs:         []string{"all"},
+		driverRoot:        driverRoot,
+		nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
+		preserveNamesFrom: output,
+		nvmllib:           server,
+	}
+	require.NoError(t, c.validateFlags(nil, opts))
+	opts.output = output
+
+	// The first generation records the UUIDs of the devices.
+	generated, err := c.generateSpecs(opts)
+	require.NoError(t, err)
+	require.NoError(t, c.writeSpecs(opts, generated))
+
+	device := generated[0].Raw().Devices[0]
+	require.Equal(t, "0", device.Name)
+	require.Equal(t, uuid, device.Annotations[nvcdi.UUIDAnnotation])
+
+	// The device is renamed in the existing spec so that we can check that
+	// the name is preserved on regeneration.
+	contents, err := os.ReadFile(output)
+	require.NoError(t, err)
+	contents = []byte(strings.Replace(string(contents), "name: \"0\"", "name: \"3\"", 1))
+	require.NoError(t, os.WriteFile(output, contents, 0600))
+
+	opts.overwrite = true
+	generated, err = c.generateSpecs(opts)
+	require.NoError(t, err)
+	require.Equal(t, "3", generated[0].Raw().Devices[0].Name)
+}
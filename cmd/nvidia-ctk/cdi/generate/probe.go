@@ -0,0 +1,115 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+const (
+	probeDeviceName = "probe"
+
+	probeGPUCountAnnotation = "nvidia.com/gpu-count"
+	probeGPUUUIDsAnnotation = "nvidia.com/gpu-uuids"
+)
+
+// generateProbeSpecs generates a minimal spec that records the number of GPUs
+// and their UUIDs as annotations. No discovery of device nodes or driver files
+// is performed, meaning that the spec can be generated cheaply to check that
+// the GPUs on the system can be enumerated.
+// Since a CDI spec must define at least one device, a single probe device that
+// does not modify the container is included.
+func (m command) generateProbeSpecs(opts *options) ([]generatedSpecs, error) {
+	nvmllib, err := m.getNvmlLib(opts)
+	if err != nil {
+		return nil, err
+	}
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+
+	uuids, err := getDeviceUUIDs(nvmllib)
+	if err != nil {
+		return nil, err
+	}
+	m.logger.Infof("Found %d GPUs", len(uuids))
+
+	probeSpec, err := spec.New(
+		spec.WithVendor(opts.vendor),
+		spec.WithClass(opts.class),
+		spec.WithFormat(opts.specFormat()),
+		spec.WithPermissions(0644),
+		spec.WithYAMLIndent(opts.yamlIndent),
+		spec.WithNoYAMLSeparator(opts.noYAMLSeparator),
+		spec.WithJSONIndent(opts.jsonIndent),
+		spec.WithUnsafeKind(opts.unsafeKind),
+		spec.WithTempDir(opts.tempDir),
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: opts.prefixDeviceName(probeDeviceName),
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{image.EnvVarNvidiaVisibleDevices + "=void"},
+				},
+			},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	probeSpec.Raw().Annotations = map[string]string{
+		probeGPUCountAnnotation: strconv.Itoa(len(uuids)),
+		probeGPUUUIDsAnnotation: strings.Join(uuids, ","),
+	}
+
+	return []generatedSpecs{{Interface: probeSpec}}, nil
+}
+
+// getDeviceUUIDs returns the UUIDs of the full GPUs reported by the specified
+// NVML library.
+func getDeviceUUIDs(nvmllib nvml.Interface) (_ []string, rerr error) {
+	if r := nvmllib.Init(); r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %w", r)
+	}
+	defer func() {
+		if r := nvmllib.Shutdown(); r != nvml.SUCCESS && rerr == nil {
+			rerr = fmt.Errorf("failed to shutdown NVML: %w", r)
+		}
+	}()
+
+	var uuids []string
+	err := device.New(nvmllib).VisitDevices(func(i int, d device.Device) error {
+		uuid, r := d.GetUUID()
+		if r != nvml.SUCCESS {
+			return fmt.Errorf("failed to get UUID of device %d: %w", i, r)
+		}
+		uuids = append(uuids, uuid)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uuids, nil
+}
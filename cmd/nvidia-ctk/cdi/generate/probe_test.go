@@ -0,0 +1,74 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestGenerateProbeSpecs(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	var expectedUUIDs []string
+	for _, d := range server.Devices {
+		expectedUUIDs = append(expectedUUIDs, d.(*dgxa100.Device).UUID)
+	}
+
+	opts := &options{
+		format:  "yaml",
+		mode:    "nvml",
+		vendor:  "example.com",
+		class:   "device",
+		nvmllib: server,
+		probe:   true,
+	}
+
+	generated, err := c.generateSpecs(opts)
+	require.NoError(t, err)
+	require.Len(t, generated, 1)
+
+	var buf bytes.Buffer
+	_, err = generated[0].WriteTo(&buf)
+	require.NoError(t, err)
+
+	var raw specs.Spec
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &raw))
+	require.Equal(t, "0.6.0", raw.Version)
+	require.Equal(t, "example.com/device", raw.Kind)
+	require.Equal(t,
+		map[string]string{
+			"nvidia.com/gpu-count": "8",
+			"nvidia.com/gpu-uuids": strings.Join(expectedUUIDs, ","),
+		},
+		raw.Annotations,
+	)
+	require.Len(t, raw.Devices, 1)
+	require.Equal(t, "probe", raw.Devices[0].Name)
+	require.Empty(t, raw.ContainerEdits)
+}
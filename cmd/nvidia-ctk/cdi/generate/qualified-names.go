@@ -0,0 +1,69 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cdi "tags.cncf.io/container-device-interface/pkg/parser"
+)
+
+// qualifiedNamesStdout indicates that the qualified device names are written
+// to STDOUT.
+const qualifiedNamesStdout = "-"
+
+// getQualifiedNames returns the fully-qualified CDI names of the devices in
+// the specified specs. The vendor and class of each name is taken from the
+// kind of the spec containing the device.
+func getQualifiedNames(specs []generatedSpecs) []string {
+	var names []string
+	for _, spec := range specs {
+		raw := spec.Raw()
+		vendor, class := cdi.ParseQualifier(raw.Kind)
+		for _, device := range raw.Devices {
+			names = append(names, cdi.QualifiedName(vendor, class, device.Name))
+		}
+	}
+	return names
+}
+
+// writeQualifiedNames writes the fully-qualified CDI names of the generated
+// devices to the requested file, one name per line.
+func (o *options) writeQualifiedNames(specs []generatedSpecs) error {
+	if o.listQualifiedNames == "" {
+		return nil
+	}
+
+	var contents strings.Builder
+	for _, name := range getQualifiedNames(specs) {
+		contents.WriteString(name + "\n")
+	}
+
+	if o.listQualifiedNames == qualifiedNamesStdout {
+		if _, err := os.Stdout.WriteString(contents.String()); err != nil {
+			return fmt.Errorf("failed to write qualified device names to STDOUT: %w", err)
+		}
+		return nil
+	}
+
+	if err := o.writeFileAtomic(o.listQualifiedNames, []byte(contents.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write qualified device names: %w", err)
+	}
+	return nil
+}
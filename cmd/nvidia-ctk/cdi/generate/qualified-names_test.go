@@ -0,0 +1,91 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestWriteQualifiedNames(t *testing.T) {
+	newSpec := func(class string, names ...string) generatedSpecs {
+		var devices []specs.Device
+		for _, name := range names {
+			devices = append(devices, specs.Device{
+				Name: name,
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia" + name}},
+				},
+			})
+		}
+		s, err := spec.New(
+			spec.WithVendor("nvidia.com"),
+			spec.WithClass(class),
+			spec.WithDeviceSpecs(devices),
+		)
+		require.NoError(t, err)
+		return generatedSpecs{Interface: s}
+	}
+
+	generated := []generatedSpecs{
+		newSpec("gpu", "0", "1"),
+		newSpec("gpu.coherent", "2"),
+	}
+
+	testCases := []struct {
+		description        string
+		listQualifiedNames string
+		expectedContents   string
+	}{
+		{
+			description: "no file is written by default",
+		},
+		{
+			description:        "names are qualified using the spec kind",
+			listQualifiedNames: "names.txt",
+			expectedContents:   "nvidia.com/gpu=0\nnvidia.com/gpu=1\nnvidia.com/gpu.coherent=2\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			opts := options{}
+			if tc.listQualifiedNames != "" {
+				opts.listQualifiedNames = filepath.Join(dir, tc.listQualifiedNames)
+			}
+
+			require.NoError(t, opts.writeQualifiedNames(generated))
+
+			if tc.listQualifiedNames == "" {
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				require.Empty(t, entries)
+				return
+			}
+			contents, err := os.ReadFile(opts.listQualifiedNames)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedContents, string(contents))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// validateRelativeTo checks whether the base that mount host paths are made
+// relative to is valid.
+func (o *options) validateRelativeTo() error {
+	if o.relativeTo == "" {
+		return nil
+	}
+	if !filepath.IsAbs(o.relativeTo) {
+		return fmt.Errorf("invalid value for --relative-to %q: an absolute path is required", o.relativeTo)
+	}
+	o.relativeTo = filepath.Clean(o.relativeTo)
+	return nil
+}
+
+// makeMountsRelative rewrites the host paths of the mounts below the
+// configured base to be relative to the base. This applies to the mounts of
+// the individual devices and those common to all devices. Mounts outside the
+// base are left unchanged. Since the base itself cannot be expressed as a
+// relative path that is re-anchored at the driver root, a mount of the base is
+// an error.
+func (m command) makeMountsRelative(opts *options, commonEdits *specs.ContainerEdits, devices []specs.Device) error {
+	if opts.relativeTo == "" {
+		return nil
+	}
+
+	rewrite := func(edits *specs.ContainerEdits) error {
+		for _, mount := range edits.Mounts {
+			relative, err := filepath.Rel(opts.relativeTo, mount.HostPath)
+			if err != nil || relative == ".." || strings.HasPrefix(relative, "../") {
+				m.logger.Debugf("Not rewriting mount %v outside of %v", mount.HostPath, opts.relativeTo)
+				continue
+			}
+			if relative == "." {
+				return fmt.Errorf("cannot make mount %v relative to itself", mount.HostPath)
+			}
+			mount.HostPath = relative
+		}
+		return nil
+	}
+
+	if err := rewrite(commonEdits); err != nil {
+		return err
+	}
+	for i := range devices {
+		if err := rewrite(&devices[i].ContainerEdits); err != nil {
+			return fmt.Errorf("device %v: %w", devices[i].Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestMakeMountsRelative(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	newEdits := func(hostPaths ...string) specs.ContainerEdits {
+		var edits specs.ContainerEdits
+		for _, hostPath := range hostPaths {
+			edits.Mounts = append(edits.Mounts, &specs.Mount{HostPath: hostPath, ContainerPath: "/container"})
+		}
+		return edits
+	}
+
+	testCases := []struct {
+		description         string
+		relativeTo          string
+		commonEdits         specs.ContainerEdits
+		devices             []specs.Device
+		expectedError       string
+		expectedCommonEdits specs.ContainerEdits
+		expectedDevices     []specs.Device
+	}{
+		{
+			description:         "no base",
+			commonEdits:         newEdits("/run/nvidia/driver/usr/lib64/libcuda.so.1"),
+			expectedCommonEdits: newEdits("/run/nvidia/driver/usr/lib64/libcuda.so.1"),
+		},
+		{
+			description:         "mounts below the base are rewritten",
+			relativeTo:          "/run/nvidia/driver/",
+			commonEdits:         newEdits("/run/nvidia/driver/usr/lib64/libcuda.so.1", "/usr/bin/nvidia-cdi-hook", "/run/nvidia/driver-other/lib"),
+			devices:             []specs.Device{{Name: "0", ContainerEdits: newEdits("/run/nvidia/driver/lib/firmware/nvidia")}},
+			expectedCommonEdits: newEdits("usr/lib64/libcuda.so.1", "/usr/bin/nvidia-cdi-hook", "/run/nvidia/driver-other/lib"),
+			expectedDevices:     []specs.Device{{Name: "0", ContainerEdits: newEdits("lib/firmware/nvidia")}},
+		},
+		{
+			description:   "mount of the base is rejected",
+			relativeTo:    "/run/nvidia/driver",
+			devices:       []specs.Device{{Name: "0", ContainerEdits: newEdits("/run/nvidia/driver/")}},
+			expectedError: "device 0: cannot make mount /run/nvidia/driver/ relative to itself",
+		},
+		{
+			description:   "relative base is rejected",
+			relativeTo:    "run/nvidia/driver",
+			expectedError: `invalid value for --relative-to "run/nvidia/driver": an absolute path is required`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			opts := &options{relativeTo: tc.relativeTo}
+			err := opts.validateRelativeTo()
+			if err == nil {
+				err = command{logger: logger}.makeMountsRelative(opts, &tc.commonEdits, tc.devices)
+			}
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			require.EqualValues(t, tc.expectedCommonEdits, tc.commonEdits)
+			require.EqualValues(t, tc.expectedDevices, tc.devices)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	dumpSchemaFlagName = "dump-schema"
+)
+
+// schema provides a machine-readable description of the flags supported by a
+// command.
+type schema struct {
+	Command string       `json:"command"`
+	Flags   []flagSchema `json:"flags"`
+}
+
+// flagSchema describes a single command line flag.
+type flagSchema struct {
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	ItemsType string   `json:"itemsType,omitempty"`
+	Default   any      `json:"default,omitempty"`
+	EnvVars   []string `json:"envVars,omitempty"`
+	Usage     string   `json:"usage,omitempty"`
+}
+
+// dumpSchema writes a JSON description of the visible flags of the specified
+// command to the writer.
+func dumpSchema(cmd *cli.Command, w io.Writer) error {
+	s := schema{
+		Command: cmd.Name,
+	}
+	for _, flag := range cmd.Flags {
+		if v, ok := flag.(cli.VisibleFlag); ok && !v.IsVisible() {
+			continue
+		}
+		s.Flags = append(s.Flags, newFlagSchema(flag))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return nil
+}
+
+func newFlagSchema(flag cli.Flag) flagSchema {
+	names := flag.Names()
+	fs := flagSchema{
+		Name:    names[0],
+		Aliases: names[1:],
+		Default: flagDefault(flag),
+	}
+	if f, ok := flag.(cli.SchemaTyper); ok {
+		fs.Type = f.SchemaType()
+	}
+	if f, ok := flag.(cli.SchemaItemsTyper); ok {
+		fs.ItemsType = f.SchemaItemsType()
+	}
+	if f, ok := flag.(cli.DocGenerationFlag); ok {
+		fs.EnvVars = f.GetEnvVars()
+		fs.Usage = f.GetUsage()
+	}
+	return fs
+}
+
+// flagDefault returns the default value for the specified flag. All the flag
+// types provided by urfave/cli define the default using a Value field.
+func flagDefault(flag cli.Flag) any {
+	v := reflect.ValueOf(flag)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	value := v.Elem().FieldByName("Value")
+	if !value.IsValid() || value.IsZero() {
+		return nil
+	}
+	return value.Interface()
+}
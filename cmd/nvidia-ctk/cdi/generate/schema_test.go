@@ -0,0 +1,89 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpSchema(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	configFilePath := ""
+	cmd := NewCommand(logger, &configFilePath)
+
+	var buf bytes.Buffer
+	require.NoError(t, dumpSchema(cmd, &buf))
+
+	var s schema
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &s))
+	require.Equal(t, "generate", s.Command)
+
+	flags := make(map[string]flagSchema)
+	for _, f := range s.Flags {
+		flags[f.Name] = f
+	}
+
+	require.NotContains(t, flags, dumpSchemaFlagName)
+	require.Equal(t,
+		flagSchema{
+			Name:      "device-name-strategy",
+			Type:      "array",
+			ItemsType: "string",
+			Default:   []any{"index", "uuid"},
+			EnvVars:   []string{"NVIDIA_CTK_CDI_GENERATE_DEVICE_NAME_STRATEGIES"},
+			Usage:     flags["device-name-strategy"].Usage,
+		},
+		flags["device-name-strategy"],
+	)
+	require.Equal(t,
+		flagSchema{
+			Name:    "no-all-device",
+			Type:    "boolean",
+			EnvVars: []string{"NVIDIA_CTK_CDI_GENERATE_NO_ALL_DEVICE"},
+			Usage:   flags["no-all-device"].Usage,
+		},
+		flags["no-all-device"],
+	)
+}
+
+func TestFlagsHaveEnvVars(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	configFilePath := ""
+	cmd := NewCommand(logger, &configFilePath)
+
+	var buf bytes.Buffer
+	require.NoError(t, dumpSchema(cmd, &buf))
+
+	var s schema
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &s))
+
+	// Each flag can be set using an environment variable so that the command
+	// can be configured without command line arguments.
+	seen := make(map[string]string)
+	for _, f := range s.Flags {
+		require.NotEmpty(t, f.EnvVars, "flag %q has no environment variable", f.Name)
+		for _, envVar := range f.EnvVars {
+			require.NotContains(t, seen, envVar, "environment variable %q is used by flags %q and %q", envVar, seen[envVar], f.Name)
+			seen[envVar] = f.Name
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	signing "github.com/NVIDIA/nvidia-container-toolkit/internal/cdi-signing"
+)
+
+// getSigner loads the configured signing key. Since the signature is written to
+// a sidecar file of the output, an output path with an explicit extension is
+// required.
+func (o *options) getSigner() (*signing.Signer, error) {
+	if o.output == "" {
+		return nil, fmt.Errorf("the --sign-key flag requires an output path")
+	}
+	if ext := filepath.Ext(o.output); ext != ".yaml" && ext != ".json" {
+		return nil, fmt.Errorf("the --sign-key flag requires an output path with a .yaml or .json extension")
+	}
+	signer, err := signing.NewSignerFromFile(o.signKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing key: %w", err)
+	}
+	return signer, nil
+}
+
+// annotateSigningKey records the ID of the signing key in each of the
+// specified specs. This is a no-op if signing is not enabled.
+func (o *options) annotateSigningKey(specs []generatedSpecs) {
+	if o.signer == nil {
+		return
+	}
+	for _, spec := range specs {
+		raw := spec.Raw()
+		if raw.Annotations == nil {
+			raw.Annotations = make(map[string]string)
+		}
+		raw.Annotations[signing.SigningKeyAnnotation] = o.signer.KeyID()
+	}
+}
+
+// signOutput writes a detached signature for each of the files that the
+// specified specs were written to. This is a no-op if signing is not enabled.
+func (o *options) signOutput(specs []generatedSpecs) error {
+	if o.signer == nil {
+		return nil
+	}
+	for _, filename := range o.outputFilenames(specs) {
+		if err := o.signer.SignFile(filename); err != nil {
+			return fmt.Errorf("failed to sign %v: %w", filename, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	snapshot "github.com/NVIDIA/nvidia-container-toolkit/internal/nvml-snapshot"
+)
+
+// getNvmlLib returns the NVML library to use for spec generation.
+// If a snapshot file is specified, an NVML library backed by the snapshot is
+// returned. If a snapshot is to be saved, this is captured from the NVML
+// library before it is returned.
+func (m command) getNvmlLib(opts *options) (nvml.Interface, error) {
+	if opts.fromSnapshot != "" {
+		s, err := snapshot.Load(opts.fromSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		m.logger.Infof("Using NVML snapshot from %v", opts.fromSnapshot)
+		return s.NvmlLib(), nil
+	}
+
+	if opts.saveSnapshot == "" {
+		return opts.nvmllib, nil
+	}
+
+	nvmllib := opts.nvmllib
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+	s, err := snapshot.Capture(nvmllib)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture NVML snapshot: %w", err)
+	}
+	if err := s.Save(opts.saveSnapshot); err != nil {
+		return nil, err
+	}
+	m.logger.Infof("Saved NVML snapshot to %v", opts.saveSnapshot)
+
+	return nvmllib, nil
+}
@@ -0,0 +1,115 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// The following phases are timed by the generate command itself. The NVML
+// initialization and MIG discovery phases are reported by the nvcdi library.
+const (
+	phaseDeviceDiscovery = "device-discovery"
+	phaseCommonDiscovery = "common-discovery"
+	phaseMarshal         = "marshal"
+	phaseWrite           = "write"
+)
+
+// timings records the accumulated duration of each phase of spec generation.
+// A nil *timings is valid and records nothing.
+type timings struct {
+	sync.Mutex
+	phases    []string
+	durations map[string]time.Duration
+}
+
+// phaseTiming is the JSON representation of the duration of a single phase.
+type phaseTiming struct {
+	Phase   string  `json:"phase"`
+	Seconds float64 `json:"seconds"`
+}
+
+func newTimings() *timings {
+	return &timings{
+		durations: make(map[string]time.Duration),
+	}
+}
+
+// record adds the specified duration to the specified phase.
+func (t *timings) record(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.durations[phase]; !ok {
+		t.phases = append(t.phases, phase)
+	}
+	t.durations[phase] += d
+}
+
+// track starts timing the specified phase. The returned function stops timing
+// and records the duration. This allows for:
+//
+//	defer opts.timings.track(phaseWrite)()
+func (t *timings) track(phase string) func() {
+	start := time.Now()
+	return func() {
+		t.record(phase, time.Since(start))
+	}
+}
+
+// recorder returns a duration recorder for use by the nvcdi library. A nil
+// recorder is returned if timings are not being recorded.
+func (t *timings) recorder() nvcdi.DurationRecorder {
+	if t == nil {
+		return nil
+	}
+	return t.record
+}
+
+// writeJSON writes the recorded durations to the specified writer as a JSON
+// object. Phases are listed in the order in which they were first recorded.
+func (t *timings) writeJSON(w io.Writer) error {
+	if t == nil {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+
+	output := struct {
+		Phases []phaseTiming `json:"phases"`
+	}{
+		Phases: []phaseTiming{},
+	}
+	for _, phase := range t.phases {
+		output.Phases = append(output.Phases, phaseTiming{
+			Phase:   phase,
+			Seconds: t.durations[phase].Seconds(),
+		})
+	}
+	if err := json.NewEncoder(w).Encode(output); err != nil {
+		return fmt.Errorf("failed to write timings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimings(t *testing.T) {
+	timings := newTimings()
+	timings.record("device-discovery", 2*time.Second)
+	timings.record("nvml-init", time.Second)
+	timings.recorder()("device-discovery", 500*time.Millisecond)
+	timings.track("write")()
+
+	var buf bytes.Buffer
+	require.NoError(t, timings.writeJSON(&buf))
+
+	var output struct {
+		Phases []phaseTiming `json:"phases"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	require.Len(t, output.Phases, 3)
+	require.Equal(t, phaseTiming{Phase: "device-discovery", Seconds: 2.5}, output.Phases[0])
+	require.Equal(t, phaseTiming{Phase: "nvml-init", Seconds: 1}, output.Phases[1])
+	require.Equal(t, "write", output.Phases[2].Phase)
+	require.GreaterOrEqual(t, output.Phases[2].Seconds, float64(0))
+}
+
+func TestTimingsNil(t *testing.T) {
+	var timings *timings
+	timings.record("device-discovery", time.Second)
+	timings.track("write")()
+	require.Nil(t, timings.recorder())
+
+	var buf bytes.Buffer
+	require.NoError(t, timings.writeJSON(&buf))
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,99 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+const (
+	nvidiaCTKBinary     = "nvidia-ctk"
+	nvidiaCDIHookBinary = "nvidia-cdi-hook"
+)
+
+// getToolkitMounts returns a discoverer for the NVIDIA Container Toolkit
+// binaries if these were requested or if the generated hooks reference a
+// container-internal path. The binaries are located alongside the
+// running executable and are mounted into the container at the path of the
+// nvidia-cdi-hook referenced by the generated hooks.
+func (o *options) getToolkitMounts() (discover.Discover, error) {
+	if !o.mountToolkit && o.containerHookPath == "" {
+		return nil, nil
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine executable path: %w", err)
+	}
+	executable, err = filepath.EvalSymlinks(executable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve executable path: %w", err)
+	}
+	return newToolkitMounts(filepath.Dir(executable), o.nvidiaCDIHookPath)
+}
+
+// newToolkitMounts creates a discoverer for the nvidia-ctk and
+// nvidia-cdi-hook binaries in the specified host directory. The
+// nvidia-cdi-hook is mounted at the specified hook path and the nvidia-ctk is
+// mounted alongside it. If the hook path refers to nvidia-ctk, the
+// nvidia-cdi-hook is mounted alongside it instead.
+func newToolkitMounts(hostDir string, nvidiaCDIHookPath string) (discover.Discover, error) {
+	containerDir := filepath.Dir(nvidiaCDIHookPath)
+	containerPaths := map[string]string{
+		nvidiaCTKBinary:     filepath.Join(containerDir, nvidiaCTKBinary),
+		nvidiaCDIHookBinary: filepath.Join(containerDir, nvidiaCDIHookBinary),
+	}
+	if filepath.Base(nvidiaCDIHookPath) != nvidiaCTKBinary {
+		containerPaths[nvidiaCDIHookBinary] = nvidiaCDIHookPath
+	}
+
+	var mounts []discover.Discover
+	for _, binary := range []string{nvidiaCTKBinary, nvidiaCDIHookBinary} {
+		hostPath := filepath.Join(hostDir, binary)
+		if _, err := os.Stat(hostPath); err != nil {
+			return nil, fmt.Errorf("failed to locate %v: %w", binary, err)
+		}
+		mounts = append(mounts, &discover.Mount{
+			HostPath: hostPath,
+			Path:     containerPaths[binary],
+			Options:  defaultAdditionalMountOptions,
+		})
+	}
+	return discover.Merge(mounts...), nil
+}
+
+// validateContainerHookPath checks whether the specified path is a valid
+// container-internal path for the hook executable. The path must be absolute
+// and clean and must refer to either nvidia-ctk or nvidia-cdi-hook so that the
+// correct hook arguments are generated.
+func validateContainerHookPath(path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("container hook path %q is not absolute", path)
+	}
+	if filepath.Clean(path) != path {
+		return fmt.Errorf("container hook path %q is not clean", path)
+	}
+	switch filepath.Base(path) {
+	case nvidiaCTKBinary, nvidiaCDIHookBinary:
+		return nil
+	default:
+		return fmt.Errorf("container hook path %q must refer to %v or %v", path, nvidiaCTKBinary, nvidiaCDIHookBinary)
+	}
+}
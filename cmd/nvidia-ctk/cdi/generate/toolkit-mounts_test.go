@@ -0,0 +1,126 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestNewToolkitMounts(t *testing.T) {
+	testCases := []struct {
+		description    string
+		binaries       []string
+		hookPath       string
+		expectedMounts []discover.Mount
+		expectedError  bool
+	}{
+		{
+			description: "binaries are mounted at hook path",
+			binaries:    []string{"nvidia-ctk", "nvidia-cdi-hook"},
+			hookPath:    "/usr/local/bin/nvidia-cdi-hook",
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "nvidia-ctk",
+					Path:     "/usr/local/bin/nvidia-ctk",
+					Options:  defaultAdditionalMountOptions,
+				},
+				{
+					HostPath: "nvidia-cdi-hook",
+					Path:     "/usr/local/bin/nvidia-cdi-hook",
+					Options:  defaultAdditionalMountOptions,
+				},
+			},
+		},
+		{
+			description: "nvidia-cdi-hook is mounted alongside nvidia-ctk hook path",
+			binaries:    []string{"nvidia-ctk", "nvidia-cdi-hook"},
+			hookPath:    "/usr/local/nvidia/bin/nvidia-ctk",
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "nvidia-ctk",
+					Path:     "/usr/local/nvidia/bin/nvidia-ctk",
+					Options:  defaultAdditionalMountOptions,
+				},
+				{
+					HostPath: "nvidia-cdi-hook",
+					Path:     "/usr/local/nvidia/bin/nvidia-cdi-hook",
+					Options:  defaultAdditionalMountOptions,
+				},
+			},
+		},
+		{
+			description:   "missing binary is an error",
+			binaries:      []string{"nvidia-ctk"},
+			hookPath:      "/usr/bin/nvidia-cdi-hook",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hostDir := t.TempDir()
+			for _, binary := range tc.binaries {
+				require.NoError(t, os.WriteFile(filepath.Join(hostDir, binary), nil, 0755))
+			}
+
+			d, err := newToolkitMounts(hostDir, tc.hookPath)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			for i := range tc.expectedMounts {
+				tc.expectedMounts[i].HostPath = filepath.Join(hostDir, tc.expectedMounts[i].HostPath)
+			}
+			require.Equal(t, tc.expectedMounts, mounts)
+		})
+	}
+}
+
+func TestValidateContainerHookPath(t *testing.T) {
+	testCases := []struct {
+		path          string
+		expectedError bool
+	}{
+		{path: "/usr/local/nvidia/bin/nvidia-ctk"},
+		{path: "/usr/local/nvidia/bin/nvidia-cdi-hook"},
+		{path: "usr/local/nvidia/bin/nvidia-ctk", expectedError: true},
+		{path: "/usr/local/nvidia/../bin/nvidia-ctk", expectedError: true},
+		{path: "/usr/local/nvidia/bin/", expectedError: true},
+		{path: "/usr/local/nvidia/bin/hook", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			err := validateContainerHookPath(tc.path)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// unixSocketOutputPrefix is the prefix of an output that refers to a unix
+	// socket instead of a file.
+	unixSocketOutputPrefix = "unix://"
+
+	unixSocketDialTimeout = 10 * time.Second
+)
+
+// getUnixSocketOutput returns the path of the unix socket that the generated
+// specs are written to. An empty string is returned if the output is not a
+// unix socket.
+func (o *options) getUnixSocketOutput() string {
+	if !o.isUnixSocketOutput() {
+		return ""
+	}
+	return strings.TrimPrefix(o.output, unixSocketOutputPrefix)
+}
+
+// isUnixSocketOutput checks whether the generated specs are written to a unix
+// socket.
+func (o *options) isUnixSocketOutput() bool {
+	return strings.HasPrefix(o.output, unixSocketOutputPrefix)
+}
+
+// validateUnixSocketOutput checks whether the other specified options are
+// compatible with writing the generated specs to a unix socket.
+func (o *options) validateUnixSocketOutput() error {
+	if !o.isUnixSocketOutput() {
+		return nil
+	}
+	if o.getUnixSocketOutput() == "" {
+		return fmt.Errorf("invalid output %q: a unix socket path is required", o.output)
+	}
+	if o.updateContainerEdits {
+		return fmt.Errorf("the --update-container-edits flag is not supported when writing to a unix socket")
+	}
+	if o.signKey != "" {
+		return fmt.Errorf("the --sign-key flag is not supported when writing to a unix socket")
+	}
+	return nil
+}
+
+// writeToUnixSocket writes the specified specs to the unix socket at the
+// specified path. The specs are marshaled as for STDOUT output and are written
+// using a single connection.
+func (m command) writeToUnixSocket(opts *options, path string, specs []generatedSpecs) (rerr error) {
+	var contents bytes.Buffer
+	for _, spec := range specs {
+		if _, err := spec.WriteTo(&contents); err != nil {
+			return fmt.Errorf("failed to write CDI spec: %w", err)
+		}
+		m.logGeneratedSpec(opts, spec)
+	}
+
+	conn, err := net.DialTimeout("unix", path, unixSocketDialTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to unix socket %v: %w", path, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil && rerr == nil {
+			rerr = fmt.Errorf("failed to close connection to unix socket %v: %w", path, err)
+		}
+	}()
+
+	if _, err := contents.WriteTo(conn); err != nil {
+		return fmt.Errorf("failed to write CDI spec to unix socket %v: %w", path, err)
+	}
+	return nil
+}
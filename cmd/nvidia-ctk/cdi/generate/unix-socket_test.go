@@ -0,0 +1,127 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+func TestWriteToUnixSocket(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	m := command{logger: logger}
+
+	s, err := spec.New(
+		spec.WithVendor("nvidia.com"),
+		spec.WithClass("gpu"),
+		spec.WithFormat(spec.FormatJSON),
+		spec.WithDeviceSpecs([]specs.Device{
+			{
+				Name: "0",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	generated := []generatedSpecs{{Interface: s}}
+
+	t.Run("spec is written to listening socket", func(t *testing.T) {
+		socketPath := filepath.Join(t.TempDir(), "agent.sock")
+		listener, err := net.Listen("unix", socketPath)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		received := make(chan string, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				received <- ""
+				return
+			}
+			defer conn.Close()
+			contents, _ := io.ReadAll(conn)
+			received <- string(contents)
+		}()
+
+		opts := options{output: unixSocketOutputPrefix + socketPath}
+		require.NoError(t, m.writeSpecs(&opts, generated))
+
+		contents := <-received
+		require.True(t, strings.HasPrefix(contents, "{"), "expected JSON output: %q", contents)
+		require.Contains(t, contents, `"kind":"nvidia.com/gpu"`)
+	})
+
+	t.Run("missing socket returns an error", func(t *testing.T) {
+		socketPath := filepath.Join(t.TempDir(), "missing.sock")
+		err := m.writeToUnixSocket(&options{}, socketPath, generated)
+		require.ErrorContains(t, err, "failed to connect to unix socket "+socketPath)
+	})
+}
+
+func TestValidateUnixSocketOutput(t *testing.T) {
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError string
+	}{
+		{
+			description: "file output is ignored",
+			options:     options{output: "/etc/cdi/nvidia.yaml", signKey: "key.pem"},
+		},
+		{
+			description: "socket output is valid",
+			options:     options{output: "unix:///run/agent.sock"},
+		},
+		{
+			description:   "socket path is required",
+			options:       options{output: "unix://"},
+			expectedError: `invalid output "unix://": a unix socket path is required`,
+		},
+		{
+			description:   "signing is not supported",
+			options:       options{output: "unix:///run/agent.sock", signKey: "key.pem"},
+			expectedError: "the --sign-key flag is not supported when writing to a unix socket",
+		},
+		{
+			description:   "updating container edits is not supported",
+			options:       options{output: "unix:///run/agent.sock", updateContainerEdits: true},
+			expectedError: "the --update-container-edits flag is not supported when writing to a unix socket",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.options.validateUnixSocketOutput()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
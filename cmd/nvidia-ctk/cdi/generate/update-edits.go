@@ -0,0 +1,65 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"os"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// updateExistingSpec replaces the container edits of the existing spec at
+// the output path with the specified edits. The devices of the existing spec
+// are left unmodified so that device names remain stable when only the
+// driver files have changed.
+func (o *options) updateExistingSpec(edits specs.ContainerEdits) ([]generatedSpecs, error) {
+	contents, err := os.ReadFile(o.output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing spec: %w", err)
+	}
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse existing spec %v: %w", o.output, err)
+	}
+	if kind := o.vendor + "/" + o.class; raw.Kind != kind {
+		return nil, fmt.Errorf("existing spec %v has kind %q; expected %q", o.output, raw.Kind, kind)
+	}
+
+	raw.ContainerEdits = edits
+	// The updated edits may require a newer spec version. Clearing the version
+	// ensures that the minimum required version is detected on save.
+	raw.Version = ""
+
+	s, err := spec.New(
+		spec.WithRawSpec(raw),
+		spec.WithFormat(o.specFormat()),
+		spec.WithPermissions(0644),
+		spec.WithYAMLIndent(o.yamlIndent),
+		spec.WithNoYAMLSeparator(o.noYAMLSeparator),
+		spec.WithJSONIndent(o.jsonIndent),
+		spec.WithUnsafeKind(o.unsafeKind),
+		spec.WithTempDir(o.tempDir),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create updated spec: %w", err)
+	}
+	return []generatedSpecs{{Interface: s}}, nil
+}
@@ -0,0 +1,141 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// validateSpecs validates each of the specified specs if strict validation
+// was requested. The errors for all specs are returned.
+func (o *options) validateSpecs(generated []generatedSpecs) error {
+	if !o.strictValidation {
+		return nil
+	}
+	var errs error
+	for _, spec := range generated {
+		if err := o.validateSpec(spec.Raw()); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid CDI spec for kind %q: %w", spec.Raw().Kind, err))
+		}
+	}
+	return errs
+}
+
+// validateSpec validates the specified spec using the validation applied by
+// the container-device-interface package when loading a spec. In contrast to
+// the container-device-interface package, which returns the first error, all
+// errors are collected. If unsafe kinds are allowed, the kind is not
+// validated.
+func (o *options) validateSpec(raw *specs.Spec) error {
+	var errs error
+	if err := specs.ValidateVersion(raw); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if !o.unsafeKind {
+		vendor, class := parser.ParseQualifier(raw.Kind)
+		if err := parser.ValidateVendorName(vendor); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if err := parser.ValidateClassName(class); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	errs = errors.Join(errs, validateSpecAnnotations("spec", raw.Annotations))
+	errs = errors.Join(errs, validateContainerEdits("spec", &raw.ContainerEdits))
+
+	if len(raw.Devices) == 0 {
+		errs = errors.Join(errs, errors.New("no devices"))
+	}
+	seen := make(map[string]bool)
+	for _, d := range raw.Devices {
+		if err := parser.ValidateDeviceName(d.Name); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if seen[d.Name] {
+			errs = errors.Join(errs, fmt.Errorf("multiple devices named %q", d.Name))
+		}
+		seen[d.Name] = true
+
+		subject := fmt.Sprintf("device %q", d.Name)
+		errs = errors.Join(errs, validateSpecAnnotations(subject, d.Annotations))
+		if isEmptyContainerEdits(&d.ContainerEdits) {
+			errs = errors.Join(errs, fmt.Errorf("%v: no container edits", subject))
+		}
+		errs = errors.Join(errs, validateContainerEdits(subject, &d.ContainerEdits))
+	}
+	return errs
+}
+
+// validateSpecAnnotations validates the keys of the specified annotations.
+func validateSpecAnnotations(subject string, annotations map[string]string) error {
+	var errs error
+	for key := range annotations {
+		if err := validateAnnotationKey(key); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%v: invalid annotation: %w", subject, err))
+		}
+	}
+	return errs
+}
+
+// validateContainerEdits validates each of the specified container edits.
+func validateContainerEdits(subject string, edits *specs.ContainerEdits) error {
+	var errs error
+	if err := cdi.ValidateEnv(edits.Env); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("%v: %w", subject, err))
+	}
+	for _, d := range edits.DeviceNodes {
+		if err := (&cdi.DeviceNode{DeviceNode: d}).Validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%v: %w", subject, err))
+		}
+	}
+	for _, h := range edits.Hooks {
+		if err := (&cdi.Hook{Hook: h}).Validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%v: %w", subject, err))
+		}
+	}
+	for _, m := range edits.Mounts {
+		if err := (&cdi.Mount{Mount: m}).Validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%v: %w", subject, err))
+		}
+	}
+	if edits.IntelRdt != nil {
+		if err := (&cdi.IntelRdt{IntelRdt: edits.IntelRdt}).Validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%v: %w", subject, err))
+		}
+	}
+	if err := cdi.ValidateNetDevices(edits.NetDevices); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("%v: %w", subject, err))
+	}
+	return errs
+}
+
+// isEmptyContainerEdits checks whether the specified container edits are
+// empty. This is valid for the spec, but not for a device.
+func isEmptyContainerEdits(edits *specs.ContainerEdits) bool {
+	return len(edits.Env) == 0 &&
+		len(edits.DeviceNodes) == 0 &&
+		len(edits.Hooks) == 0 &&
+		len(edits.Mounts) == 0 &&
+		len(edits.AdditionalGIDs) == 0 &&
+		edits.IntelRdt == nil &&
+		len(edits.NetDevices) == 0
+}
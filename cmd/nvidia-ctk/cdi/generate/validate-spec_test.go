@@ -0,0 +1,121 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestValidateSpec(t *testing.T) {
+	validDevice := func(name string) specs.Device {
+		return specs.Device{
+			Name: name,
+			ContainerEdits: specs.ContainerEdits{
+				DeviceNodes: []*specs.DeviceNode{{Path: "/dev/nvidia0"}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description    string
+		unsafeKind     bool
+		spec           *specs.Spec
+		expectedErrors []string
+	}{
+		{
+			description: "valid spec",
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				Devices: []specs.Device{validDevice("0"), validDevice("all")},
+			},
+		},
+		{
+			description: "all errors are reported",
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+				ContainerEdits: specs.ContainerEdits{
+					Env:    []string{"=invalid"},
+					Mounts: []*specs.Mount{{HostPath: "/lib/libcuda.so"}},
+				},
+				Devices: []specs.Device{
+					validDevice("0"),
+					validDevice("0"),
+					{Name: "1"},
+					{
+						Name: "2",
+						ContainerEdits: specs.ContainerEdits{
+							Hooks: []*specs.Hook{{HookName: "createContainer"}},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{
+				`spec: invalid environment variable "=invalid"`,
+				"spec: invalid mount, empty container path",
+				`multiple devices named "0"`,
+				`device "1": no container edits`,
+				`device "2": invalid hook "createContainer" with empty path`,
+			},
+		},
+		{
+			description: "invalid kind",
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/experimental!",
+				Devices: []specs.Device{validDevice("0")},
+			},
+			expectedErrors: []string{"invalid class"},
+		},
+		{
+			description: "invalid kind is ignored for unsafe kinds",
+			unsafeKind:  true,
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/experimental!",
+				Devices: []specs.Device{validDevice("0")},
+			},
+		},
+		{
+			description: "no devices",
+			spec: &specs.Spec{
+				Version: "0.5.0",
+				Kind:    "nvidia.com/gpu",
+			},
+			expectedErrors: []string{"no devices"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			o := &options{unsafeKind: tc.unsafeKind}
+			err := o.validateSpec(tc.spec)
+			if len(tc.expectedErrors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expected := range tc.expectedErrors {
+				require.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
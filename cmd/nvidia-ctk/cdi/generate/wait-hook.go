@@ -0,0 +1,47 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// getDeviceNodeWait returns the device nodes that a container waits for when
+// it is started if the wait hook was requested.
+func (o *options) getDeviceNodeWait() (*nvcdi.DeviceNodeWait, error) {
+	if !o.emitWaitHook {
+		return nil, nil
+	}
+	if len(o.waitHookDeviceNodes) == 0 {
+		return nil, fmt.Errorf("--emit-wait-hook requires at least one --wait-hook-device-node")
+	}
+	for _, deviceNode := range o.waitHookDeviceNodes {
+		if !strings.HasPrefix(deviceNode, "/") {
+			return nil, fmt.Errorf("invalid wait hook device node %q: an absolute path is required", deviceNode)
+		}
+	}
+	if o.waitHookTimeout <= 0 {
+		return nil, fmt.Errorf("invalid wait hook timeout %v: the timeout must be positive", o.waitHookTimeout)
+	}
+	return &nvcdi.DeviceNodeWait{
+		DeviceNodes: o.waitHookDeviceNodes,
+		Timeout:     o.waitHookTimeout,
+	}, nil
+}
@@ -0,0 +1,92 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+func TestGetDeviceNodeWait(t *testing.T) {
+	testCases := []struct {
+		description   string
+		options       options
+		expectedWait  *nvcdi.DeviceNodeWait
+		expectedError bool
+	}{
+		{
+			description: "no hook requested",
+			options: options{
+				waitHookDeviceNodes: []string{"/dev/nvidia-uvm"},
+				waitHookTimeout:     10 * time.Second,
+			},
+		},
+		{
+			description: "hook requested",
+			options: options{
+				emitWaitHook:        true,
+				waitHookDeviceNodes: []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+				waitHookTimeout:     5 * time.Second,
+			},
+			expectedWait: &nvcdi.DeviceNodeWait{
+				DeviceNodes: []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+				Timeout:     5 * time.Second,
+			},
+		},
+		{
+			description: "hook without device nodes is an error",
+			options: options{
+				emitWaitHook:    true,
+				waitHookTimeout: 5 * time.Second,
+			},
+			expectedError: true,
+		},
+		{
+			description: "relative device node is an error",
+			options: options{
+				emitWaitHook:        true,
+				waitHookDeviceNodes: []string{"dev/nvidia-uvm"},
+				waitHookTimeout:     5 * time.Second,
+			},
+			expectedError: true,
+		},
+		{
+			description: "zero timeout is an error",
+			options: options{
+				emitWaitHook:        true,
+				waitHookDeviceNodes: []string{"/dev/nvidia-uvm"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			wait, err := tc.options.getDeviceNodeWait()
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedWait, wait)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+const defaultWatchInterval = 30 * time.Second
+
+// validateWatch checks whether the other specified options are compatible with
+// running as a watcher.
+func (o *options) validateWatch() error {
+	if !o.watch {
+		return nil
+	}
+	if o.output == "" || o.isUnixSocketOutput() {
+		return fmt.Errorf("the --watch flag requires the CDI spec to be written to a file")
+	}
+	if o.watchInterval <= 0 {
+		return fmt.Errorf("invalid watch interval %v: the interval must be positive", o.watchInterval)
+	}
+	if o.updateContainerEdits {
+		return fmt.Errorf("the --watch and --update-container-edits flags are mutually exclusive")
+	}
+	if o.probe {
+		return fmt.Errorf("the --watch and --probe flags are mutually exclusive")
+	}
+	if o.fromSnapshot != "" {
+		return fmt.Errorf("the --watch and --from-snapshot flags are mutually exclusive")
+	}
+	// Since NVML calls cannot be cancelled, a generation pass that times out
+	// is abandoned while it is still running. Such a pass would race with the
+	// next pass of the watcher.
+	if o.timeout > 0 {
+		return fmt.Errorf("the --watch and --timeout flags are mutually exclusive")
+	}
+	return nil
+}
+
+// A watchState records the fingerprint of the specs written by an iteration of
+// the watcher and the files that these were written to.
+type watchState struct {
+	fingerprint string
+	outputs     []string
+}
+
+// watch regenerates the CDI specs at the configured interval until the context
+// is cancelled or the process is interrupted. The output is only rewritten if
+// the fingerprint of the generated specs changes or if an output file was
+// removed. Output files that are no longer generated are removed. Failures to
+// regenerate the specs are logged and retried at the next interval.
+func (m command) watch(ctx context.Context, opts *options) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(opts.watchInterval)
+	defer ticker.Stop()
+
+	m.logger.Infof("Watching for changes every %v", opts.watchInterval)
+	var previous *watchState
+	for {
+		state, err := m.generateAndWrite(ctx, opts, previous)
+		if state != nil {
+			previous = state
+		}
+		if err != nil {
+			m.logger.Warningf("Failed to update CDI spec: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			m.logger.Infof("Stopped watching for changes")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// getWatchState returns the state of the watcher for the specified specs.
+func (o *options) getWatchState(specs []generatedSpecs) (*watchState, error) {
+	fingerprint, err := o.fingerprint(specs)
+	if err != nil {
+		return nil, err
+	}
+	state := &watchState{
+		fingerprint: fingerprint,
+	}
+	if o.format == formatYAMLStream {
+		state.outputs = []string{o.output}
+		return state, nil
+	}
+	for _, s := range specs {
+		state.outputs = append(state.outputs, s.updateFilename(o.output))
+	}
+	return state, nil
+}
+
+// fingerprint returns a hash of the output files and the canonical form of the
+// specified specs. This is used to determine whether the output has to be
+// rewritten.
+func (o *options) fingerprint(specs []generatedSpecs) (string, error) {
+	h := sha256.New()
+	for _, s := range specs {
+		fingerprint, err := spec.Fingerprint(s.Raw())
+		if err != nil {
+			return "", fmt.Errorf("failed to fingerprint CDI spec: %w", err)
+		}
+		fmt.Fprintf(h, "%s\n%s\n", s.updateFilename(o.output), fingerprint)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isUnchanged checks whether the specified current state matches the state of
+// a previous iteration. Since an output file may have been removed since it
+// was written, the state is only unchanged if all the output files exist.
+func (s *watchState) isUnchanged(current *watchState) bool {
+	if s == nil || s.fingerprint != current.fingerprint {
+		return false
+	}
+	for _, output := range current.outputs {
+		if _, err := os.Stat(output); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// removeStaleOutputs removes the output files of the previous iteration of the
+// watcher that were not written by the current iteration. This is the case if
+// the set of output files shrinks, for example when a device is removed while
+// per-device output is used.
+func (m command) removeStaleOutputs(previous *watchState, current *watchState) {
+	if previous == nil {
+		return
+	}
+	for _, output := range previous.outputs {
+		if slices.Contains(current.outputs, output) {
+			continue
+		}
+		m.logger.Infof("Removing CDI spec %v that is no longer generated", output)
+		if err := os.Remove(output); err != nil && !errors.Is(err, os.ErrNotExist) {
+			m.logger.Warningf("Failed to remove CDI spec %v: %v", output, err)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestValidateWatch(t *testing.T) {
+	testCases := []struct {
+		description   string
+		options       options
+		expectedError string
+	}{
+		{
+			description: "watch not requested",
+			options:     options{},
+		},
+		{
+			description: "output file",
+			options: options{
+				watch:         true,
+				watchInterval: time.Second,
+				output:        "/etc/cdi/nvidia.yaml",
+			},
+		},
+		{
+			description: "stdout is rejected",
+			options: options{
+				watch:         true,
+				watchInterval: time.Second,
+			},
+			expectedError: "the --watch flag requires the CDI spec to be written to a file",
+		},
+		{
+			description: "unix socket is rejected",
+			options: options{
+				watch:         true,
+				watchInterval: time.Second,
+				output:        "unix:///run/cdi.sock",
+			},
+			expectedError: "the --watch flag requires the CDI spec to be written to a file",
+		},
+		{
+			description: "zero interval is rejected",
+			options: options{
+				watch:  true,
+				output: "/etc/cdi/nvidia.yaml",
+			},
+			expectedError: "invalid watch interval 0s: the interval must be positive",
+		},
+		{
+			description: "update container edits is rejected",
+			options: options{
+				watch:                true,
+				watchInterval:        time.Second,
+				output:               "/etc/cdi/nvidia.yaml",
+				updateContainerEdits: true,
+			},
+			expectedError: "the --watch and --update-container-edits flags are mutually exclusive",
+		},
+		{
+			description: "timeout is rejected",
+			options: options{
+				watch:         true,
+				watchInterval: time.Second,
+				output:        "/etc/cdi/nvidia.yaml",
+				timeout:       time.Minute,
+			},
+			expectedError: "the --watch and --timeout flags are mutually exclusive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.options.validateWatch()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWatchOnlyWritesChangedSpecs(t *testing.T) {
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+	driverRoot := filepath.Join(moduleRoot, "testdata", "lookup", "rootfs-1")
+
+	logger, hook := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	server := dgxa100.New()
+	server.SystemGetDriverVersionFunc = func() (string, nvml.Return) {
+		return "999.88.77", nvml.SUCCESS
+	}
+	server.DeviceGetCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	for _, d := range server.Devices {
+		(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		}
+		(d.(*mockserver.Device)).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return 0, nvml.ERROR_NOT_SUPPORTED
+		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+
+	output := filepath.Join(t.TempDir(), "nvidia.yaml")
+	opts := &options{
+		output:               output,
+		format:               "yaml",
+		mode:                 "nvml",
+		vendor:               "example.com",
+		class:                "device",
+		deviceIDs:            []string{"all"},
+		deviceNameStrategies: []string{"index"},
+		driverRoot:           driverRoot,
+		nvidiaCDIHookPath:    "/usr/bin/nvidia-cdi-hook",
+		nvmllib:              server,
+		watch:                true,
+		watchInterval:        10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	require.NoError(t, c.watch(ctx, opts))
+
+	require.FileExists(t, output)
+	var writes int
+	for _, entry := range hook.AllEntries() {
+		if strings.HasPrefix(entry.Message, "Generated CDI spec with version") {
+			writes++
+		}
+	}
+	require.Equal(t, 1, writes)
+}
+
+func TestWatchStateIsUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "nvidia.yaml")
+	require.NoError(t, os.WriteFile(existing, nil, 0644))
+	missing := filepath.Join(dir, "nvidia_1.yaml")
+
+	testCases := []struct {
+		description string
+		previous    *watchState
+		current     *watchState
+		expected    bool
+	}{
+		{
+			description: "no previous state",
+			current:     &watchState{fingerprint: "a", outputs: []string{existing}},
+		},
+		{
+			description: "fingerprint changed",
+			previous:    &watchState{fingerprint: "a", outputs: []string{existing}},
+			current:     &watchState{fingerprint: "b", outputs: []string{existing}},
+		},
+		{
+			description: "output file removed",
+			previous:    &watchState{fingerprint: "a", outputs: []string{existing, missing}},
+			current:     &watchState{fingerprint: "a", outputs: []string{existing, missing}},
+		},
+		{
+			description: "unchanged",
+			previous:    &watchState{fingerprint: "a", outputs: []string{existing}},
+			current:     &watchState{fingerprint: "a", outputs: []string{existing}},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.previous.isUnchanged(tc.current))
+		})
+	}
+}
+
+func TestRemoveStaleOutputs(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	c := command{
+		logger: logger,
+	}
+
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "nvidia_0.yaml")
+	stale := filepath.Join(dir, "nvidia_1.yaml")
+	for _, f := range []string{kept, stale} {
+		require.NoError(t, os.WriteFile(f, nil, 0644))
+	}
+
+	previous := &watchState{outputs: []string{kept, stale}}
+	current := &watchState{outputs: []string{kept}}
+	c.removeStaleOutputs(previous, current)
+
+	require.FileExists(t, kept)
+	require.NoFileExists(t, stale)
+}
@@ -0,0 +1,238 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	device            string
+	format            string
+	mode              string
+	driverRoot        string
+	devRoot           string
+	nvidiaCDIHookPath string
+	ldconfigPath      string
+}
+
+// NewCommand constructs a cdi inspect command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "inspect",
+		Usage: "Show the device nodes, mounts, and hooks that are discovered for a single device",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "device",
+				Usage:       "Specify the device to inspect. This can be a device index (e.g. 3 or gpu3), a MIG device index (e.g. 3:0), or a device UUID.",
+				Required:    true,
+				Destination: &opts.device,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "The output format [" + formatText + " | " + formatJSON + "]",
+				Value:       formatText,
+				Destination: &opts.format,
+			},
+			&cli.StringFlag{
+				Name:        "mode",
+				Aliases:     []string{"discovery-mode"},
+				Usage:       "The mode to use when discovering the entities for the device. One of [" + string(nvcdi.ModeAuto) + " | " + string(nvcdi.ModeNvml) + " | " + string(nvcdi.ModeWsl) + "]",
+				Value:       string(nvcdi.ModeAuto),
+				Destination: &opts.mode,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_INSPECT_MODE"),
+			},
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities for the device",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+				Destination: &opts.devRoot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DEV_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-cdi-hook-path",
+				Usage:       "Specify the path to use for the nvidia-cdi-hook in the discovered hooks. If not specified, the PATH will be searched for `nvidia-cdi-hook`.",
+				Destination: &opts.nvidiaCDIHookPath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_HOOK_PATH"),
+			},
+			&cli.StringFlag{
+				Name:        "ldconfig-path",
+				Usage:       "Specify the path to use for ldconfig in the discovered hooks",
+				Destination: &opts.ldconfigPath,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	opts.format = strings.ToLower(opts.format)
+	switch opts.format {
+	case formatText, formatJSON:
+	default:
+		return fmt.Errorf("invalid output format: %v", opts.format)
+	}
+	if !nvcdi.IsValidMode(opts.mode) {
+		return fmt.Errorf("invalid discovery mode: %v", opts.mode)
+	}
+	if normalizeDeviceID(opts.device) == "all" {
+		return fmt.Errorf("a single device must be specified")
+	}
+	opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	cdilib, err := nvcdi.New(
+		nvcdi.WithLogger(m.logger),
+		nvcdi.WithMode(opts.mode),
+		nvcdi.WithDriverRoot(opts.driverRoot),
+		nvcdi.WithDevRoot(opts.devRoot),
+		nvcdi.WithNVIDIACDIHookPath(opts.nvidiaCDIHookPath),
+		nvcdi.WithLdconfigPath(opts.ldconfigPath),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CDI library: %w", err)
+	}
+
+	// Only the entities for the requested device are discovered. The common
+	// edits (e.g. driver libraries) are not included.
+	deviceSpecs, err := cdilib.GetDeviceSpecsByID(normalizeDeviceID(opts.device))
+	if err != nil {
+		return fmt.Errorf("failed to discover device %v: %w", opts.device, err)
+	}
+	if len(deviceSpecs) == 0 {
+		return fmt.Errorf("no device specifications generated for device %v", opts.device)
+	}
+
+	return writeDevices(os.Stdout, opts.format, deviceSpecs)
+}
+
+// normalizeDeviceID converts the specified device ID to an ID that is
+// supported by the CDI library. IDs of the form gpu3 are converted to device
+// indices.
+func normalizeDeviceID(id string) string {
+	id = strings.TrimSpace(id)
+	if index, ok := strings.CutPrefix(strings.ToLower(id), "gpu"); ok && index != "" && !strings.HasPrefix(index, "-") {
+		return index
+	}
+	return id
+}
+
+// writeDevices writes the specified device specs in the requested format.
+func writeDevices(w io.Writer, format string, devices []specs.Device) error {
+	if format == formatJSON {
+		contents, err := json.MarshalIndent(devices, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal device specs: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", contents)
+		return err
+	}
+
+	var b strings.Builder
+	for i, device := range devices {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeDeviceText(&b, device)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeDeviceText writes a human-readable description of the specified
+// device spec.
+func writeDeviceText(b *strings.Builder, device specs.Device) {
+	edits := device.ContainerEdits
+
+	fmt.Fprintf(b, "Device: %s\n", device.Name)
+
+	b.WriteString("Device nodes:\n")
+	for _, dn := range edits.DeviceNodes {
+		hostPath := dn.HostPath
+		if hostPath == "" {
+			hostPath = dn.Path
+		}
+		fmt.Fprintf(b, "  %s -> %s\n", hostPath, dn.Path)
+	}
+
+	b.WriteString("Mounts:\n")
+	for _, mount := range edits.Mounts {
+		fmt.Fprintf(b, "  %s -> %s", mount.HostPath, mount.ContainerPath)
+		if len(mount.Options) > 0 {
+			fmt.Fprintf(b, " (%s)", strings.Join(mount.Options, ","))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Hooks:\n")
+	for _, hook := range edits.Hooks {
+		fmt.Fprintf(b, "  %s: %s\n", hook.HookName, strings.Join(hook.Args, " "))
+	}
+
+	if len(edits.Env) > 0 {
+		b.WriteString("Environment:\n")
+		for _, env := range edits.Env {
+			fmt.Fprintf(b, "  %s\n", env)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestNormalizeDeviceID(t *testing.T) {
+	testCases := map[string]string{
+		"3":            "3",
+		"gpu3":         "3",
+		"GPU3":         "3",
+		"3:0":          "3:0",
+		"GPU-0123abcd": "GPU-0123abcd",
+		"all":          "all",
+	}
+
+	for id, expected := range testCases {
+		t.Run(id, func(t *testing.T) {
+			require.Equal(t, expected, normalizeDeviceID(id))
+		})
+	}
+}
+
+func TestWriteDevices(t *testing.T) {
+	devices := []specs.Device{
+		{
+			Name: "3",
+			ContainerEdits: specs.ContainerEdits{
+				DeviceNodes: []*specs.DeviceNode{
+					{Path: "/dev/nvidia3"},
+					{Path: "/dev/dri/card4", HostPath: "/host/dev/dri/card4"},
+				},
+				Mounts: []*specs.Mount{
+					{HostPath: "/usr/lib/libfoo.so", ContainerPath: "/usr/lib/libfoo.so", Options: []string{"ro", "bind"}},
+				},
+				Hooks: []*specs.Hook{
+					{
+						HookName: "createContainer",
+						Path:     "/usr/bin/nvidia-cdi-hook",
+						Args:     []string{"nvidia-cdi-hook", "create-symlinks", "--link", "../card4::/dev/dri/by-path/pci-0000:07:00.0-card"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeDevices(&buf, formatText, devices))
+		require.Equal(t, `Device: 3
+Device nodes:
+  /dev/nvidia3 -> /dev/nvidia3
+  /host/dev/dri/card4 -> /dev/dri/card4
+Mounts:
+  /usr/lib/libfoo.so -> /usr/lib/libfoo.so (ro,bind)
+Hooks:
+  createContainer: nvidia-cdi-hook create-symlinks --link ../card4::/dev/dri/by-path/pci-0000:07:00.0-card
+`, buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeDevices(&buf, formatJSON, devices))
+
+		var decoded []specs.Device
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, devices, decoded)
+	})
+}
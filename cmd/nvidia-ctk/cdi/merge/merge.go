@@ -0,0 +1,253 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+const (
+	allDeviceName = "all"
+
+	onConflictError  = "error"
+	onConflictRename = "rename"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	output     string
+	onConflict string
+	specs      []string
+}
+
+// NewCommand constructs a cdi merge command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:      "merge",
+		Usage:     "Merge multiple CDI specifications of the same kind into a single specification",
+		ArgsUsage: "SPEC SPEC [SPEC...]",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			opts.specs = cmd.Args().Slice()
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Specify the file to output the merged CDI specification to. If this is '' the specification is output to STDOUT",
+				Destination: &opts.output,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_MERGE_OUTPUT"),
+			},
+			&cli.StringFlag{
+				Name: "on-conflict",
+				Usage: "Specify how devices with the same name in different specifications are handled. " +
+					"One of [error | rename]. If 'rename' is specified, a numeric suffix is added to the names of later devices.",
+				Value:       onConflictError,
+				Destination: &opts.onConflict,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_MERGE_ON_CONFLICT"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if len(opts.specs) < 2 {
+		return errors.New("at least two CDI specifications must be specified")
+	}
+	switch opts.onConflict {
+	case onConflictError, onConflictRename:
+	default:
+		return fmt.Errorf("invalid --on-conflict value %q", opts.onConflict)
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	var inputs []*specs.Spec
+	for _, filename := range opts.specs {
+		contents, err := os.ReadFile(filename)
+		if err != nil {
+			return fmt.Errorf("failed to read %v: %w", filename, err)
+		}
+		raw, err := cdi.ParseSpec(contents)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v: %w", filename, err)
+		}
+		inputs = append(inputs, raw)
+	}
+
+	merged, hasAllDevice, err := m.mergeSpecs(opts, inputs)
+	if err != nil {
+		return err
+	}
+	if err := validate(merged); err != nil {
+		return fmt.Errorf("invalid merged CDI specification: %w", err)
+	}
+
+	specOptions := []spec.Option{
+		spec.WithRawSpec(merged),
+	}
+	// As is the case for generated specs, the 'all' device references all
+	// other devices and is regenerated from the merged devices.
+	if hasAllDevice {
+		specOptions = append(specOptions,
+			spec.WithMergedDeviceOptions(
+				transform.WithName(allDeviceName),
+				transform.WithLogger(m.logger),
+			),
+		)
+	}
+	s, err := spec.New(specOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to create merged CDI specification: %w", err)
+	}
+
+	if opts.output == "" {
+		if _, err := s.WriteTo(os.Stdout); err != nil {
+			return fmt.Errorf("failed to write CDI spec to STDOUT: %w", err)
+		}
+		return nil
+	}
+	if err := s.Save(opts.output); err != nil {
+		return fmt.Errorf("failed to save merged CDI specification: %w", err)
+	}
+	m.logger.Infof("Merged %d CDI specifications into %v", len(inputs), opts.output)
+	return nil
+}
+
+// mergeSpecs combines the specified specs into a single spec. The devices are
+// concatenated and the spec-level container edits are combined and
+// deduplicated. The 'all' devices of the input specs are dropped and the
+// returned flag indicates whether any of the inputs included one.
+// The version of the merged spec is left empty so that the minimum required
+// version is detected when the spec is written.
+func (m command) mergeSpecs(opts *options, inputs []*specs.Spec) (*specs.Spec, bool, error) {
+	merged := &specs.Spec{
+		Kind: inputs[0].Kind,
+	}
+	mergedEdits := edits.EmptyFactory.New()
+	names := make(map[string]string)
+	var hasAllDevice bool
+	for i, raw := range inputs {
+		filename := opts.specs[i]
+		if raw.Kind != merged.Kind {
+			return nil, false, fmt.Errorf("the kind %q of %v does not match the kind %q of %v", raw.Kind, filename, merged.Kind, opts.specs[0])
+		}
+
+		for _, device := range raw.Devices {
+			if device.Name == allDeviceName {
+				hasAllDevice = true
+				continue
+			}
+			if other, ok := names[device.Name]; ok {
+				if opts.onConflict != onConflictRename {
+					return nil, false, fmt.Errorf("device %q in %v conflicts with a device in %v", device.Name, filename, other)
+				}
+				name := uniqueName(device.Name, names)
+				m.logger.Infof("Renaming device %q in %v to %q", device.Name, filename, name)
+				device.Name = name
+			}
+			names[device.Name] = filename
+			merged.Devices = append(merged.Devices, device)
+		}
+
+		mergedEdits.Append(&cdi.ContainerEdits{ContainerEdits: &raw.ContainerEdits})
+
+		for key, value := range raw.Annotations {
+			if existing, ok := merged.Annotations[key]; ok && existing != value {
+				m.logger.Warningf("Ignoring conflicting value %q for annotation %q in %v", value, key, filename)
+				continue
+			}
+			if merged.Annotations == nil {
+				merged.Annotations = make(map[string]string)
+			}
+			merged.Annotations[key] = value
+		}
+	}
+	merged.ContainerEdits = *mergedEdits.ContainerEdits
+
+	dedupe, _ := transform.NewDedupe()
+	if err := dedupe.Transform(merged); err != nil {
+		return nil, false, fmt.Errorf("failed to deduplicate container edits: %w", err)
+	}
+
+	return merged, hasAllDevice, nil
+}
+
+// uniqueName returns a name for a device that does not conflict with the
+// specified names by adding a numeric suffix.
+func uniqueName(name string, names map[string]string) string {
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if _, ok := names[candidate]; !ok && candidate != allDeviceName {
+			return candidate
+		}
+	}
+}
+
+// validate checks the device names and container edits of the merged spec.
+// All errors are returned.
+func validate(raw *specs.Spec) error {
+	var errs error
+	if err := (&cdi.ContainerEdits{ContainerEdits: &raw.ContainerEdits}).Validate(); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if len(raw.Devices) == 0 {
+		errs = errors.Join(errs, errors.New("no devices defined"))
+	}
+	for i := range raw.Devices {
+		device := &raw.Devices[i]
+		if err := parser.ValidateDeviceName(device.Name); err != nil {
+			errs = errors.Join(errs, err)
+		}
+		edits := &cdi.ContainerEdits{ContainerEdits: &device.ContainerEdits}
+		if err := edits.Validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("device %q: %w", device.Name, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,182 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package merge
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	gpuSpec := `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+  - name: all
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+containerEdits:
+  deviceNodes:
+    - path: /dev/nvidiactl
+  env:
+    - NVIDIA_VISIBLE_DEVICES=void
+`
+
+	testCases := []struct {
+		description   string
+		inputs        []string
+		onConflict    string
+		expected      string
+		expectedError string
+	}{
+		{
+			description: "devices are concatenated and edits are deduplicated",
+			inputs: []string{
+				gpuSpec,
+				`---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  - name: gpu1
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia1
+containerEdits:
+  deviceNodes:
+    - path: /dev/nvidiactl
+    - path: /dev/nvidia-uvm
+`,
+			},
+			onConflict: onConflictError,
+			expected: `---
+cdiVersion: 0.3.0
+kind: nvidia.com/gpu
+devices:
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+            - path: /dev/nvidia1
+    - name: gpu0
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+    - name: gpu1
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia1
+containerEdits:
+    env:
+        - NVIDIA_VISIBLE_DEVICES=void
+    deviceNodes:
+        - path: /dev/nvidia-uvm
+        - path: /dev/nvidiactl
+`,
+		},
+		{
+			description:   "conflicting device names are rejected",
+			inputs:        []string{gpuSpec, gpuSpec},
+			onConflict:    onConflictError,
+			expectedError: `device "gpu0" in input1.yaml conflicts with a device in input0.yaml`,
+		},
+		{
+			description: "conflicting device names are renamed",
+			inputs:      []string{gpuSpec, gpuSpec},
+			onConflict:  onConflictRename,
+			expected: `---
+cdiVersion: 0.3.0
+kind: nvidia.com/gpu
+devices:
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+    - name: gpu0
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+    - name: gpu0-1
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+containerEdits:
+    env:
+        - NVIDIA_VISIBLE_DEVICES=void
+    deviceNodes:
+        - path: /dev/nvidiactl
+`,
+		},
+		{
+			description: "different kinds are rejected",
+			inputs: []string{
+				gpuSpec,
+				`---
+cdiVersion: 0.5.0
+kind: nvidia.com/imex-channel
+devices:
+  - name: channel0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia-caps-imex-channels/channel0
+`,
+			},
+			onConflict:    onConflictError,
+			expectedError: `the kind "nvidia.com/imex-channel" of input1.yaml does not match the kind "nvidia.com/gpu" of input0.yaml`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+
+			opts := &options{
+				output:     filepath.Join(dir, "merged.yaml"),
+				onConflict: tc.onConflict,
+			}
+			for i, input := range tc.inputs {
+				filename := fmt.Sprintf("input%d.yaml", i)
+				require.NoError(t, os.WriteFile(filename, []byte(input), 0600))
+				opts.specs = append(opts.specs, filename)
+			}
+
+			err := command{logger: logger}.run(opts)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				require.NoFileExists(t, opts.output)
+				return
+			}
+			require.NoError(t, err)
+
+			contents, err := os.ReadFile(opts.output)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(contents))
+		})
+	}
+}
@@ -0,0 +1,410 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package prune
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/fsutil"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+const (
+	allDeviceName = "all"
+)
+
+// generatedDeviceNamePatterns match the device names produced by the
+// supported device naming strategies. Devices with other names, such as names
+// with a custom prefix or devices renamed when merging specs, cannot be
+// resolved to a device on the system and are never pruned.
+var generatedDeviceNamePatterns = []*regexp.Regexp{
+	// index
+	regexp.MustCompile(`^[0-9]+(:[0-9]+)?$`),
+	// type-index and mig-profile
+	regexp.MustCompile(`^gpu[0-9]+$`),
+	regexp.MustCompile(`^mig[0-9]+:[0-9]+$`),
+	regexp.MustCompile(`^gpu[0-9]+-mig-[^-]+-[0-9]+$`),
+	// uuid
+	regexp.MustCompile(`^(GPU|MIG)-[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+}
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	specDir string
+	vendor  string
+	class   string
+	dryRun  bool
+
+	removeEmptySpecs bool
+
+	// the following are used for dependency injection.
+	nvmllib nvml.Interface
+}
+
+// NewCommand constructs a cdi prune command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "prune",
+		Usage: "Remove devices that are no longer present on the system from generated CDI specifications",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "spec-dir",
+				Usage:       "specify the directory to scan for generated CDI specifications",
+				Value:       cdi.DefaultStaticDir,
+				Destination: &opts.specDir,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_PRUNE_SPEC_DIR"),
+			},
+			&cli.StringFlag{
+				Name:        "vendor",
+				Aliases:     []string{"cdi-vendor"},
+				Usage:       "the vendor of the CDI specifications to prune",
+				Value:       "nvidia.com",
+				Destination: &opts.vendor,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_PRUNE_VENDOR"),
+			},
+			&cli.StringFlag{
+				Name:        "class",
+				Aliases:     []string{"cdi-class"},
+				Usage:       "the class of the CDI specifications to prune",
+				Value:       "gpu",
+				Destination: &opts.class,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_PRUNE_CLASS"),
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "only log the changes that would be made without modifying any files",
+				Destination: &opts.dryRun,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_PRUNE_DRY_RUN"),
+			},
+			&cli.BoolFlag{
+				Name:        "remove-empty-specs",
+				Usage:       "remove specification files where none of the devices are present. By default such files are left unmodified",
+				Destination: &opts.removeEmptySpecs,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_PRUNE_REMOVE_EMPTY_SPECS"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.specDir == "" {
+		return errors.New("a CDI specification directory must be specified")
+	}
+	if err := parser.ValidateVendorName(opts.vendor); err != nil {
+		return fmt.Errorf("invalid CDI vendor name: %v", err)
+	}
+	if err := parser.ValidateClassName(opts.class); err != nil {
+		return fmt.Errorf("invalid CDI class name: %v", err)
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	present, err := m.getPresentDevices(opts)
+	if err != nil {
+		return fmt.Errorf("failed to determine present devices: %w", err)
+	}
+
+	var filenames []string
+	for _, ext := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, err := filepath.Glob(filepath.Join(opts.specDir, ext))
+		if err != nil {
+			return fmt.Errorf("failed to find CDI specifications: %w", err)
+		}
+		filenames = append(filenames, matches...)
+	}
+	slices.Sort(filenames)
+
+	var errs error
+	for _, filename := range filenames {
+		if err := m.pruneSpec(opts, filename, present); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to prune %v: %w", filename, err))
+		}
+	}
+	return errs
+}
+
+// pruneSpec removes the devices that are not present from the specified spec
+// file. If no devices remain, the file is only removed if this was requested.
+// Specs with a kind other than the requested kind are left unmodified.
+func (m command) pruneSpec(opts *options, filename string, present *presentDevices) error {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if raw.Kind != opts.vendor+"/"+opts.class {
+		m.logger.Debugf("Skipping %v with kind %q", filename, raw.Kind)
+		return nil
+	}
+
+	var hasAllDevice bool
+	var remaining []string
+	var removed []string
+	devices := raw.Devices[:0]
+	for _, d := range raw.Devices {
+		switch {
+		case d.Name == allDeviceName:
+			hasAllDevice = true
+			continue
+		case present.isAbsent(d):
+			removed = append(removed, d.Name)
+		default:
+			remaining = append(remaining, d.Name)
+			devices = append(devices, d)
+		}
+	}
+
+	if len(removed) == 0 {
+		m.logger.Debugf("No stale devices in %v", filename)
+		return nil
+	}
+
+	if len(remaining) == 0 {
+		if !opts.removeEmptySpecs {
+			m.logger.Warningf("None of the devices %v in %v are present; specify --remove-empty-specs to remove it", removed, filename)
+			return nil
+		}
+		m.logger.Infof("Removing %v; none of the devices %v are present", filename, removed)
+		if opts.dryRun {
+			return nil
+		}
+		return os.Remove(filename)
+	}
+
+	m.logger.Infof("Removing devices %v from %v", removed, filename)
+	if opts.dryRun {
+		return nil
+	}
+
+	raw.Devices = devices
+	specOptions := []spec.Option{
+		spec.WithRawSpec(raw),
+		spec.WithFormat(formatFromFilename(filename)),
+		spec.WithPermissions(0644),
+	}
+	// The 'all' device references all other devices and is regenerated from
+	// the devices that remain.
+	if hasAllDevice {
+		specOptions = append(specOptions,
+			spec.WithMergedDeviceOptions(
+				transform.WithName(allDeviceName),
+				transform.WithSkipIfExists(true),
+			),
+		)
+	}
+	s, err := spec.New(specOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to create spec: %w", err)
+	}
+	// The spec is only saved with a .yaml or .json extension. Specs with a
+	// .yml extension are rendered as YAML and written in place instead.
+	if filepath.Ext(filename) == ".yml" {
+		var buf bytes.Buffer
+		if _, err := s.WriteTo(&buf); err != nil {
+			return fmt.Errorf("failed to render spec: %w", err)
+		}
+		return fsutil.WriteFileAtomic(filename, "", buf.Bytes(), 0644)
+	}
+	return s.Save(filename)
+}
+
+// presentDevices records the devices that are present on the system.
+type presentDevices struct {
+	// names includes the names for all supported naming strategies so that
+	// specs generated with any strategy can be matched.
+	names        map[string]bool
+	uuids        map[string]bool
+	pciAddresses map[string]bool
+}
+
+// isAbsent checks whether the specified device from a CDI spec refers to a
+// device that is no longer present on the system. Devices are matched on the
+// UUID or PCI address annotations where these are available and on their name
+// otherwise. A device whose name was not generated by one of the supported
+// naming strategies is never considered absent.
+func (p *presentDevices) isAbsent(d specs.Device) bool {
+	if uuid := d.Annotations[nvcdi.UUIDAnnotation]; uuid != "" {
+		return !p.uuids[uuid]
+	}
+	if address := d.Annotations[nvcdi.PCIAddressAnnotation]; address != "" {
+		return !p.pciAddresses[address]
+	}
+	if p.names[d.Name] {
+		return false
+	}
+	for _, pattern := range generatedDeviceNamePatterns {
+		if pattern.MatchString(d.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPresentDevices returns the devices that are present on the system.
+func (m command) getPresentDevices(opts *options) (*presentDevices, error) {
+	var deviceNamers nvcdi.DeviceNamers
+	for _, strategy := range []string{
+		nvcdi.DeviceNameStrategyIndex,
+		nvcdi.DeviceNameStrategyTypeIndex,
+		nvcdi.DeviceNameStrategyUUID,
+		nvcdi.DeviceNameStrategyMigProfile,
+	} {
+		deviceNamer, err := nvcdi.NewDeviceNamer(strategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create device namer: %w", err)
+		}
+		deviceNamers = append(deviceNamers, deviceNamer)
+	}
+
+	nvmllib := opts.nvmllib
+	if nvmllib == nil {
+		nvmllib = nvml.New()
+	}
+	if r := nvmllib.Init(); r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %w", r)
+	}
+	defer func() {
+		if r := nvmllib.Shutdown(); r != nvml.SUCCESS {
+			m.logger.Warningf("failed to shutdown NVML: %v", r)
+		}
+	}()
+
+	devicelib := device.New(nvmllib)
+
+	present := &presentDevices{
+		names:        make(map[string]bool),
+		uuids:        make(map[string]bool),
+		pciAddresses: make(map[string]bool),
+	}
+	err := devicelib.VisitDevices(func(i int, d device.Device) error {
+		names, err := deviceNamers.GetDeviceNames(i, uuider{d})
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			present.names[name] = true
+		}
+		if uuid, ret := d.GetUUID(); ret == nvml.SUCCESS {
+			present.uuids[uuid] = true
+		}
+		if address, err := d.GetPCIBusID(); err == nil && address != "" {
+			present.pciAddresses[address] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GPU device names: %w", err)
+	}
+
+	err = devicelib.VisitMigDevices(func(i int, d device.Device, j int, mig device.MigDevice) error {
+		names, err := deviceNamers.GetMigDeviceNames(i, uuider{d}, j, migUUIDer{uuider{mig}, mig})
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			present.names[name] = true
+		}
+		if uuid, ret := mig.GetUUID(); ret == nvml.SUCCESS {
+			present.uuids[uuid] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MIG device names: %w", err)
+	}
+
+	return present, nil
+}
+
+func formatFromFilename(filename string) string {
+	if filepath.Ext(filename) == ".json" {
+		return spec.FormatJSON
+	}
+	return spec.FormatYAML
+}
+
+// uuider adapts an NVML device to the nvcdi.UUIDer interface.
+type uuider struct {
+	device interface {
+		GetUUID() (string, nvml.Return)
+	}
+}
+
+func (u uuider) GetUUID() (string, error) {
+	uuid, ret := u.device.GetUUID()
+	if ret != nvml.SUCCESS {
+		return "", ret
+	}
+	return uuid, nil
+}
+
+// migUUIDer adapts an NVML MIG device to the nvcdi.UUIDer interface and also
+// exposes the MIG profile for naming strategies that require it.
+type migUUIDer struct {
+	uuider
+	mig device.MigDevice
+}
+
+func (u migUUIDer) GetProfile() (string, error) {
+	profile, err := u.mig.GetProfile()
+	if err != nil {
+		return "", err
+	}
+	return profile.String(), nil
+}
@@ -0,0 +1,246 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package prune
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	const staleSpec = `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: "0"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+- name: "9"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia9
+- name: all
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+    - path: /dev/nvidia9
+containerEdits:
+  deviceNodes:
+  - path: /dev/nvidiactl
+`
+	const prunedSpec = `---
+cdiVersion: 0.5.0
+containerEdits:
+  deviceNodes:
+  - path: /dev/nvidiactl
+devices:
+- containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+  name: "0"
+- containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+  name: all
+kind: nvidia.com/gpu
+`
+	const rewrittenSpec = `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+    - name: "0"
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+    - name: all
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+containerEdits:
+    deviceNodes:
+        - path: /dev/nvidiactl
+`
+	const absentSpec = `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+- name: gpu9
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia9
+`
+	// Devices with custom names are matched on their annotations and are
+	// otherwise left unmodified.
+	const customSpec = `---
+cdiVersion: 0.6.0
+kind: nvidia.com/gpu
+devices:
+- name: prefix-9
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia9
+- name: 0-1
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+- name: present
+  annotations:
+    nvidia.com/uuid: {{ .uuid }}
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia0
+- name: absent
+  annotations:
+    nvidia.com/uuid: GPU-00000000-0000-0000-0000-000000000000
+  containerEdits:
+    deviceNodes:
+    - path: /dev/nvidia9
+`
+	const prunedCustomSpec = `---
+cdiVersion: 0.6.0
+kind: nvidia.com/gpu
+devices:
+    - name: prefix-9
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia9
+    - name: 0-1
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+    - name: present
+      annotations:
+        nvidia.com/uuid: {{ .uuid }}
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+`
+	const otherKindSpec = `---
+cdiVersion: 0.5.0
+kind: example.com/device
+devices:
+- name: "9"
+  containerEdits:
+    deviceNodes:
+    - path: /dev/example9
+`
+
+	testCases := []struct {
+		description      string
+		dryRun           bool
+		removeEmptySpecs bool
+		expectedContents map[string]string
+	}{
+		{
+			description: "stale devices are pruned",
+			expectedContents: map[string]string{
+				"stale.yaml":    rewrittenSpec,
+				"stale.yml":     rewrittenSpec,
+				"other.yaml":    otherKindSpec,
+				"uptodate.yaml": prunedSpec,
+				"absent.yaml":   absentSpec,
+				"custom.yaml":   prunedCustomSpec,
+			},
+		},
+		{
+			description:      "empty specs are removed if requested",
+			removeEmptySpecs: true,
+			expectedContents: map[string]string{
+				"stale.yaml":    rewrittenSpec,
+				"stale.yml":     rewrittenSpec,
+				"other.yaml":    otherKindSpec,
+				"uptodate.yaml": prunedSpec,
+				"absent.yaml":   "",
+				"custom.yaml":   prunedCustomSpec,
+			},
+		},
+		{
+			description:      "dry-run does not modify specs",
+			dryRun:           true,
+			removeEmptySpecs: true,
+			expectedContents: map[string]string{
+				"stale.yaml":    staleSpec,
+				"stale.yml":     staleSpec,
+				"other.yaml":    otherKindSpec,
+				"uptodate.yaml": prunedSpec,
+				"absent.yaml":   absentSpec,
+				"custom.yaml":   customSpec,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			for _, d := range server.Devices {
+				// TODO: This is not implemented in the mock.
+				(d.(*mockserver.Device)).GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				}
+			}
+			withUUID := strings.NewReplacer("{{ .uuid }}", server.Devices[0].(*mockserver.Device).UUID).Replace
+
+			specDir := t.TempDir()
+			for filename, contents := range map[string]string{
+				"stale.yaml":    staleSpec,
+				"stale.yml":     staleSpec,
+				"other.yaml":    otherKindSpec,
+				"uptodate.yaml": prunedSpec,
+				"absent.yaml":   absentSpec,
+				"custom.yaml":   withUUID(customSpec),
+			} {
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, filename), []byte(contents), 0644))
+			}
+
+			c := command{
+				logger: logger,
+			}
+			opts := options{
+				specDir: specDir,
+				vendor:  "nvidia.com",
+				class:   "gpu",
+				dryRun:  tc.dryRun,
+				nvmllib: server,
+
+				removeEmptySpecs: tc.removeEmptySpecs,
+			}
+			require.NoError(t, c.validateFlags(&opts))
+			require.NoError(t, c.run(&opts))
+
+			for filename, expected := range tc.expectedContents {
+				contents, err := os.ReadFile(filepath.Join(specDir, filename))
+				if expected == "" {
+					require.ErrorIs(t, err, os.ErrNotExist)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, withUUID(expected), string(contents))
+			}
+		})
+	}
+}
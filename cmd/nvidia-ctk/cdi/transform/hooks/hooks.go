@@ -0,0 +1,156 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/specio"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	specio.Options
+	stripHooks []string
+}
+
+// NewCommand constructs a command to remove hooks from a CDI specification.
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:                   "hooks",
+		Usage:                  "Remove hooks from a CDI specification",
+		UseShortOptionHandling: true,
+		EnableShellCompletion:  true,
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "input",
+				Usage:       "Specify the file to read the CDI specification from. If this is '-' the specification is read from STDIN",
+				Value:       "-",
+				Destination: &opts.Input,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
+				Destination: &opts.Output,
+			},
+			&cli.StringSliceFlag{
+				Name: "strip-hook",
+				Usage: "Specify the name of a hook to remove from the spec-level and device-level container edits. " +
+					"For the nvidia-cdi-hook (or nvidia-ctk hook) this is the name of the hook subcommand (e.g. update-ldcache). " +
+					"For other hooks this is the base name of the hook path. This can be specified multiple times.",
+				Destination: &opts.stripHooks,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if len(opts.stripHooks) == 0 {
+		return fmt.Errorf("at least one --strip-hook must be specified")
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	spec, err := opts.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load CDI specification: %w", err)
+	}
+
+	before := countHooks(spec.Raw())
+	if err := transform.NewHookRemover(opts.stripHooks...).Transform(spec.Raw()); err != nil {
+		return fmt.Errorf("failed to transform CDI specification: %w", err)
+	}
+	m.logger.Infof("Removed %d hooks from CDI specification", before-countHooks(spec.Raw()))
+
+	if err := validate(spec.Raw()); err != nil {
+		return fmt.Errorf("invalid CDI specification after removing hooks: %w", err)
+	}
+
+	return opts.Save(spec)
+}
+
+// countHooks returns the number of hooks in the spec-level and device-level
+// container edits of the specified spec.
+func countHooks(raw *specs.Spec) int {
+	count := len(raw.ContainerEdits.Hooks)
+	for _, device := range raw.Devices {
+		count += len(device.ContainerEdits.Hooks)
+	}
+	return count
+}
+
+// validate checks that the container edits of the specified spec are still
+// valid after the hooks have been removed. Since devices are required to
+// define container edits, a device for which only hooks were defined is
+// invalid.
+func validate(raw *specs.Spec) error {
+	var errs error
+	if err := (&cdi.ContainerEdits{ContainerEdits: &raw.ContainerEdits}).Validate(); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	for i := range raw.Devices {
+		device := &raw.Devices[i]
+		edits := &cdi.ContainerEdits{ContainerEdits: &device.ContainerEdits}
+		if err := edits.Validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("device %q: %w", device.Name, err))
+		}
+		if isEmpty(&device.ContainerEdits) {
+			errs = errors.Join(errs, fmt.Errorf("device %q has no container edits", device.Name))
+		}
+	}
+	return errs
+}
+
+func isEmpty(edits *specs.ContainerEdits) bool {
+	return len(edits.Env) == 0 &&
+		len(edits.DeviceNodes) == 0 &&
+		len(edits.Hooks) == 0 &&
+		len(edits.Mounts) == 0 &&
+		len(edits.AdditionalGIDs) == 0 &&
+		edits.IntelRdt == nil &&
+		len(edits.NetDevices) == 0
+}
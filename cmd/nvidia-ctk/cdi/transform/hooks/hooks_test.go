@@ -0,0 +1,128 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/specio"
+)
+
+func TestRun(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description   string
+		input         string
+		stripHooks    []string
+		expected      string
+		expectedError string
+	}{
+		{
+			description: "matching hooks are removed",
+			input: `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+      hooks:
+        - hookName: createContainer
+          path: /usr/bin/nvidia-cdi-hook
+          args: ["nvidia-cdi-hook", "enable-cuda-compat", "--host-driver-version=999.88.77"]
+containerEdits:
+  hooks:
+    - hookName: createContainer
+      path: /usr/bin/nvidia-cdi-hook
+      args: ["nvidia-cdi-hook", "update-ldcache"]
+    - hookName: createContainer
+      path: /usr/bin/nvidia-cdi-hook
+      args: ["nvidia-cdi-hook", "create-symlinks"]
+`,
+			stripHooks: []string{"enable-cuda-compat", "update-ldcache"},
+			expected: `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+containerEdits:
+  hooks:
+    - hookName: createContainer
+      path: /usr/bin/nvidia-cdi-hook
+      args:
+        - nvidia-cdi-hook
+        - create-symlinks
+`,
+		},
+		{
+			description: "device without remaining edits is invalid",
+			input: `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  - name: gpu0
+    containerEdits:
+      hooks:
+        - hookName: createContainer
+          path: /opt/vendor/bin/custom-hook
+containerEdits:
+  deviceNodes:
+    - path: /dev/nvidiactl
+`,
+			stripHooks:    []string{"custom-hook"},
+			expectedError: `invalid CDI specification after removing hooks: device "gpu0" has no container edits`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, "input.yaml")
+			output := filepath.Join(dir, "output.yaml")
+			require.NoError(t, os.WriteFile(input, []byte(tc.input), 0600))
+
+			opts := &options{
+				Options: specio.Options{
+					Input:  input,
+					Output: output,
+				},
+				stripHooks: tc.stripHooks,
+			}
+			err := command{logger: logger}.run(opts)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				require.NoFileExists(t, output)
+				return
+			}
+			require.NoError(t, err)
+
+			contents, err := os.ReadFile(output)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(contents))
+		})
+	}
+}
@@ -19,14 +19,11 @@ package root
 import (
 	"context"
 	"fmt"
-	"io"
-	"os"
 
 	"github.com/urfave/cli/v3"
-	"tags.cncf.io/container-device-interface/pkg/cdi"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/specio"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
-	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
 	transformroot "github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform/root"
 )
 
@@ -34,13 +31,8 @@ type command struct {
 	logger logger.Interface
 }
 
-type transformOptions struct {
-	input  string
-	output string
-}
-
 type options struct {
-	transformOptions
+	specio.Options
 	from       string
 	to         string
 	relativeTo string
@@ -79,12 +71,12 @@ func (m command) build() *cli.Command {
 				Name:        "input",
 				Usage:       "Specify the file to read the CDI specification from. If this is '-' the specification is read from STDIN",
 				Value:       "-",
-				Destination: &opts.input,
+				Destination: &opts.Input,
 			},
 			&cli.StringFlag{
 				Name:        "output",
 				Usage:       "Specify the file to output the generated CDI specification to. If this is '' the specification is output to STDOUT",
-				Destination: &opts.output,
+				Destination: &opts.Output,
 			},
 			&cli.StringFlag{
 				Name:        "relative-to",
@@ -131,41 +123,3 @@ func (m command) run(opts *options) error {
 
 	return opts.Save(spec)
 }
-
-// Load lodas the input CDI specification
-func (o transformOptions) Load() (spec.Interface, error) {
-	contents, err := o.getContents()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read spec contents: %v", err)
-	}
-
-	raw, err := cdi.ParseSpec(contents)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse CDI spec: %v", err)
-	}
-
-	return spec.New(
-		spec.WithRawSpec(raw),
-	)
-}
-
-func (o transformOptions) getContents() ([]byte, error) {
-	if o.input == "-" {
-		return io.ReadAll(os.Stdin)
-	}
-
-	return os.ReadFile(o.input)
-}
-
-// Save saves the CDI specification to the output file
-func (o transformOptions) Save(s spec.Interface) error {
-	if o.output == "" {
-		_, err := s.WriteTo(os.Stdout)
-		if err != nil {
-			return fmt.Errorf("failed to write CDI spec to STDOUT: %v", err)
-		}
-		return nil
-	}
-
-	return s.Save(o.output)
-}
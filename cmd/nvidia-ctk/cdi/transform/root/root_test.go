@@ -0,0 +1,104 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/specio"
+)
+
+func TestRunPreservesComments(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description string
+		input       string
+		expected    string
+	}{
+		{
+			description: "comments of a YAML spec are preserved",
+			input: `---
+# Generated for the driver container.
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  # The first GPU.
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+          hostPath: /driver/dev/nvidia0 # host device node
+`,
+			expected: `---
+# Generated for the driver container.
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  # The first GPU.
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0
+          hostPath: /dev/nvidia0 # host device node
+`,
+		},
+		{
+			description: "JSON specs are output using the default encoding",
+			input:       `{"cdiVersion":"0.5.0","kind":"nvidia.com/gpu","devices":[{"name":"gpu0","containerEdits":{"deviceNodes":[{"path":"/dev/nvidia0","hostPath":"/driver/dev/nvidia0"}]}}]}`,
+			expected: `---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+    - name: gpu0
+      containerEdits:
+        deviceNodes:
+            - path: /dev/nvidia0
+              hostPath: /dev/nvidia0
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, "input.yaml")
+			output := filepath.Join(dir, "output.yaml")
+			require.NoError(t, os.WriteFile(input, []byte(tc.input), 0600))
+
+			opts := &options{
+				Options: specio.Options{
+					Input:  input,
+					Output: output,
+				},
+				from:       "/driver",
+				to:         "/",
+				relativeTo: "host",
+			}
+			require.NoError(t, command{logger: logger}.run(opts))
+
+			contents, err := os.ReadFile(output)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(contents))
+		})
+	}
+}
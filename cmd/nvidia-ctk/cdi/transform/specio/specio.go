@@ -0,0 +1,88 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package specio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/spec"
+)
+
+// Options defines the input and output of a transform command.
+type Options struct {
+	Input  string
+	Output string
+}
+
+// Load loads the input CDI specification
+func (o Options) Load() (spec.Interface, error) {
+	contents, err := o.getContents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec contents: %v", err)
+	}
+
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CDI spec: %v", err)
+	}
+
+	opts := []spec.Option{
+		spec.WithRawSpec(raw),
+	}
+	// The comments of YAML input are preserved when the transformed spec is
+	// output as YAML.
+	if !isJSON(contents) {
+		marshaler, err := spec.NewCommentPreservingMarshaler(contents, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CDI spec: %v", err)
+		}
+		opts = append(opts, spec.WithYAMLMarshaler(marshaler))
+	}
+
+	return spec.New(opts...)
+}
+
+// isJSON checks whether the specified contents are a JSON document.
+func isJSON(contents []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(contents), []byte("{"))
+}
+
+func (o Options) getContents() ([]byte, error) {
+	if o.Input == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(o.Input)
+}
+
+// Save saves the CDI specification to the output file
+func (o Options) Save(s spec.Interface) error {
+	if o.Output == "" {
+		_, err := s.WriteTo(os.Stdout)
+		if err != nil {
+			return fmt.Errorf("failed to write CDI spec to STDOUT: %v", err)
+		}
+		return nil
+	}
+
+	return s.Save(o.Output)
+}
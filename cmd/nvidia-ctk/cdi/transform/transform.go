@@ -19,6 +19,7 @@ package transform
 import (
 	"github.com/urfave/cli/v3"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/hooks"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/cdi/transform/root"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
@@ -42,6 +43,7 @@ func (m command) build() *cli.Command {
 		Usage: "Apply a transform to a CDI specification",
 		Commands: []*cli.Command{
 			root.NewCommand(m.logger),
+			hooks.NewCommand(m.logger),
 		},
 	}
 
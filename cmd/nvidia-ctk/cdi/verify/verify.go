@@ -0,0 +1,125 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+
+	signing "github.com/NVIDIA/nvidia-container-toolkit/internal/cdi-signing"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	key   string
+	specs []string
+}
+
+// NewCommand constructs a cdi verify command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:      "verify",
+		Usage:     "Verify the detached signatures of signed CDI specifications",
+		ArgsUsage: "SPEC [SPEC...]",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			opts.specs = cmd.Args().Slice()
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "key",
+				Usage:       "specify the PEM-encoded Ed25519 public key to verify the signatures with",
+				Destination: &opts.key,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_VERIFY_KEY"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if opts.key == "" {
+		return errors.New("a key must be specified")
+	}
+	if len(opts.specs) == 0 {
+		return errors.New("at least one CDI specification must be specified")
+	}
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	verifier, err := signing.NewVerifierFromFile(opts.key)
+	if err != nil {
+		return fmt.Errorf("failed to load key: %w", err)
+	}
+
+	var errs error
+	for _, filename := range opts.specs {
+		if err := m.verifySpec(verifier, filename); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to verify %v: %w", filename, err))
+			continue
+		}
+		m.logger.Infof("Verified signature of %v", filename)
+	}
+	return errs
+}
+
+// verifySpec verifies the detached signature of the specified spec file.
+// The signature is authoritative. The signing key annotation of the spec is
+// only checked for consistency.
+func (m command) verifySpec(verifier *signing.Verifier, filename string) error {
+	if err := verifier.VerifyFile(filename); err != nil {
+		return err
+	}
+
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	raw, err := cdi.ParseSpec(contents)
+	if err != nil {
+		m.logger.Debugf("Not checking signing key annotation of %v: %v", filename, err)
+		return nil
+	}
+	if keyID := raw.Annotations[signing.SigningKeyAnnotation]; keyID != "" && keyID != verifier.KeyID() {
+		m.logger.Warningf("The signing key annotation of %v (%v) does not match the verification key (%v)", filename, keyID, verifier.KeyID())
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/api/config/v1"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ocipatch"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type options struct {
+	output            string
+	mode              string
+	driverRoot        string
+	devRoot           string
+	nvidiaCDIHookPath string
+	ldconfigPath      string
+}
+
+// NewCommand constructs a command to export the hooks that would be applied by
+// a generated CDI specification, together with the container edits that they
+// depend on, as an OCI runtime specification patch.
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+func (m command) build() *cli.Command {
+	opts := options{}
+
+	c := cli.Command{
+		Name:  "export",
+		Usage: "Export the hooks that would be included in a generated CDI specification, together with the mounts, device nodes, and environment that they depend on, as an OCI runtime specification patch",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&opts)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&opts)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Specify the file to output the OCI runtime specification patch to. If this is '' the patch is output to STDOUT",
+				Destination: &opts.output,
+			},
+			&cli.StringFlag{
+				Name:        "mode",
+				Aliases:     []string{"discovery-mode"},
+				Usage:       "The mode to use when discovering the hooks to export. One of [" + string(nvcdi.ModeAuto) + " | " + string(nvcdi.ModeNvml) + " | " + string(nvcdi.ModeWsl) + " | " + string(nvcdi.ModeManagement) + "]",
+				Value:       string(nvcdi.ModeAuto),
+				Destination: &opts.mode,
+				Sources:     cli.EnvVars("NVIDIA_CTK_HOOK_EXPORT_MODE"),
+			},
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the hooks",
+				Value:       "/",
+				Destination: &opts.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+				Destination: &opts.devRoot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DEV_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-cdi-hook-path",
+				Usage:       "Specify the path to use for the nvidia-cdi-hook in the exported hooks. If not specified, the PATH will be searched for `nvidia-cdi-hook`.",
+				Destination: &opts.nvidiaCDIHookPath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_HOOK_PATH"),
+			},
+			&cli.StringFlag{
+				Name:        "ldconfig-path",
+				Usage:       "Specify the path to use for ldconfig in the exported hooks",
+				Destination: &opts.ldconfigPath,
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(opts *options) error {
+	if !nvcdi.IsValidMode(opts.mode) {
+		return fmt.Errorf("invalid discovery mode: %v", opts.mode)
+	}
+	opts.nvidiaCDIHookPath = config.ResolveNVIDIACDIHookPath(m.logger, opts.nvidiaCDIHookPath)
+	return nil
+}
+
+func (m command) run(opts *options) error {
+	cdilib, err := nvcdi.New(
+		nvcdi.WithLogger(m.logger),
+		nvcdi.WithMode(opts.mode),
+		nvcdi.WithDriverRoot(opts.driverRoot),
+		nvcdi.WithDevRoot(opts.devRoot),
+		nvcdi.WithNVIDIACDIHookPath(opts.nvidiaCDIHookPath),
+		nvcdi.WithLdconfigPath(opts.ldconfigPath),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CDI library: %w", err)
+	}
+
+	// The hooks operate on the libraries, device nodes, and environment that
+	// are injected by the other container edits. These are included in the
+	// output so that the exported hooks are usable by themselves.
+	p, err := ocipatch.Generate(cdilib, "all")
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		return p.Write(os.Stdout)
+	}
+	f, err := os.Create(opts.output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+	if err := p.Write(f); err != nil {
+		return err
+	}
+	m.logger.Infof("Wrote OCI hooks to %v", opts.output)
+	return nil
+}
@@ -18,6 +18,7 @@ package hook
 
 import (
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-cdi-hook/commands"
+	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/hook/export"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 
 	"github.com/urfave/cli/v3"
@@ -37,8 +38,12 @@ func NewCommand(logger logger.Interface) *cli.Command {
 
 // build
 func (m hookCommand) build() *cli.Command {
-	return commands.ConfigureCDIHookCommand(m.logger, &cli.Command{
+	c := commands.ConfigureCDIHookCommand(m.logger, &cli.Command{
 		Name:  "hook",
 		Usage: "A collection of hooks that may be injected into an OCI spec",
 	})
+	// The export command is not a hook and is therefore only available as an
+	// nvidia-ctk subcommand.
+	c.Commands = append(c.Commands, export.NewCommand(m.logger))
+	return c
 }
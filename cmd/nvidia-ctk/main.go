@@ -18,6 +18,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 
 	"github.com/sirupsen/logrus"
@@ -28,6 +30,7 @@ import (
 	infoCLI "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/runtime"
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/system"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/errorformat"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/info"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 
@@ -43,6 +46,8 @@ type options struct {
 	Quiet bool
 	// Config specifies the path to the config file
 	Config string
+	// ErrorFormat specifies the format in which errors are output
+	ErrorFormat string
 }
 
 func main() {
@@ -70,8 +75,15 @@ func main() {
 			}
 			logger.SetLevel(logLevel)
 
+			if !errorformat.IsValid(opts.ErrorFormat) {
+				return ctx, fmt.Errorf("invalid error format %q: must be one of [%v | %v]", opts.ErrorFormat, errorformat.FormatText, errorformat.FormatJSON)
+			}
+
 			return ctx, nil
 		},
+		// Errors, including any exit codes associated with them, are handled
+		// once the CLI has returned.
+		ExitErrHandler: func(context.Context, *cli.Command, error) {},
 		// Define the subcommands
 		Commands: getCommands(logger, &opts.Config),
 		Flags: []cli.Flag{
@@ -94,15 +106,40 @@ func main() {
 				Destination: &opts.Config,
 				Sources:     cli.EnvVars("NVIDIA_CTK_CONFIG"),
 			},
+			&cli.StringFlag{
+				Name: "error-format",
+				Usage: "The format in which errors are output [text | json]. " +
+					"If json is specified, each error is written to STDERR as a JSON object with code, component, device, and message fields.",
+				Value:       errorformat.FormatText,
+				Destination: &opts.ErrorFormat,
+				Sources:     cli.EnvVars("NVIDIA_CTK_ERROR_FORMAT"),
+			},
 		},
 	}
 
 	// Run the CLI
 	err := c.Run(context.Background(), os.Args)
 	if err != nil {
-		logger.Errorf("%v", err)
-		os.Exit(1)
+		code := exitCode(err)
+		if opts.ErrorFormat != errorformat.FormatJSON {
+			logger.Errorf("%v", err)
+			os.Exit(code)
+		}
+		if err := errorformat.WriteJSON(os.Stderr, code, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+		os.Exit(code)
+	}
+}
+
+// exitCode returns the exit code for the specified error.
+// If the error does not define an exit code, 1 is returned.
+func exitCode(err error) int {
+	var exitCoder cli.ExitCoder
+	if errors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
 	}
+	return 1
 }
 
 func getCommands(logger logger.Interface, configFilePath *string) []*cli.Command {
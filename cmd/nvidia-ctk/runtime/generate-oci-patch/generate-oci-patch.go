@@ -0,0 +1,163 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package generateocipatch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/ocipatch"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+type command struct {
+	logger logger.Interface
+}
+
+type config struct {
+	output            string
+	devices           []string
+	mode              string
+	driverRoot        string
+	devRoot           string
+	nvidiaCDIHookPath string
+	ldconfigPath      string
+}
+
+// NewCommand constructs a generate-oci-patch command with the specified logger
+func NewCommand(logger logger.Interface) *cli.Command {
+	c := command{
+		logger: logger,
+	}
+	return c.build()
+}
+
+// build creates the CLI command
+func (m command) build() *cli.Command {
+	cfg := config{}
+
+	c := cli.Command{
+		Name: "generate-oci-patch",
+		Usage: "Generate the modifications to an OCI runtime specification (config.json) that are required to make the " +
+			"requested NVIDIA devices available in a container. This is intended for runtimes that do not support CDI.",
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, m.validateFlags(&cfg)
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return m.run(&cfg)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "Specify the file to output the generated patch to. If this is '' the patch is output to STDOUT",
+				Destination: &cfg.output,
+				Sources:     cli.EnvVars("NVIDIA_CTK_RUNTIME_GENERATE_OCI_PATCH_OUTPUT"),
+			},
+			&cli.StringSliceFlag{
+				Name:        "device",
+				Usage:       "Specify a device to include in the patch. A device can be specified as an index, a UUID, or 'all'. This can be specified multiple times.",
+				Value:       []string{"all"},
+				Destination: &cfg.devices,
+				Sources:     cli.EnvVars("NVIDIA_CTK_RUNTIME_GENERATE_OCI_PATCH_DEVICES"),
+			},
+			&cli.StringFlag{
+				Name:    "mode",
+				Aliases: []string{"discovery-mode"},
+				Usage: "The mode to use when discovering the available entities. " +
+					"One of [" + strings.Join(nvcdi.AllModes[string](), " | ") + "]. " +
+					"If mode is set to 'auto' the mode will be determined based on the system configuration.",
+				Value:       string(nvcdi.ModeAuto),
+				Destination: &cfg.mode,
+				Sources:     cli.EnvVars("NVIDIA_CTK_RUNTIME_GENERATE_OCI_PATCH_MODE"),
+			},
+			&cli.StringFlag{
+				Name:        "driver-root",
+				Usage:       "Specify the NVIDIA GPU driver root to use when discovering the entities that should be included in the patch.",
+				Value:       "/",
+				Destination: &cfg.driverRoot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DRIVER_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "dev-root",
+				Usage:       "Specify the root where `/dev` is located. If this is not specified, the driver-root is assumed.",
+				Destination: &cfg.devRoot,
+				Sources:     cli.EnvVars("NVIDIA_CTK_DEV_ROOT"),
+			},
+			&cli.StringFlag{
+				Name:        "nvidia-cdi-hook-path",
+				Aliases:     []string{"nvidia-ctk-path"},
+				Usage:       "Specify the path to use for hooks in the generated patch. If this is not specified, the default path is used.",
+				Destination: &cfg.nvidiaCDIHookPath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_CDI_HOOK_PATH"),
+			},
+			&cli.StringFlag{
+				Name:        "ldconfig-path",
+				Usage:       "Specify the path to use for ldconfig in the generated patch",
+				Destination: &cfg.ldconfigPath,
+				Sources:     cli.EnvVars("NVIDIA_CTK_RUNTIME_GENERATE_OCI_PATCH_LDCONFIG_PATH"),
+			},
+		},
+	}
+
+	return &c
+}
+
+func (m command) validateFlags(cfg *config) error {
+	if len(cfg.devices) == 0 {
+		return fmt.Errorf("at least one device must be specified")
+	}
+
+	cfg.mode = strings.ToLower(cfg.mode)
+	if !nvcdi.IsValidMode(cfg.mode) {
+		return fmt.Errorf("invalid discovery mode: %v", cfg.mode)
+	}
+	return nil
+}
+
+func (m command) run(cfg *config) error {
+	lib, err := nvcdi.New(
+		nvcdi.WithLogger(m.logger),
+		nvcdi.WithDriverRoot(cfg.driverRoot),
+		nvcdi.WithDevRoot(cfg.devRoot),
+		nvcdi.WithNVIDIACDIHookPath(cfg.nvidiaCDIHookPath),
+		nvcdi.WithLdconfigPath(cfg.ldconfigPath),
+		nvcdi.WithMode(nvcdi.Mode(cfg.mode)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CDI library: %w", err)
+	}
+
+	p, err := ocipatch.Generate(lib, cfg.devices...)
+	if err != nil {
+		return err
+	}
+
+	if cfg.output == "" {
+		return p.Write(os.Stdout)
+	}
+	f, err := os.Create(cfg.output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer f.Close()
+	return p.Write(f)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/runtime/configure"
+	generateocipatch "github.com/NVIDIA/nvidia-container-toolkit/cmd/nvidia-ctk/runtime/generate-oci-patch"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 )
 
@@ -42,6 +43,7 @@ func (m runtimeCommand) build() *cli.Command {
 		Usage: "A collection of runtime-related utilities for the NVIDIA Container Toolkit",
 		Commands: []*cli.Command{
 			configure.NewCommand(m.logger),
+			generateocipatch.NewCommand(m.logger),
 		},
 	}
 
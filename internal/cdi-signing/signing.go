@@ -0,0 +1,163 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// SigningKeyAnnotation is the spec annotation that records the ID of the
+	// key used to sign a CDI specification.
+	SigningKeyAnnotation = "cdi.nvidia.com/signing-key"
+
+	signatureFileExtension = ".sig"
+)
+
+// A Signer creates detached signatures for CDI specifications.
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// A Verifier checks detached signatures for CDI specifications.
+type Verifier struct {
+	key ed25519.PublicKey
+}
+
+// NewSignerFromFile creates a signer from the PEM-encoded PKCS #8 Ed25519
+// private key in the specified file.
+func NewSignerFromFile(filename string) (*Signer, error) {
+	key, err := loadKey(filename)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%v does not contain an Ed25519 private key", filename)
+	}
+	return &Signer{key: privateKey}, nil
+}
+
+// NewVerifierFromFile creates a verifier from the PEM-encoded Ed25519 key in
+// the specified file. Both public keys and private keys are supported.
+func NewVerifierFromFile(filename string) (*Verifier, error) {
+	key, err := loadKey(filename)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return &Verifier{key: k}, nil
+	case ed25519.PrivateKey:
+		return &Verifier{key: k.Public().(ed25519.PublicKey)}, nil
+	}
+	return nil, fmt.Errorf("%v does not contain an Ed25519 key", filename)
+}
+
+// KeyID returns the identity of the signing key. This is the SHA-256
+// fingerprint of the public key.
+func (s *Signer) KeyID() string {
+	return keyID(s.key.Public().(ed25519.PublicKey))
+}
+
+// KeyID returns the identity of the verification key.
+func (v *Verifier) KeyID() string {
+	return keyID(v.key)
+}
+
+// Sign returns the base64-encoded signature of the specified contents.
+func (s *Signer) Sign(contents []byte) []byte {
+	signature := ed25519.Sign(s.key, contents)
+	return []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+}
+
+// SignFile writes a detached signature for the specified file to a sidecar
+// file.
+func (s *Signer) SignFile(filename string) error {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read %v: %w", filename, err)
+	}
+	if err := os.WriteFile(SignatureFilename(filename), s.Sign(contents), 0644); err != nil {
+		return fmt.Errorf("failed to write signature: %w", err)
+	}
+	return nil
+}
+
+// Verify checks the base64-encoded signature against the specified contents.
+func (v *Verifier) Verify(contents []byte, signature []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+	if !ed25519.Verify(v.key, contents, decoded) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+// VerifyFile checks the detached signature in the sidecar file of the
+// specified file.
+func (v *Verifier) VerifyFile(filename string) error {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read %v: %w", filename, err)
+	}
+	signature, err := os.ReadFile(SignatureFilename(filename))
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %w", err)
+	}
+	return v.Verify(contents, signature)
+}
+
+// SignatureFilename returns the name of the sidecar file containing the
+// detached signature for the specified file.
+func SignatureFilename(filename string) string {
+	return filename + signatureFileExtension
+}
+
+func loadKey(filename string) (any, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %v", filename)
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("unsupported PEM block type %q in %v", block.Type, filename)
+}
+
+func keyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,91 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerifyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	privateKeyFile := writeKey(t, dir, "key.pem", "PRIVATE KEY", privateKey)
+	publicKeyFile := writeKey(t, dir, "key.pub", "PUBLIC KEY", publicKey)
+
+	signer, err := NewSignerFromFile(privateKeyFile)
+	require.NoError(t, err)
+
+	specFile := filepath.Join(dir, "spec.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte("cdiVersion: 0.6.0\n"), 0644))
+	require.NoError(t, signer.SignFile(specFile))
+	require.FileExists(t, specFile+".sig")
+
+	testCases := []struct {
+		description string
+		keyFile     string
+	}{
+		{
+			description: "public key",
+			keyFile:     publicKeyFile,
+		},
+		{
+			description: "private key",
+			keyFile:     privateKeyFile,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			verifier, err := NewVerifierFromFile(tc.keyFile)
+			require.NoError(t, err)
+			require.Equal(t, signer.KeyID(), verifier.KeyID())
+			require.NoError(t, verifier.VerifyFile(specFile))
+		})
+	}
+
+	t.Run("modified file", func(t *testing.T) {
+		verifier, err := NewVerifierFromFile(publicKeyFile)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(specFile, []byte("cdiVersion: 0.7.0\n"), 0644))
+		require.EqualError(t, verifier.VerifyFile(specFile), "signature verification failed")
+	})
+}
+
+func writeKey(t *testing.T, dir string, name string, blockType string, key any) string {
+	var der []byte
+	var err error
+	switch blockType {
+	case "PRIVATE KEY":
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	default:
+		der, err = x509.MarshalPKIXPublicKey(key)
+	}
+	require.NoError(t, err)
+
+	filename := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(filename, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return filename
+}
@@ -145,6 +145,14 @@ func (i CUDA) HasDisableRequire() bool {
 	return false
 }
 
+// IsCDIInjected returns whether the NVIDIA Container Toolkit components have
+// already been injected into the container using CDI. This is indicated by a
+// marker environment variable included in a CDI specification.
+func (i CUDA) IsCDIInjected() bool {
+	injected, _ := strconv.ParseBool(i.env[EnvVarNvidiaCTKCDIInjected])
+	return injected
+}
+
 // devicesFromEnvvars returns the devices requested by the image through environment variables
 func (i CUDA) devicesFromEnvvars(envVars ...string) []string {
 	// We concantenate all the devices from the specified env.
@@ -27,5 +27,9 @@ const (
 	EnvVarNvidiaRequireJetpack     = NvidiaRequirePrefix + "JETPACK"
 	EnvVarNvidiaVisibleDevices     = "NVIDIA_VISIBLE_DEVICES"
 
+	// EnvVarNvidiaCTKCDIInjected is set in containers where the NVIDIA
+	// Container Toolkit components have already been injected using CDI.
+	EnvVarNvidiaCTKCDIInjected = "NVIDIA_CTK_CDI_INJECTED"
+
 	NvidiaRequirePrefix = "NVIDIA_REQUIRE_"
 )
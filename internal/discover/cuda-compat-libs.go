@@ -0,0 +1,129 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+// cudaCompatLibraryPatterns are the patterns used to locate the libcuda.so
+// library of a CUDA forward compatibility package under the driver root. The
+// patterns are checked in order.
+var cudaCompatLibraryPatterns = []string{
+	"/usr/local/cuda/compat/libcuda.so.*.*",
+	"/usr/local/cuda-*/compat/libcuda.so.*.*",
+}
+
+// cudaCompatLibs is a discoverer for the CUDA forward compatibility libraries
+// that are installed under the driver root.
+type cudaCompatLibs struct {
+	None
+	logger            logger.Interface
+	driver            *root.Driver
+	hookCreator       HookCreator
+	hostDriverVersion string
+}
+
+var _ Discover = (*cudaCompatLibs)(nil)
+
+// NewCUDACompatLibsDiscoverer creates a discoverer for the CUDA forward
+// compatibility libraries installed under the driver root. The libraries are
+// mounted at the same path in the container and an enable-cuda-compat hook is
+// added so that these take precedence over the driver libraries if they are
+// newer than the host driver. If no compat package is found, nothing is
+// discovered.
+func NewCUDACompatLibsDiscoverer(logger logger.Interface, driver *root.Driver, hookCreator HookCreator, hostDriverVersion string) Discover {
+	d := &cudaCompatLibs{
+		logger:            logger,
+		driver:            driver,
+		hookCreator:       hookCreator,
+		hostDriverVersion: hostDriverVersion,
+	}
+	return WithCache(d)
+}
+
+// Mounts returns the mounts for the libraries in the CUDA compat folder.
+// Symlinks are not mounted since these are created by ldconfig in the
+// container.
+func (d *cudaCompatLibs) Mounts() ([]Mount, error) {
+	compatDir := d.getCompatDir()
+	if compatDir == "" {
+		return nil, nil
+	}
+
+	candidates, err := d.driver.Files().Locate(filepath.Join(compatDir, "*.so.*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate CUDA compat libraries: %w", err)
+	}
+
+	var mounts []Mount
+	for _, candidate := range candidates {
+		info, err := os.Lstat(candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %v: %w", candidate, err)
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		mounts = append(mounts, Mount{
+			HostPath: candidate,
+			Path:     d.driver.RelativeToRoot(candidate),
+			Options: []string{
+				"ro",
+				"nosuid",
+				"nodev",
+				"rbind",
+				"rprivate",
+			},
+		})
+	}
+	return mounts, nil
+}
+
+// Hooks returns the enable-cuda-compat hook for the CUDA compat folder.
+func (d *cudaCompatLibs) Hooks() ([]Hook, error) {
+	compatDir := d.getCompatDir()
+	if compatDir == "" {
+		return nil, nil
+	}
+	o := &EnableCUDACompatHookOptions{
+		HostDriverVersion:       d.hostDriverVersion,
+		CUDACompatContainerRoot: compatDir,
+	}
+	return d.hookCreator.Create(EnableCudaCompatHook, o.args()...).Hooks()
+}
+
+// getCompatDir returns the path of the CUDA compat folder relative to the
+// driver root. An empty path is returned if no compat package is installed.
+func (d *cudaCompatLibs) getCompatDir() string {
+	for _, pattern := range cudaCompatLibraryPatterns {
+		candidates, err := d.driver.Files().Locate(pattern)
+		if err != nil || len(candidates) == 0 {
+			continue
+		}
+		compatDir := filepath.Dir(d.driver.RelativeToRoot(candidates[0]))
+		d.logger.Debugf("Using CUDA compat libraries from %v", compatDir)
+		return compatDir
+	}
+	d.logger.Debugf("No CUDA compat libraries found")
+	return ""
+}
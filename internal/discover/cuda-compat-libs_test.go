@@ -0,0 +1,122 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestNewCUDACompatLibsDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	mountOptions := []string{"ro", "nosuid", "nodev", "rbind", "rprivate"}
+
+	testCases := []struct {
+		description    string
+		compatDir      string
+		expectedMounts []discover.Mount
+		expectedHooks  []discover.Hook
+	}{
+		{
+			description: "no compat package is a no-op",
+		},
+		{
+			description: "compat libraries are mounted",
+			compatDir:   "/usr/local/cuda/compat",
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/usr/local/cuda/compat/libcuda.so.999.88.77",
+					Path:     "/usr/local/cuda/compat/libcuda.so.999.88.77",
+					Options:  mountOptions,
+				},
+				{
+					HostPath: "/usr/local/cuda/compat/libnvidia-ptxjitcompiler.so.999.88.77",
+					Path:     "/usr/local/cuda/compat/libnvidia-ptxjitcompiler.so.999.88.77",
+					Options:  mountOptions,
+				},
+			},
+			expectedHooks: []discover.Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      "/usr/bin/nvidia-cdi-hook",
+					Args:      []string{"nvidia-cdi-hook", "enable-cuda-compat", "--host-driver-version=555.42.06", "--cuda-compat-container-root=/usr/local/cuda/compat"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+		{
+			description: "versioned cuda folder is supported",
+			compatDir:   "/usr/local/cuda-13.0/compat",
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/usr/local/cuda-13.0/compat/libcuda.so.999.88.77",
+					Path:     "/usr/local/cuda-13.0/compat/libcuda.so.999.88.77",
+					Options:  mountOptions,
+				},
+				{
+					HostPath: "/usr/local/cuda-13.0/compat/libnvidia-ptxjitcompiler.so.999.88.77",
+					Path:     "/usr/local/cuda-13.0/compat/libnvidia-ptxjitcompiler.so.999.88.77",
+					Options:  mountOptions,
+				},
+			},
+			expectedHooks: []discover.Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      "/usr/bin/nvidia-cdi-hook",
+					Args:      []string{"nvidia-cdi-hook", "enable-cuda-compat", "--host-driver-version=555.42.06", "--cuda-compat-container-root=/usr/local/cuda-13.0/compat"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+
+			if tc.compatDir != "" {
+				compatDir := filepath.Join(driverRoot, tc.compatDir)
+				require.NoError(t, os.MkdirAll(compatDir, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(compatDir, "libcuda.so.999.88.77"), nil, 0600))
+				require.NoError(t, os.Symlink("libcuda.so.999.88.77", filepath.Join(compatDir, "libcuda.so.1")))
+				require.NoError(t, os.WriteFile(filepath.Join(compatDir, "libnvidia-ptxjitcompiler.so.999.88.77"), nil, 0600))
+			}
+
+			driver := root.New(
+				root.WithDriverRoot(driverRoot),
+			)
+			d := discover.NewCUDACompatLibsDiscoverer(logger, driver, discover.NewHookCreator(), "555.42.06")
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, test.StripRoot(mounts, driverRoot))
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedHooks, hooks)
+		})
+	}
+}
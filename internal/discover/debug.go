@@ -0,0 +1,98 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// debugDump is a discoverer that logs the entities returned by the wrapped
+// discoverer.
+type debugDump struct {
+	Discover
+	logger logger.Interface
+	name   string
+}
+
+var _ Discover = (*debugDump)(nil)
+
+// WithDebugDump wraps the specified discoverer so that the discovered devices,
+// environment variables, mounts, and hooks are logged at debug level. The name
+// is used to identify the discoverer in the logged output.
+func WithDebugDump(logger logger.Interface, name string, d Discover) Discover {
+	if d == nil {
+		return nil
+	}
+	return &debugDump{
+		Discover: d,
+		logger:   logger,
+		name:     name,
+	}
+}
+
+// Devices returns and logs the devices of the wrapped discoverer.
+func (d *debugDump) Devices() ([]Device, error) {
+	devices, err := d.Discover.Devices()
+	if err != nil {
+		d.logger.Debugf("%v: failed to discover devices: %v", d.name, err)
+		return nil, err
+	}
+	for _, device := range devices {
+		d.logger.Debugf("%v: discovered device: %+v", d.name, device)
+	}
+	return devices, nil
+}
+
+// EnvVars returns and logs the environment variables of the wrapped discoverer.
+func (d *debugDump) EnvVars() ([]EnvVar, error) {
+	envVars, err := d.Discover.EnvVars()
+	if err != nil {
+		d.logger.Debugf("%v: failed to discover environment variables: %v", d.name, err)
+		return nil, err
+	}
+	for _, envVar := range envVars {
+		d.logger.Debugf("%v: discovered environment variable: %+v", d.name, envVar)
+	}
+	return envVars, nil
+}
+
+// Mounts returns and logs the mounts of the wrapped discoverer.
+func (d *debugDump) Mounts() ([]Mount, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		d.logger.Debugf("%v: failed to discover mounts: %v", d.name, err)
+		return nil, err
+	}
+	for _, mount := range mounts {
+		d.logger.Debugf("%v: discovered mount: %+v", d.name, mount)
+	}
+	return mounts, nil
+}
+
+// Hooks returns and logs the hooks of the wrapped discoverer.
+func (d *debugDump) Hooks() ([]Hook, error) {
+	hooks, err := d.Discover.Hooks()
+	if err != nil {
+		d.logger.Debugf("%v: failed to discover hooks: %v", d.name, err)
+		return nil, err
+	}
+	for _, hook := range hooks {
+		d.logger.Debugf("%v: discovered hook: %+v", d.name, hook)
+	}
+	return hooks, nil
+}
@@ -0,0 +1,71 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDebugDump(t *testing.T) {
+	logger, logHook := testlog.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	d := WithDebugDump(logger, "test",
+		&DiscoverMock{
+			DevicesFunc: func() ([]Device, error) {
+				return []Device{{HostPath: "/dev/nvidia0", Path: "/dev/nvidia0"}}, nil
+			},
+			MountsFunc: func() ([]Mount, error) {
+				return []Mount{{HostPath: "/usr/lib/libcuda.so.1", Path: "/usr/lib/libcuda.so.1"}}, nil
+			},
+			HooksFunc: func() ([]Hook, error) {
+				return nil, errors.New("hook error")
+			},
+		},
+	)
+
+	devices, err := d.Devices()
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+
+	mounts, err := d.Mounts()
+	require.NoError(t, err)
+	require.Len(t, mounts, 1)
+
+	_, err = d.Hooks()
+	require.Error(t, err)
+
+	var messages []string
+	for _, entry := range logHook.AllEntries() {
+		require.Equal(t, logrus.DebugLevel, entry.Level)
+		messages = append(messages, entry.Message)
+	}
+	require.Equal(t,
+		[]string{
+			"test: discovered device: {HostPath:/dev/nvidia0 Path:/dev/nvidia0}",
+			"test: discovered mount: {HostPath:/usr/lib/libcuda.so.1 Path:/usr/lib/libcuda.so.1 Options:[]}",
+			"test: failed to discover hooks: hook error",
+		},
+		messages,
+	)
+}
@@ -0,0 +1,186 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// dedupedLibraries is a discoverer that collapses library mounts that refer
+// to the same file on the host.
+type dedupedLibraries struct {
+	Discover
+	logger      logger.Interface
+	hookCreator HookCreator
+}
+
+var _ Discover = (*dedupedLibraries)(nil)
+
+// fileID uniquely identifies a file on the host.
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+// WithDedupedLibraries decorates the specified discoverer so that library
+// mounts that resolve to the same host file (e.g. libcuda.so.1 and
+// libcuda.so.RM_VERSION) are only mounted once. The mount of the real
+// (versioned) file is kept and the remaining paths are created as symlinks to
+// it using the create-symlinks hook.
+func WithDedupedLibraries(logger logger.Interface, d Discover, hookCreator HookCreator) Discover {
+	return &dedupedLibraries{
+		Discover:    d,
+		logger:      logger,
+		hookCreator: hookCreator,
+	}
+}
+
+// Mounts returns the deduplicated library mounts.
+func (d *dedupedLibraries) Mounts() ([]Mount, error) {
+	mounts, _, err := d.dedupe()
+	return mounts, err
+}
+
+// Hooks returns the hooks of the wrapped discoverer as well as a hook to create
+// symlinks for the library mounts that were removed.
+func (d *dedupedLibraries) Hooks() ([]Hook, error) {
+	hooks, err := d.Discover.Hooks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hooks: %w", err)
+	}
+	_, links, err := d.dedupe()
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return hooks, nil
+	}
+	symlinkHooks, err := d.hookCreator.Create(CreateSymlinksHook, links...).Hooks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create symlink hook: %w", err)
+	}
+	return append(hooks, symlinkHooks...), nil
+}
+
+// dedupe returns the library mounts with duplicates removed as well as the
+// symlinks that replace the removed mounts.
+func (d *dedupedLibraries) dedupe() ([]Mount, []string, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get library mounts: %w", err)
+	}
+
+	groups := make(map[fileID][]int)
+	var order []fileID
+	for i, m := range mounts {
+		id, ok := getFileID(m.HostPath)
+		if !ok || !isLibName(m.Path) {
+			continue
+		}
+		if _, exists := groups[id]; !exists {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], i)
+	}
+
+	removed := make(map[int]bool)
+	replacements := make(map[int]int)
+	var links []string
+	for _, id := range order {
+		group := groups[id]
+		if len(group) < 2 {
+			continue
+		}
+		preferred := group[0]
+		for _, i := range group[1:] {
+			if isPreferredLibraryMount(mounts[i], mounts[preferred]) {
+				preferred = i
+			}
+		}
+		// The preferred mount replaces the first mount in the group so that
+		// the order of the mounts is otherwise unchanged.
+		replacements[group[0]] = preferred
+		for _, i := range group[1:] {
+			removed[i] = true
+		}
+		for _, i := range group {
+			if i == preferred {
+				continue
+			}
+			target := mounts[preferred].Path
+			if filepath.Dir(target) == filepath.Dir(mounts[i].Path) {
+				target = filepath.Base(target)
+			}
+			d.logger.Debugf("Replacing mount of %v with a symlink to %v", mounts[i].HostPath, mounts[preferred].HostPath)
+			links = append(links, fmt.Sprintf("%s::%s", target, mounts[i].Path))
+		}
+	}
+
+	var deduped []Mount
+	for i, m := range mounts {
+		if p, ok := replacements[i]; ok {
+			deduped = append(deduped, mounts[p])
+			continue
+		}
+		if removed[i] {
+			continue
+		}
+		deduped = append(deduped, m)
+	}
+	return deduped, links, nil
+}
+
+// isPreferredLibraryMount checks whether the candidate mount is preferred over
+// the current mount for the same host file. A mount of a regular file is
+// preferred over a mount of a symlink. Otherwise the longer (i.e. more
+// specifically versioned) filename is preferred.
+func isPreferredLibraryMount(candidate Mount, current Mount) bool {
+	candidateIsLink := isSymlink(candidate.HostPath)
+	currentIsLink := isSymlink(current.HostPath)
+	if candidateIsLink != currentIsLink {
+		return currentIsLink
+	}
+	return len(filepath.Base(candidate.Path)) > len(filepath.Base(current.Path))
+}
+
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeSymlink != 0
+}
+
+// getFileID returns the device and inode of the file that the specified path
+// resolves to.
+func getFileID(path string) (fileID, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileID{}, false
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileID{}, false
+	}
+	//nolint:unconvert // The types of Dev and Ino differ between platforms.
+	return fileID{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}, true
+}
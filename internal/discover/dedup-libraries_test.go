@@ -0,0 +1,131 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDedupedLibraries(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	libDir := t.TempDir()
+	realLib := filepath.Join(libDir, "libcuda.so.570.133.20")
+	require.NoError(t, os.WriteFile(realLib, []byte("libcuda"), 0600))
+	soname := filepath.Join(libDir, "libcuda.so.1")
+	require.NoError(t, os.Symlink("libcuda.so.570.133.20", soname))
+	otherLib := filepath.Join(libDir, "libnvidia-ml.so.570.133.20")
+	require.NoError(t, os.WriteFile(otherLib, []byte("libnvidia-ml"), 0600))
+
+	testCases := []struct {
+		description    string
+		mounts         []Mount
+		expectedMounts []Mount
+		expectedHooks  []Hook
+	}{
+		{
+			description: "distinct libraries are unchanged",
+			mounts: []Mount{
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+				{HostPath: otherLib, Path: "/usr/lib/libnvidia-ml.so.570.133.20"},
+			},
+			expectedMounts: []Mount{
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+				{HostPath: otherLib, Path: "/usr/lib/libnvidia-ml.so.570.133.20"},
+			},
+		},
+		{
+			description: "symlink is replaced by a hook",
+			mounts: []Mount{
+				{HostPath: soname, Path: "/usr/lib/libcuda.so.1"},
+				{HostPath: otherLib, Path: "/usr/lib/libnvidia-ml.so.570.133.20"},
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+			},
+			expectedMounts: []Mount{
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+				{HostPath: otherLib, Path: "/usr/lib/libnvidia-ml.so.570.133.20"},
+			},
+			expectedHooks: []Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      "/usr/bin/nvidia-cdi-hook",
+					Args:      []string{"nvidia-cdi-hook", "create-symlinks", "--link", "libcuda.so.570.133.20::/usr/lib/libcuda.so.1"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+		{
+			description: "symlink in a different folder uses an absolute target",
+			mounts: []Mount{
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+				{HostPath: soname, Path: "/usr/lib64/libcuda.so.1"},
+			},
+			expectedMounts: []Mount{
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+			},
+			expectedHooks: []Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      "/usr/bin/nvidia-cdi-hook",
+					Args:      []string{"nvidia-cdi-hook", "create-symlinks", "--link", "/usr/lib/libcuda.so.570.133.20::/usr/lib64/libcuda.so.1"},
+					Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+		{
+			description: "non-library mounts are unchanged",
+			mounts: []Mount{
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+				{HostPath: soname, Path: "/etc/some-config"},
+			},
+			expectedMounts: []Mount{
+				{HostPath: realLib, Path: "/usr/lib/libcuda.so.570.133.20"},
+				{HostPath: soname, Path: "/etc/some-config"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			d := WithDedupedLibraries(
+				logger,
+				&DiscoverMock{
+					HooksFunc: func() ([]Hook, error) {
+						return nil, nil
+					},
+					MountsFunc: func() ([]Mount, error) {
+						return tc.mounts, nil
+					},
+				},
+				NewHookCreator(),
+			)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, mounts)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedHooks, hooks)
+		})
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
@@ -49,7 +48,8 @@ func NewDRMNodesDiscoverer(logger logger.Interface, devices image.VisibleDevices
 }
 
 // NewGraphicsMountsDiscoverer creates a discoverer for the mounts required by graphics tools such as vulkan.
-func NewGraphicsMountsDiscoverer(logger logger.Interface, driver *root.Driver, hookCreator HookCreator) (Discover, error) {
+// Rewritten Vulkan manifests are created in the specified vulkanManifestDir.
+func NewGraphicsMountsDiscoverer(logger logger.Interface, driver *root.Driver, hookCreator HookCreator, vulkanManifestDir string) (Discover, error) {
 	libraries, err := newGraphicsLibrariesDiscoverer(logger, driver, hookCreator)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct discoverer for graphics libraries: %w", err)
@@ -67,48 +67,18 @@ func NewGraphicsMountsDiscoverer(logger logger.Interface, driver *root.Driver, h
 			"nvidia/nvoptix.bin",
 			"X11/xorg.conf.d/10-nvidia.conf",
 			"X11/xorg.conf.d/nvidia-drm-outputclass.conf",
-			"OpenCL/vendors/nvidia.icd",
 		},
 	)
 
 	discover := Merge(
 		libraries,
 		configs,
-		newVulkanConfigsDiscover(logger, driver),
+		NewVulkanDiscoverer(logger, driver, vulkanManifestDir),
 	)
 
 	return discover, nil
 }
 
-// newVulkanConfigsDiscover creates a discoverer for vulkan ICD files.
-// For these files we search the standard driver config paths as well as the
-// driver root itself. This allows us to support GKE installations where the
-// vulkan ICD files are at {{ .driverRoot }}/vulkan instead of in /etc/vulkan.
-func newVulkanConfigsDiscover(logger logger.Interface, driver *root.Driver) Discover {
-	locator := lookup.First(driver.Configs(), driver.Files())
-
-	required := []string{
-		"vulkan/icd.d/nvidia_icd.json",
-		"vulkan/icd.d/nvidia_layers.json",
-		"vulkan/implicit_layer.d/nvidia_layers.json",
-	}
-	// For some RPM-based driver packages, the vulkan ICD files are installed to
-	// /usr/share/vulkan/icd.d/nvidia_icd.%{_target_cpu}.json
-	// We also include this in the list of candidates for the ICD file.
-	switch runtime.GOARCH {
-	case "amd64":
-		required = append(required, "vulkan/icd.d/nvidia_icd.x86_64.json")
-	case "arm64":
-		required = append(required, "vulkan/icd.d/nvidia_icd.aarch64.json")
-	}
-	return &mountsToContainerPath{
-		logger:        logger,
-		locator:       locator,
-		required:      required,
-		containerRoot: "/etc",
-	}
-}
-
 type graphicsDriverLibraries struct {
 	Discover
 	logger        logger.Interface
@@ -19,6 +19,7 @@ package discover
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 )
@@ -60,11 +61,18 @@ const (
 	// An EnableCudaCompatHook is used to enabled CUDA Forward Compatibility.
 	// Added in v1.17.5
 	EnableCudaCompatHook = HookName("enable-cuda-compat")
+	// A SetApplicationClocksHook is used to set the application clocks of the
+	// devices that are injected into a container.
+	SetApplicationClocksHook = HookName("set-application-clocks")
 	// An UpdateLDCacheHook is the hook used to update the ldcache in the
 	// container. This allows injected libraries to be discoverable.
 	UpdateLDCacheHook = HookName("update-ldcache")
+	// A WaitForDeviceNodesHook is used to delay the start of a container
+	// until the specified device nodes exist.
+	WaitForDeviceNodesHook = HookName("wait-for-device-nodes")
 
 	defaultNvidiaCDIHookPath = "/usr/bin/nvidia-cdi-hook"
+	defaultChmodMode         = "755"
 )
 
 // defaultDisabledHooks defines hooks that are disabled by default.
@@ -108,6 +116,8 @@ type hookCreatorOptions struct {
 	disabledHooks     []HookName
 	enabledHooks      []HookName
 	debugLogging      bool
+	chmodMode         string
+	chmodGroup        string
 }
 
 type Option func(*hookCreatorOptions)
@@ -119,6 +129,9 @@ type cdiHookCreator struct {
 
 	fixedArgs    []string
 	debugLogging bool
+
+	chmodMode  string
+	chmodGroup string
 }
 
 // An allDisabledHookCreator is a HookCreator that does not create any hooks.
@@ -156,6 +169,22 @@ func WithEnabledHooks(hooks ...HookName) Option {
 	}
 }
 
+// WithChmodMode sets the octal file mode that is applied by the chmod hook.
+// If this is not specified, a mode of 755 is used.
+func WithChmodMode(mode string) Option {
+	return func(c *hookCreatorOptions) {
+		c.chmodMode = mode
+	}
+}
+
+// WithChmodGroup sets the numeric group ID that the chmod hook assigns to the
+// specified paths. If this is not specified, the group is left unchanged.
+func WithChmodGroup(gid string) Option {
+	return func(c *hookCreatorOptions) {
+		c.chmodGroup = gid
+	}
+}
+
 func WithLdconfigPath(ldconfigPath string) Option {
 	return func(c *hookCreatorOptions) {
 		c.ldconfigPath = ldconfigPath
@@ -198,6 +227,8 @@ func NewHookCreator(opts ...Option) HookCreator {
 		disabledHooks:     disabledHooks,
 		fixedArgs:         getFixedArgsForCDIHookCLI(o.nvidiaCDIHookPath),
 		debugLogging:      o.debugLogging,
+		chmodMode:         o.chmodMode,
+		chmodGroup:        o.chmodGroup,
 	}
 
 	return c
@@ -248,16 +279,28 @@ func (c cdiHookCreator) requiredArgs(name HookName) []string {
 	return append(c.fixedArgs, string(name))
 }
 
+// transformArgs converts the specified arguments to the command line arguments
+// of the hook. For hooks where the order of the arguments does not matter, the
+// arguments are sorted so that the generated hooks do not depend on the order
+// in which files were discovered. Note that the order of the folders for the
+// update-ldcache hook is preserved since this determines the search order.
 func (c cdiHookCreator) transformArgs(name HookName, args ...string) []string {
 	var transformedArgs []string
 	switch name {
 	case CreateSymlinksHook:
-		for _, arg := range args {
+		for _, arg := range slices.Sorted(slices.Values(args)) {
 			transformedArgs = append(transformedArgs, "--link", arg)
 		}
 	case ChmodHook:
-		transformedArgs = append(transformedArgs, "--mode", "755")
-		for _, arg := range args {
+		mode := c.chmodMode
+		if mode == "" {
+			mode = defaultChmodMode
+		}
+		transformedArgs = append(transformedArgs, "--mode", mode)
+		if c.chmodGroup != "" {
+			transformedArgs = append(transformedArgs, "--group", c.chmodGroup)
+		}
+		for _, arg := range slices.Sorted(slices.Values(args)) {
 			transformedArgs = append(transformedArgs, "--path", arg)
 		}
 	case UpdateLDCacheHook:
@@ -176,7 +176,7 @@ func TestCDIHookCreator_Create(t *testing.T) {
 			expectedHook: &Hook{
 				Lifecycle: "createContainer",
 				Path:      "/usr/bin/nvidia-cdi-hook",
-				Args:      []string{"nvidia-cdi-hook", "create-symlinks", "--link", "/source::/target", "--link", "/source2::/target2"},
+				Args:      []string{"nvidia-cdi-hook", "create-symlinks", "--link", "/source2::/target2", "--link", "/source::/target"},
 				Env:       []string{"NVIDIA_CTK_DEBUG=false"},
 			},
 		},
@@ -202,6 +202,23 @@ func TestCDIHookCreator_Create(t *testing.T) {
 				Env:       []string{"NVIDIA_CTK_DEBUG=false"},
 			},
 		},
+		{
+			name: "ChmodHook with mode and group",
+			hookCreator: NewHookCreator(
+				WithNVIDIACDIHookPath(defaultNvidiaCDIHookPath),
+				WithEnabledHooks(ChmodHook),
+				WithChmodMode("750"),
+				WithChmodGroup("44"),
+			),
+			hookName: ChmodHook,
+			args:     []string{"/dev/dri"},
+			expectedHook: &Hook{
+				Lifecycle: "createContainer",
+				Path:      defaultNvidiaCDIHookPath,
+				Args:      []string{"nvidia-cdi-hook", "chmod", "--mode", "750", "--group", "44", "--path", "/dev/dri"},
+				Env:       []string{"NVIDIA_CTK_DEBUG=false"},
+			},
+		},
 		{
 			name:         "ChmodHook disabled by default returns nil",
 			hookCreator:  NewHookCreator(WithNVIDIACDIHookPath(defaultNvidiaCDIHookPath)),
@@ -479,3 +496,20 @@ func TestCDIHookCreator_isDisabled(t *testing.T) {
 		})
 	}
 }
+
+func TestCDIHookCreatorArgsAreSorted(t *testing.T) {
+	hookCreator := NewHookCreator(WithEnabledHooks(ChmodHook))
+
+	for _, name := range []HookName{CreateSymlinksHook, ChmodHook} {
+		t.Run(string(name), func(t *testing.T) {
+			first := hookCreator.Create(name, "/b::/d", "/a::/c")
+			second := hookCreator.Create(name, "/a::/c", "/b::/d")
+			require.Equal(t, first, second)
+		})
+	}
+
+	t.Run("update-ldcache folders retain their order", func(t *testing.T) {
+		hook := hookCreator.Create(UpdateLDCacheHook, "/b", "/a")
+		require.Equal(t, []string{"nvidia-cdi-hook", "update-ldcache", "--folder", "/b", "--folder", "/a"}, hook.Args)
+	})
+}
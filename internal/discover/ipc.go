@@ -34,6 +34,22 @@ type ipcMounts mounts
 
 // NewIPCDiscoverer creats a discoverer for NVIDIA IPC sockets.
 func NewIPCDiscoverer(logger logger.Interface, driverRoot string) (Discover, error) {
+	return newIPCDiscoverer(logger, driverRoot, true), nil
+}
+
+// NewIPCDiscovererWithoutPersistencedSocket creates a discoverer for NVIDIA
+// IPC sockets that does not include the nvidia-persistenced socket.
+func NewIPCDiscovererWithoutPersistencedSocket(logger logger.Interface, driverRoot string) (Discover, error) {
+	return newIPCDiscoverer(logger, driverRoot, false), nil
+}
+
+func newIPCDiscoverer(logger logger.Interface, driverRoot string, includePersistencedSocket bool) Discover {
+	var socketPaths []string
+	if includePersistencedSocket {
+		socketPaths = append(socketPaths, "/nvidia-persistenced/socket")
+	}
+	socketPaths = append(socketPaths, "/nvidia-fabricmanager/socket")
+
 	sockets := newMounts(
 		logger,
 		lookup.NewFileLocator(
@@ -43,10 +59,7 @@ func NewIPCDiscoverer(logger logger.Interface, driverRoot string) (Discover, err
 			lookup.WithCount(1),
 		),
 		driverRoot,
-		[]string{
-			"/nvidia-persistenced/socket",
-			"/nvidia-fabricmanager/socket",
-		},
+		socketPaths,
 	)
 
 	mps := newMounts(
@@ -62,11 +75,10 @@ func NewIPCDiscoverer(logger logger.Interface, driverRoot string) (Discover, err
 		},
 	)
 
-	d := Merge(
+	return Merge(
 		(*ipcMounts)(sockets),
 		(*ipcMounts)(mps),
 	)
-	return d, nil
 }
 
 // Mounts returns the discovered mounts with IPC-specific mount options.
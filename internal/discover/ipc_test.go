@@ -17,6 +17,9 @@
 package discover
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	testlog "github.com/sirupsen/logrus/hooks/test"
@@ -60,3 +63,86 @@ func TestIPCMounts(t *testing.T) {
 		mounts,
 	)
 }
+
+func TestIPCDiscovererPersistencedSocket(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description               string
+		files                     []string
+		excludePersistencedSocket bool
+		expectedMounts            []Mount
+	}{
+		{
+			description:    "missing socket is skipped",
+			expectedMounts: nil,
+		},
+		{
+			description: "persistenced socket is included",
+			files:       []string{"/var/run/nvidia-persistenced/socket"},
+			expectedMounts: []Mount{
+				{
+					HostPath: "{{ .driverRoot }}/var/run/nvidia-persistenced/socket",
+					Path:     "/var/run/nvidia-persistenced/socket",
+					Options:  ipcMountOptions,
+				},
+			},
+		},
+		{
+			description: "persistenced socket in /run is included",
+			files:       []string{"/run/nvidia-persistenced/socket"},
+			expectedMounts: []Mount{
+				{
+					HostPath: "{{ .driverRoot }}/run/nvidia-persistenced/socket",
+					Path:     "/run/nvidia-persistenced/socket",
+					Options:  ipcMountOptions,
+				},
+			},
+		},
+		{
+			description:               "persistenced socket is excluded",
+			files:                     []string{"/var/run/nvidia-persistenced/socket"},
+			excludePersistencedSocket: true,
+			expectedMounts:            nil,
+		},
+		{
+			description:               "other sockets are included when persistenced socket is excluded",
+			files:                     []string{"/var/run/nvidia-persistenced/socket", "/var/run/nvidia-fabricmanager/socket"},
+			excludePersistencedSocket: true,
+			expectedMounts: []Mount{
+				{
+					HostPath: "{{ .driverRoot }}/var/run/nvidia-fabricmanager/socket",
+					Path:     "/var/run/nvidia-fabricmanager/socket",
+					Options:  ipcMountOptions,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+			for _, f := range tc.files {
+				path := filepath.Join(driverRoot, f)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0600))
+			}
+
+			newIPCDiscoverer := NewIPCDiscoverer
+			if tc.excludePersistencedSocket {
+				newIPCDiscoverer = NewIPCDiscovererWithoutPersistencedSocket
+			}
+			ipcs, err := newIPCDiscoverer(logger, driverRoot)
+			require.NoError(t, err)
+			mounts, err := ipcs.Mounts()
+			require.NoError(t, err)
+
+			var expectedMounts []Mount
+			for _, m := range tc.expectedMounts {
+				m.HostPath = strings.ReplaceAll(m.HostPath, "{{ .driverRoot }}", driverRoot)
+				expectedMounts = append(expectedMounts, m)
+			}
+			require.EqualValues(t, expectedMounts, mounts)
+		})
+	}
+}
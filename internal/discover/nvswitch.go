@@ -19,6 +19,7 @@ package discover
 import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
 )
 
 // NewNvSwitchDiscoverer creates a discoverer for NVSWITCH devices.
@@ -34,3 +35,34 @@ func NewNvSwitchDiscoverer(logger logger.Interface, driver *root.Driver) (Discov
 
 	return devices, nil
 }
+
+// NewNvSwitchFabricDiscoverer creates a discoverer for the NVSWITCH devices and
+// the nvidia-fabricmanager socket. These are required by multi-GPU collectives
+// on NVSwitch-based systems. On systems without NVSwitch devices or where
+// nvidia-fabricmanager is not running, nothing is discovered.
+func NewNvSwitchFabricDiscoverer(logger logger.Interface, driver *root.Driver) (Discover, error) {
+	devices, err := NewNvSwitchDiscoverer(logger, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	socket := newMounts(
+		logger,
+		lookup.NewFileLocator(
+			lookup.WithLogger(logger),
+			lookup.WithRoot(driver.Root),
+			lookup.WithSearchPaths("/run", "/var/run"),
+			lookup.WithCount(1),
+		),
+		driver.Root,
+		[]string{
+			"/nvidia-fabricmanager/socket",
+		},
+	)
+
+	d := Merge(
+		devices,
+		(*ipcMounts)(socket),
+	)
+	return d, nil
+}
@@ -18,6 +18,7 @@
 package discover_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -85,3 +86,75 @@ func TestNewNvSwitchDiscoverer(t *testing.T) {
 		})
 	}
 }
+
+func TestNewNvSwitchFabricDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	defer devices.SetAllForTest()()
+
+	moduleRoot, err := test.GetModuleRoot()
+	require.NoError(t, err)
+
+	lookupRoot := filepath.Join(moduleRoot, "testdata", "lookup")
+
+	testCases := []struct {
+		description     string
+		rootfs          string
+		socket          bool
+		expectedDevices []discover.Device
+		expectedMounts  []discover.Mount
+	}{
+		{
+			description: "non-NVSwitch system discovers nothing",
+			rootfs:      "rootfs-empty",
+		},
+		{
+			description: "device nodes without fabricmanager socket",
+			rootfs:      "rootfs-1",
+			expectedDevices: []discover.Device{
+				{Path: "/dev/nvidia-nvswitchctl", HostPath: "/dev/nvidia-nvswitchctl"},
+				{Path: "/dev/nvidia-nvswitch0", HostPath: "/dev/nvidia-nvswitch0"},
+			},
+		},
+		{
+			description: "device nodes and fabricmanager socket",
+			rootfs:      "rootfs-1",
+			socket:      true,
+			expectedDevices: []discover.Device{
+				{Path: "/dev/nvidia-nvswitchctl", HostPath: "/dev/nvidia-nvswitchctl"},
+				{Path: "/dev/nvidia-nvswitch0", HostPath: "/dev/nvidia-nvswitch0"},
+			},
+			expectedMounts: []discover.Mount{
+				{
+					Path:     "/run/nvidia-fabricmanager/socket",
+					HostPath: "/run/nvidia-fabricmanager/socket",
+					Options:  []string{"nosuid", "nodev", "rbind", "rprivate", "noexec"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			devRoot := filepath.Join(lookupRoot, tc.rootfs)
+			driverRoot := t.TempDir()
+			if tc.socket {
+				socket := filepath.Join(driverRoot, "run", "nvidia-fabricmanager", "socket")
+				require.NoError(t, os.MkdirAll(filepath.Dir(socket), 0755))
+				require.NoError(t, os.WriteFile(socket, nil, 0600))
+			}
+			driver := root.New(root.WithDriverRoot(driverRoot), root.WithDevRoot(devRoot))
+
+			d, err := discover.NewNvSwitchFabricDiscoverer(logger, driver)
+			require.NoError(t, err)
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedDevices, test.StripRoot(devices, devRoot))
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, test.StripRoot(mounts, driverRoot))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+const (
+	openCLICDPath = "OpenCL/vendors/nvidia.icd"
+)
+
+// openCL is a discoverer for the NVIDIA OpenCL ICD file and the library that
+// it references.
+type openCL struct {
+	None
+	logger logger.Interface
+	driver *root.Driver
+}
+
+var _ Discover = (*openCL)(nil)
+
+// NewOpenCLDiscoverer creates a discoverer for the NVIDIA OpenCL ICD file
+// (OpenCL/vendors/nvidia.icd) and the vendor library that it references. If
+// the ICD file is not found, no mounts are returned.
+func NewOpenCLDiscoverer(logger logger.Interface, driver *root.Driver) Discover {
+	o := &openCL{
+		logger: logger,
+		driver: driver,
+	}
+	return WithCache(o)
+}
+
+// Mounts returns the mounts for the OpenCL ICD file and its referenced library.
+func (d *openCL) Mounts() ([]Mount, error) {
+	icd := newMounts(d.logger, d.driver.Configs(), d.driver.Root, []string{openCLICDPath})
+	icdMounts, err := icd.Mounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OpenCL ICD file: %w", err)
+	}
+	if len(icdMounts) == 0 {
+		d.logger.Debugf("No OpenCL ICD file found")
+		return nil, nil
+	}
+
+	library, err := getOpenCLICDLibrary(icdMounts[0].HostPath)
+	if err != nil {
+		d.logger.Warningf("Failed to read OpenCL ICD file %v: %v", icdMounts[0].HostPath, err)
+		return icdMounts, nil
+	}
+
+	var locator lookup.Locator = d.driver.Libraries()
+	if filepath.IsAbs(library) {
+		locator = d.driver.Files()
+	}
+	libraryMounts, err := newMounts(d.logger, locator, d.driver.Root, []string{library}).Mounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OpenCL library %v: %w", library, err)
+	}
+
+	return append(icdMounts, libraryMounts...), nil
+}
+
+// getOpenCLICDLibrary returns the library referenced by the specified OpenCL
+// ICD file. This is the first non-empty line in the file.
+func getOpenCLICDLibrary(icdFilePath string) (string, error) {
+	icdFile, err := os.Open(icdFilePath)
+	if err != nil {
+		return "", err
+	}
+	defer icdFile.Close()
+
+	scanner := bufio.NewScanner(icdFile)
+	for scanner.Scan() {
+		if library := strings.TrimSpace(scanner.Text()); library != "" {
+			return library, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no library specified")
+}
@@ -0,0 +1,124 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestNewOpenCLDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	mountOptions := []string{"ro", "nosuid", "nodev", "rbind", "rprivate"}
+
+	testCases := []struct {
+		description    string
+		icdContents    *string
+		expectedMounts []discover.Mount
+	}{
+		{
+			description: "no ICD file returns no mounts",
+		},
+		{
+			description: "ICD file and library are mounted",
+			icdContents: ptr("libnvidia-opencl.so.1\n"),
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/etc/OpenCL/vendors/nvidia.icd",
+					Path:     "/etc/OpenCL/vendors/nvidia.icd",
+					Options:  mountOptions,
+				},
+				{
+					HostPath: "/lib/x86_64-linux-gnu/libnvidia-opencl.so.999.88.77",
+					Path:     "/lib/x86_64-linux-gnu/libnvidia-opencl.so.999.88.77",
+					Options:  mountOptions,
+				},
+			},
+		},
+		{
+			description: "missing library only mounts ICD file",
+			icdContents: ptr("libnvidia-missing.so.1\n"),
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/etc/OpenCL/vendors/nvidia.icd",
+					Path:     "/etc/OpenCL/vendors/nvidia.icd",
+					Options:  mountOptions,
+				},
+			},
+		},
+		{
+			description: "empty ICD file only mounts ICD file",
+			icdContents: ptr(""),
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/etc/OpenCL/vendors/nvidia.icd",
+					Path:     "/etc/OpenCL/vendors/nvidia.icd",
+					Options:  mountOptions,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+
+			libDir := filepath.Join(driverRoot, "lib/x86_64-linux-gnu")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(libDir, "libnvidia-opencl.so.999.88.77"), nil, 0600))
+			require.NoError(t, os.Symlink("libnvidia-opencl.so.999.88.77", filepath.Join(libDir, "libnvidia-opencl.so.1")))
+
+			if tc.icdContents != nil {
+				icdDir := filepath.Join(driverRoot, "etc/OpenCL/vendors")
+				require.NoError(t, os.MkdirAll(icdDir, 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(icdDir, "nvidia.icd"), []byte(*tc.icdContents), 0600))
+			}
+
+			driver := root.New(
+				root.WithDriverRoot(driverRoot),
+				root.WithLibrarySearchPaths(libDir),
+			)
+			d := discover.NewOpenCLDiscoverer(logger, driver)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+
+			require.EqualValues(t, tc.expectedMounts, test.StripRoot(mounts, driverRoot))
+
+			devices, err := d.Devices()
+			require.NoError(t, err)
+			require.Empty(t, devices)
+
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.Empty(t, hooks)
+		})
+	}
+}
+
+func ptr[T any](x T) *T {
+	return &x
+}
@@ -0,0 +1,67 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+// NewVGPUGuestDiscoverer creates a discoverer for the files that are specific
+// to the GRID guest driver that is installed in vGPU virtual machines. These
+// include the nvidia-gridd licensing daemon, its configuration, and the
+// folder containing its runtime socket.
+func NewVGPUGuestDiscoverer(logger logger.Interface, driver *root.Driver) Discover {
+	binaries := NewMounts(
+		logger,
+		lookup.NewExecutableLocator(logger, driver.Root),
+		driver.Root,
+		[]string{
+			"nvidia-gridd",
+		},
+	)
+
+	configs := NewMounts(
+		logger,
+		driver.Configs(),
+		driver.Root,
+		[]string{
+			"nvidia/gridd.conf",
+		},
+	)
+
+	sockets := newMounts(
+		logger,
+		lookup.NewDirectoryLocator(
+			lookup.WithLogger(logger),
+			lookup.WithRoot(driver.Root),
+			lookup.WithSearchPaths("/run", "/var/run"),
+			lookup.WithCount(1),
+		),
+		driver.Root,
+		[]string{
+			"/nvidia-gridd",
+		},
+	)
+
+	return Merge(
+		binaries,
+		configs,
+		(*ipcMounts)(sockets),
+	)
+}
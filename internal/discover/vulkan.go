@@ -0,0 +1,252 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+const (
+	// vulkanManifestContainerRoot is the folder in the container under which
+	// the Vulkan manifests are mounted.
+	vulkanManifestContainerRoot = "/etc"
+)
+
+// vulkan is a discoverer for the NVIDIA Vulkan ICD and layer manifests and the
+// libraries that these reference.
+type vulkan struct {
+	None
+	logger     logger.Interface
+	driver     *root.Driver
+	rewriteDir string
+}
+
+var _ Discover = (*vulkan)(nil)
+
+// NewVulkanDiscoverer creates a discoverer for the NVIDIA Vulkan ICD and layer
+// manifests and the libraries that these reference. The manifests are mounted
+// under /etc/vulkan in the container.
+//
+// For the manifests we search the standard driver config paths as well as
+// the driver root itself. This allows us to support GKE installations where
+// the vulkan ICD files are at {{ .driverRoot }}/vulkan instead of in
+// /etc/vulkan.
+//
+// If the library_path of a manifest refers to a path that is not valid in the
+// container, for example a path relative to the location of the manifest on
+// the host or a path that includes the driver root, a copy of the manifest
+// with the library_path rewritten to the path in the container is created in
+// the specified rewriteDir and mounted instead. If rewriteDir is empty, the
+// manifest is mounted as is. If no manifests are found, no mounts are
+// returned.
+func NewVulkanDiscoverer(logger logger.Interface, driver *root.Driver, rewriteDir string) Discover {
+	d := &vulkan{
+		logger:     logger,
+		driver:     driver,
+		rewriteDir: rewriteDir,
+	}
+	return WithCache(d)
+}
+
+// getVulkanManifests returns the paths of the Vulkan manifests relative to
+// the config search paths.
+func getVulkanManifests() []string {
+	manifests := []string{
+		"vulkan/icd.d/nvidia_icd.json",
+		"vulkan/icd.d/nvidia_layers.json",
+		"vulkan/implicit_layer.d/nvidia_layers.json",
+	}
+	// For some RPM-based driver packages, the vulkan ICD files are installed to
+	// /usr/share/vulkan/icd.d/nvidia_icd.%{_target_cpu}.json
+	// We also include this in the list of candidates for the ICD file.
+	switch runtime.GOARCH {
+	case "amd64":
+		manifests = append(manifests, "vulkan/icd.d/nvidia_icd.x86_64.json")
+	case "arm64":
+		manifests = append(manifests, "vulkan/icd.d/nvidia_icd.aarch64.json")
+	}
+	return manifests
+}
+
+// Mounts returns the mounts for the Vulkan manifests and the libraries that
+// they reference.
+func (d *vulkan) Mounts() ([]Mount, error) {
+	locator := lookup.First(d.driver.Configs(), d.driver.Files())
+
+	seen := make(map[string]bool)
+	var mounts []Mount
+	var libraries []string
+	for _, target := range getVulkanManifests() {
+		candidates, err := locator.Locate(target)
+		if err != nil || len(candidates) == 0 {
+			d.logger.Debugf("Vulkan manifest %v not found", target)
+			continue
+		}
+		hostPath := candidates[0]
+		if seen[hostPath] {
+			d.logger.Debugf("Skipping duplicate mount %v", hostPath)
+			continue
+		}
+		seen[hostPath] = true
+
+		containerPath := filepath.Join(vulkanManifestContainerRoot, target)
+		mountPath, manifestLibraries := d.rewriteManifest(hostPath, containerPath, target)
+		d.logger.Infof("Selecting %v as %v", mountPath, containerPath)
+
+		mounts = append(mounts, Mount{
+			HostPath: mountPath,
+			Path:     containerPath,
+			Options: []string{
+				"ro",
+				"nosuid",
+				"nodev",
+				"rbind",
+				"rprivate",
+			},
+		})
+		libraries = append(libraries, manifestLibraries...)
+	}
+	if len(mounts) == 0 {
+		d.logger.Debugf("No Vulkan manifests found")
+		return nil, nil
+	}
+
+	seen = make(map[string]bool)
+	for _, library := range libraries {
+		if seen[library] {
+			continue
+		}
+		seen[library] = true
+
+		var locator lookup.Locator = d.driver.Libraries()
+		if filepath.IsAbs(library) {
+			locator = d.driver.Files()
+		}
+		libraryMounts, err := newMounts(d.logger, locator, d.driver.Root, []string{library}).Mounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover Vulkan library %v: %w", library, err)
+		}
+		mounts = append(mounts, libraryMounts...)
+	}
+
+	return mounts, nil
+}
+
+// rewriteManifest returns the path of the manifest to mount at the specified
+// container path and the libraries referenced by the manifest. If the
+// library_path of the manifest needs to be rewritten, the path to the
+// rewritten copy is returned.
+func (d *vulkan) rewriteManifest(hostPath string, containerPath string, target string) (string, []string) {
+	contents, err := os.ReadFile(hostPath)
+	if err != nil {
+		d.logger.Warningf("Failed to read Vulkan manifest %v: %v", hostPath, err)
+		return hostPath, nil
+	}
+	var manifest map[string]any
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		d.logger.Warningf("Failed to parse Vulkan manifest %v: %v", hostPath, err)
+		return hostPath, nil
+	}
+
+	// An ICD manifest defines a single ICD, whereas a layer manifest
+	// defines either a single layer or a list of layers.
+	var entries []map[string]any
+	for _, key := range []string{"ICD", "layer"} {
+		if entry, ok := manifest[key].(map[string]any); ok {
+			entries = append(entries, entry)
+		}
+	}
+	if layers, ok := manifest["layers"].([]any); ok {
+		for _, layer := range layers {
+			if entry, ok := layer.(map[string]any); ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	var libraries []string
+	var rewrite bool
+	for _, entry := range entries {
+		libraryPath, ok := entry["library_path"].(string)
+		if !ok || libraryPath == "" {
+			continue
+		}
+		containerLibraryPath := d.getContainerLibraryPath(libraryPath, filepath.Dir(hostPath), filepath.Dir(containerPath))
+		libraries = append(libraries, containerLibraryPath)
+		if containerLibraryPath != libraryPath {
+			entry["library_path"] = containerLibraryPath
+			rewrite = true
+		}
+	}
+	if !rewrite {
+		return hostPath, libraries
+	}
+
+	if d.rewriteDir == "" {
+		d.logger.Warningf("The library paths in Vulkan manifest %v are not valid in the container; no directory for rewritten manifests is configured", hostPath)
+		return hostPath, libraries
+	}
+	rewrittenPath := filepath.Join(d.rewriteDir, target)
+	if err := writeVulkanManifest(rewrittenPath, manifest); err != nil {
+		d.logger.Warningf("Failed to rewrite Vulkan manifest %v: %v", hostPath, err)
+		return hostPath, libraries
+	}
+	d.logger.Infof("Rewrote the library paths in Vulkan manifest %v to %v", hostPath, rewrittenPath)
+	return rewrittenPath, libraries
+}
+
+// getContainerLibraryPath returns the library_path that refers to the
+// specified library in the container. Library names without a path are
+// resolved by the dynamic linker and are returned as is. Relative paths are
+// relative to the folder containing the manifest and are only returned as is
+// if they resolve to the same library in the container.
+func (d *vulkan) getContainerLibraryPath(libraryPath string, hostDir string, containerDir string) string {
+	if !strings.Contains(libraryPath, "/") {
+		return libraryPath
+	}
+	hostLibraryPath := libraryPath
+	if !filepath.IsAbs(libraryPath) {
+		hostLibraryPath = filepath.Join(hostDir, libraryPath)
+	}
+	containerLibraryPath := d.driver.RelativeToRoot(hostLibraryPath)
+	if !filepath.IsAbs(libraryPath) && filepath.Join(containerDir, libraryPath) == containerLibraryPath {
+		return libraryPath
+	}
+	return containerLibraryPath
+}
+
+// writeVulkanManifest writes the specified manifest to the specified path
+// creating the parent folder if required.
+func writeVulkanManifest(path string, manifest map[string]any) error {
+	contents, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(contents, '\n'), 0644)
+}
@@ -0,0 +1,172 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package discover_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
+)
+
+func TestNewVulkanDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	mountOptions := []string{"ro", "nosuid", "nodev", "rbind", "rprivate"}
+
+	relativeLayer := `{
+    "file_format_version": "1.0.0",
+    "layer": {
+        "name": "VK_LAYER_NV_optimus",
+        "library_path": "../../../lib/x86_64-linux-gnu/libGLX_nvidia.so.0"
+    }
+}`
+
+	testCases := []struct {
+		description      string
+		manifests        map[string]string
+		noRewriteDir     bool
+		expectedMounts   []discover.Mount
+		expectedRewrites map[string]string
+	}{
+		{
+			description: "no manifests returns no mounts",
+		},
+		{
+			description: "ICD manifest and library are mounted",
+			manifests: map[string]string{
+				"etc/vulkan/icd.d/nvidia_icd.json": `{"file_format_version": "1.0.1", "ICD": {"library_path": "libGLX_nvidia.so.0"}}`,
+			},
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/etc/vulkan/icd.d/nvidia_icd.json",
+					Path:     "/etc/vulkan/icd.d/nvidia_icd.json",
+					Options:  mountOptions,
+				},
+				{
+					HostPath: "/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.999.88.77",
+					Path:     "/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.999.88.77",
+					Options:  mountOptions,
+				},
+			},
+		},
+		{
+			description: "relative library path is rewritten",
+			manifests: map[string]string{
+				"usr/share/vulkan/implicit_layer.d/nvidia_layers.json": relativeLayer,
+			},
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/rewritten/vulkan/implicit_layer.d/nvidia_layers.json",
+					Path:     "/etc/vulkan/implicit_layer.d/nvidia_layers.json",
+					Options:  mountOptions,
+				},
+				{
+					HostPath: "/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.0",
+					Path:     "/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.0",
+					Options:  mountOptions,
+				},
+			},
+			expectedRewrites: map[string]string{
+				"rewritten/vulkan/implicit_layer.d/nvidia_layers.json": `{
+    "file_format_version": "1.0.0",
+    "layer": {
+        "library_path": "/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.0",
+        "name": "VK_LAYER_NV_optimus"
+    }
+}
+`,
+			},
+		},
+		{
+			description: "manifest is mounted as is without a rewrite directory",
+			manifests: map[string]string{
+				"usr/share/vulkan/implicit_layer.d/nvidia_layers.json": relativeLayer,
+			},
+			noRewriteDir: true,
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/usr/share/vulkan/implicit_layer.d/nvidia_layers.json",
+					Path:     "/etc/vulkan/implicit_layer.d/nvidia_layers.json",
+					Options:  mountOptions,
+				},
+				{
+					HostPath: "/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.0",
+					Path:     "/usr/lib/x86_64-linux-gnu/libGLX_nvidia.so.0",
+					Options:  mountOptions,
+				},
+			},
+		},
+		{
+			description: "invalid manifest is mounted as is",
+			manifests: map[string]string{
+				"etc/vulkan/icd.d/nvidia_icd.json": `not json`,
+			},
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: "/etc/vulkan/icd.d/nvidia_icd.json",
+					Path:     "/etc/vulkan/icd.d/nvidia_icd.json",
+					Options:  mountOptions,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			driverRoot := t.TempDir()
+
+			libDir := filepath.Join(driverRoot, "usr/lib/x86_64-linux-gnu")
+			require.NoError(t, os.MkdirAll(libDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(libDir, "libGLX_nvidia.so.999.88.77"), nil, 0600))
+			require.NoError(t, os.Symlink("libGLX_nvidia.so.999.88.77", filepath.Join(libDir, "libGLX_nvidia.so.0")))
+
+			for path, contents := range tc.manifests {
+				path = filepath.Join(driverRoot, path)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+			}
+
+			rewriteDir := filepath.Join(driverRoot, "rewritten")
+			if tc.noRewriteDir {
+				rewriteDir = ""
+			}
+
+			driver := root.New(
+				root.WithDriverRoot(driverRoot),
+				root.WithLibrarySearchPaths(libDir),
+			)
+			d := discover.NewVulkanDiscoverer(logger, driver, rewriteDir)
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, test.StripRoot(mounts, driverRoot))
+
+			for path, expected := range tc.expectedRewrites {
+				contents, err := os.ReadFile(filepath.Join(driverRoot, path))
+				require.NoError(t, err)
+				require.Equal(t, expected, string(contents))
+			}
+		})
+	}
+}
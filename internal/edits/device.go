@@ -29,7 +29,8 @@ import (
 
 type device struct {
 	discover.Device
-	noAdditionalGIDs bool
+	noAdditionalGIDs  bool
+	cgroupDeviceRules bool
 }
 
 // toEdits converts a discovered device to CDI Container Edits.
@@ -87,17 +88,17 @@ func (d device) fromPathOrDefault() *specs.DeviceNode {
 	//
 	// * dn.Rule.Allow: This has no equivalent in the CDI spec and is used for
 	//					specifying cgroup rules in a container.
-	// * dn.Rule.Type:  This could be translated to the DeviceNode.Type, but is
-	//					not done. In the toolkit we only consider char devices
-	//					(Type = 'c') and these are the default for device nodes
-	//					in OCI compliant runtimes.
+	// * dn.Rule.Type:  This is only translated to the DeviceNode.Type if
+	//					cgroup device rules are requested. In the toolkit we only
+	//					consider char devices (Type = 'c') and these are the
+	//					default for device nodes in OCI compliant runtimes.
 	// * dn.UID:		This is ignored so as to allow the UID of the container
 	//					user to be applied when making modifications to the OCI
 	//					runtime specification. Note that for most NVIDIA devices
 	//					this would be 0 and as such the target UID pointer will
 	//					remain `nil`.
 	//					See: https://github.com/cncf-tags/container-device-interface/blob/e2632194760242fc74a30c3803107f9c1ba5718b/pkg/cdi/container-edits.go#L96-L100
-	return &specs.DeviceNode{
+	deviceNode := &specs.DeviceNode{
 		HostPath:    d.HostPath,
 		Path:        d.Path,
 		Major:       dn.Major,
@@ -106,6 +107,10 @@ func (d device) fromPathOrDefault() *specs.DeviceNode {
 		Permissions: string(dn.Permissions),
 		GID:         ptrIfNonZero(dn.Gid),
 	}
+	if d.cgroupDeviceRules && dn.Type != 0 {
+		deviceNode.Type = string(rune(dn.Type))
+	}
+	return deviceNode
 }
 
 func ptrIfNonZero[T uint32 | os.FileMode](id T) *T {
@@ -109,10 +109,11 @@ func TestDeviceToEdits(t *testing.T) {
 
 func TestDeviceToSpec(t *testing.T) {
 	testCases := []struct {
-		description string
-		device      discover.Device
-		deviceslib  devices.Interface
-		expected    *specs.DeviceNode
+		description       string
+		device            discover.Device
+		deviceslib        devices.Interface
+		cgroupDeviceRules bool
+		expected          *specs.DeviceNode
 	}{
 		{
 			device: discover.Device{
@@ -207,10 +208,47 @@ func TestDeviceToSpec(t *testing.T) {
 				GID:         ptrIfNonZero[uint32](44),
 			},
 		},
+		{
+			description: "device with cgroup device rules",
+			device: discover.Device{
+				Path: "/foo",
+			},
+			deviceslib: &devices.InterfaceMock{
+				DeviceFromPathFunc: func(path, permissions string) (*devices.Device, error) {
+					cd := &config.Device{
+						Rule: config.Rule{
+							Type:        config.CharDevice,
+							Major:       100,
+							Minor:       200,
+							Permissions: config.Permissions(permissions),
+						},
+					}
+					return (*devices.Device)(cd), nil
+				},
+			},
+			cgroupDeviceRules: true,
+			expected: &specs.DeviceNode{
+				Path:        "/foo",
+				Type:        "c",
+				Permissions: "rwm",
+				Major:       100,
+				Minor:       200,
+			},
+		},
+		{
+			description: "cgroup device rules require device properties",
+			device: discover.Device{
+				Path: "/foo",
+			},
+			cgroupDeviceRules: true,
+			expected: &specs.DeviceNode{
+				Path: "/foo",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
-		f := factory{}
+		f := factory{cgroupDeviceRules: tc.cgroupDeviceRules}
 		t.Run(tc.description, func(t *testing.T) {
 			defer devices.SetInterfaceForTests(tc.deviceslib)()
 			spec, err := f.device(tc.device).toSpec()
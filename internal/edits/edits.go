@@ -42,6 +42,8 @@ type empty string
 type factory struct {
 	logger                         logger.Interface
 	noAdditionalGIDsForDeviceNodes bool
+	cgroupDeviceRules              bool
+	hookPriorities                 HookPriorities
 }
 
 var _ Factory = (*empty)(nil)
@@ -51,7 +53,8 @@ type Option func(*factory)
 
 func NewFactory(opts ...Option) Factory {
 	f := &factory{
-		logger: &logger.NullLogger{},
+		logger:         &logger.NullLogger{},
+		hookPriorities: DefaultHookPriorities(),
 	}
 	for _, opt := range opts {
 		opt(f)
@@ -63,7 +66,13 @@ func (f *factory) New() *cdi.ContainerEdits {
 	return EmptyFactory.New()
 }
 
+// FromDiscoverer creates CDI container edits for the entities discovered by
+// the specified discoverer. If no entities are discovered, or if the
+// discoverer is nil, empty (but non-nil) container edits are returned.
 func (f *factory) FromDiscoverer(d discover.Discover) (*cdi.ContainerEdits, error) {
+	if d == nil {
+		return f.New(), nil
+	}
 	devices, err := d.Devices()
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover devices: %v", err)
@@ -104,14 +113,16 @@ func (f *factory) FromDiscoverer(d discover.Discover) (*cdi.ContainerEdits, erro
 	for _, h := range hooks {
 		c.Append(hook(h).toEdits())
 	}
+	SortHooks(c, f.hookPriorities)
 
 	return c, nil
 }
 
 func (f *factory) device(d discover.Device) *device {
 	return &device{
-		Device:           d,
-		noAdditionalGIDs: f.noAdditionalGIDsForDeviceNodes,
+		Device:            d,
+		noAdditionalGIDs:  f.noAdditionalGIDsForDeviceNodes,
+		cgroupDeviceRules: f.cgroupDeviceRules,
 	}
 }
 
@@ -139,3 +150,13 @@ func WithNoAdditionalGIDsForDeviceNodes(noAdditionalGIDsForDeviceNodes bool) Opt
 		f.noAdditionalGIDsForDeviceNodes = noAdditionalGIDsForDeviceNodes
 	}
 }
+
+// WithCgroupDeviceRules sets whether the device type is included for device
+// nodes. Along with the major and minor numbers and the permissions, this
+// fully specifies the cgroup device rule for the device node, meaning that
+// the container runtime does not need to query the device node on the host.
+func WithCgroupDeviceRules(cgroupDeviceRules bool) Option {
+	return func(f *factory) {
+		f.cgroupDeviceRules = cgroupDeviceRules
+	}
+}
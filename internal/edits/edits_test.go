@@ -19,9 +19,12 @@ package edits
 import (
 	"testing"
 
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
 )
 
 // TODO: This test doesn't actually do anything.
@@ -32,3 +35,151 @@ func TestFromDiscovererAllowsMountsToIterate(t *testing.T) {
 
 	require.Empty(t, edits.Mounts)
 }
+
+func TestFromDiscovererHookOrder(t *testing.T) {
+	hookCreator := discover.NewHookCreator()
+
+	testCases := []struct {
+		description   string
+		options       []Option
+		hooks         []*discover.Hook
+		expectedHooks []discover.HookName
+	}{
+		{
+			description: "ldcache hook is run after symlinks and cuda-compat hooks",
+			hooks: []*discover.Hook{
+				hookCreator.Create(discover.UpdateLDCacheHook),
+				hookCreator.Create(discover.CreateSymlinksHook, "foo::bar"),
+				hookCreator.Create(discover.EnableCudaCompatHook),
+			},
+			expectedHooks: []discover.HookName{
+				discover.CreateSymlinksHook,
+				discover.EnableCudaCompatHook,
+				discover.UpdateLDCacheHook,
+			},
+		},
+		{
+			description: "default order is preserved",
+			hooks: []*discover.Hook{
+				hookCreator.Create(discover.CreateSymlinksHook, "foo::bar"),
+				hookCreator.Create(discover.EnableCudaCompatHook),
+				hookCreator.Create(discover.UpdateLDCacheHook),
+				hookCreator.Create(discover.DisableDeviceNodeModificationHook),
+				hookCreator.Create(discover.ApplicationProfileHook),
+			},
+			expectedHooks: []discover.HookName{
+				discover.CreateSymlinksHook,
+				discover.EnableCudaCompatHook,
+				discover.UpdateLDCacheHook,
+				discover.DisableDeviceNodeModificationHook,
+				discover.ApplicationProfileHook,
+			},
+		},
+		{
+			description: "priorities can be overridden",
+			options: []Option{
+				WithHookPriorities(HookPriorities{discover.CreateSymlinksHook: 15}),
+			},
+			hooks: []*discover.Hook{
+				hookCreator.Create(discover.CreateSymlinksHook, "foo::bar"),
+				hookCreator.Create(discover.UpdateLDCacheHook),
+				hookCreator.Create(discover.DisableDeviceNodeModificationHook),
+			},
+			expectedHooks: []discover.HookName{
+				discover.UpdateLDCacheHook,
+				discover.CreateSymlinksHook,
+				discover.DisableDeviceNodeModificationHook,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var discoverers []discover.Discover
+			for _, h := range tc.hooks {
+				discoverers = append(discoverers, h)
+			}
+
+			edits, err := NewFactory(tc.options...).FromDiscoverer(discover.Merge(discoverers...))
+			require.NoError(t, err)
+
+			var hookNames []discover.HookName
+			for _, h := range edits.Hooks {
+				hookNames = append(hookNames, getHookName(h))
+			}
+			require.EqualValues(t, tc.expectedHooks, hookNames)
+		})
+	}
+}
+
+func TestSortHooksAfterAppend(t *testing.T) {
+	hookCreator := discover.NewHookCreator(discover.WithNVIDIACDIHookPath("/usr/bin/nvidia-ctk"))
+	factory := NewFactory()
+
+	common, err := factory.FromDiscoverer(hookCreator.Create(discover.UpdateLDCacheHook))
+	require.NoError(t, err)
+	additional, err := factory.FromDiscoverer(hookCreator.Create(discover.CreateSymlinksHook, "foo::bar"))
+	require.NoError(t, err)
+
+	common.Append(additional)
+	SortHooks(common, nil)
+
+	require.Len(t, common.Hooks, 2)
+	require.EqualValues(t, discover.CreateSymlinksHook, getHookName(common.Hooks[0]))
+	require.EqualValues(t, discover.UpdateLDCacheHook, getHookName(common.Hooks[1]))
+}
+
+func TestFromDiscovererWithNoEntities(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	driverRoot := t.TempDir()
+
+	ipcs, err := discover.NewIPCDiscoverer(logger, driverRoot)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description string
+		discoverer  discover.Discover
+	}{
+		{
+			description: "nil discoverer",
+		},
+		{
+			description: "none discoverer",
+			discoverer:  discover.None{},
+		},
+		{
+			description: "empty merged discoverer",
+			discoverer:  discover.Merge(nil, discover.None{}),
+		},
+		{
+			description: "IPC discoverer without sockets",
+			discoverer:  ipcs,
+		},
+		{
+			description: "mounts discoverer without matching files",
+			discoverer: discover.NewMounts(
+				logger,
+				lookup.NewFileLocator(lookup.WithRoot(driverRoot)),
+				driverRoot,
+				[]string{"/lib/firmware/nvidia/*/gsp*.bin"},
+			),
+		},
+		{
+			description: "ldcache update hook without libraries",
+			discoverer: func() discover.Discover {
+				d, _ := discover.NewLDCacheUpdateHook(logger, discover.None{}, discover.NewHookCreator())
+				return d
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			edits, err := NewFactory().FromDiscoverer(tc.discoverer)
+			require.NoError(t, err)
+			require.NotNil(t, edits)
+			require.NotNil(t, edits.ContainerEdits)
+			require.EqualValues(t, &specs.ContainerEdits{}, edits.ContainerEdits)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package edits
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// HookPriorities maps hook names to the priority used when ordering hooks.
+// Hooks with a lower priority are run first. Hooks that are not included
+// have a priority of 0 and hooks with the same priority retain the order in
+// which they were discovered.
+type HookPriorities map[discover.HookName]int
+
+// DefaultHookPriorities returns the default hook priorities.
+// The update-ldcache hook is ordered after all hooks that may create files or
+// symlinks in the container so that these are included in the ldcache.
+func DefaultHookPriorities() HookPriorities {
+	return HookPriorities{
+		discover.UpdateLDCacheHook:                 10,
+		discover.DisableDeviceNodeModificationHook: 20,
+		discover.ApplicationProfileHook:            20,
+	}
+}
+
+// WithHookPriorities overrides the priorities used to order hooks in the
+// generated container edits. Priorities for hooks that are not specified are
+// taken from the defaults.
+func WithHookPriorities(priorities HookPriorities) Option {
+	return func(f *factory) {
+		maps.Copy(f.hookPriorities, priorities)
+	}
+}
+
+// SortHooks performs a stable sort of the hooks in the specified container
+// edits according to the supplied priorities. If no priorities are specified
+// the default priorities are used. This is required to ensure a consistent
+// hook order after container edits have been combined using Append.
+func SortHooks(c *cdi.ContainerEdits, priorities HookPriorities) {
+	if c == nil || c.ContainerEdits == nil {
+		return
+	}
+	if priorities == nil {
+		priorities = DefaultHookPriorities()
+	}
+	slices.SortStableFunc(c.Hooks, func(a, b *specs.Hook) int {
+		return priorities.of(a) - priorities.of(b)
+	})
+}
+
+// of returns the priority of the specified hook.
+func (p HookPriorities) of(h *specs.Hook) int {
+	return p[getHookName(h)]
+}
+
+// getHookName returns the name of an nvidia-cdi-hook (or nvidia-ctk hook)
+// from its arguments. An empty name is returned for other hooks.
+func getHookName(h *specs.Hook) discover.HookName {
+	if h == nil || len(h.Args) < 2 {
+		return ""
+	}
+	switch filepath.Base(h.Args[0]) {
+	case "nvidia-cdi-hook":
+		return discover.HookName(h.Args[1])
+	case "nvidia-ctk":
+		if h.Args[1] == "hook" && len(h.Args) > 2 {
+			return discover.HookName(h.Args[2])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package engines
+
+import (
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// SupportsNVENC returns whether the specified device has an NVENC engine.
+// This is the case if the device reports a non-zero H.264 encoder capacity.
+func SupportsNVENC(d nvml.Device) bool {
+	capacity, ret := d.GetEncoderCapacity(nvml.ENCODER_QUERY_H264)
+	return ret == nvml.SUCCESS && capacity > 0
+}
+
+// SupportsNVDEC returns whether the specified device has an NVDEC engine.
+// Devices without an NVDEC engine either do not support querying the decoder
+// utilization or do not sample it, reporting a sampling period of zero.
+func SupportsNVDEC(d nvml.Device) bool {
+	_, samplingPeriodUs, ret := d.GetDecoderUtilization()
+	return ret == nvml.SUCCESS && samplingPeriodUs > 0
+}
@@ -0,0 +1,151 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package errorformat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The following formats are supported for errors returned by the CLI.
+const (
+	// FormatText indicates that errors are logged as free text.
+	FormatText = "text"
+	// FormatJSON indicates that errors are written as JSON objects.
+	FormatJSON = "json"
+)
+
+// DevicesComponent is the component reported for failures to generate the CDI
+// device specs for a particular device.
+const DevicesComponent = "devices"
+
+// A Report is the structured representation of an error.
+type Report struct {
+	// Code is the exit code associated with the error.
+	Code int `json:"code"`
+	// Component is the component that failed, if known.
+	Component string `json:"component,omitempty"`
+	// Device is the ID of the device that the error applies to, if any.
+	Device string `json:"device,omitempty"`
+	// Message is the error message.
+	Message string `json:"message"`
+}
+
+// A deviceError is an error that applies to a single device such as the
+// nvcdi.DeviceError.
+type deviceError interface {
+	error
+	DeviceID() string
+}
+
+// A partialDiscoveryError is an error that includes the failures for
+// individual devices such as the nvcdi.PartialDiscoveryError.
+type partialDiscoveryError interface {
+	error
+	DeviceErrors() []error
+}
+
+// A componentError associates the component that failed with an error.
+type componentError struct {
+	component string
+	err       error
+}
+
+// WithComponent associates the specified component with an error so that it
+// is included when the error is reported. If the error is nil, nil is
+// returned.
+func WithComponent(component string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &componentError{component: component, err: err}
+}
+
+func (e *componentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *componentError) Unwrap() error {
+	return e.err
+}
+
+// IsValid checks whether the specified error format is supported.
+func IsValid(format string) bool {
+	return format == FormatText || format == FormatJSON
+}
+
+// NewReports returns the structured reports for the specified error. If the
+// error includes failures for individual devices, a report is returned for
+// each of these devices. Otherwise a single report is returned.
+func NewReports(code int, err error) []Report {
+	var partialErr partialDiscoveryError
+	if errors.As(err, &partialErr) && len(partialErr.DeviceErrors()) > 0 {
+		var reports []Report
+		for _, err := range partialErr.DeviceErrors() {
+			reports = append(reports, newDeviceReport(code, err))
+		}
+		return reports
+	}
+
+	report := Report{
+		Code:    code,
+		Message: err.Error(),
+	}
+	var ce *componentError
+	if errors.As(err, &ce) {
+		report.Component = ce.component
+	}
+	var deviceErr deviceError
+	if errors.As(err, &deviceErr) {
+		report.Component = DevicesComponent
+		report.Device = deviceErr.DeviceID()
+	}
+	return []Report{report}
+}
+
+// newDeviceReport returns the report for the failure for a single device. If
+// the device is known, this is reported separately and the message only
+// includes the underlying error.
+func newDeviceReport(code int, err error) Report {
+	report := Report{
+		Code:      code,
+		Component: DevicesComponent,
+		Message:   err.Error(),
+	}
+	var deviceErr deviceError
+	if errors.As(err, &deviceErr) {
+		report.Device = deviceErr.DeviceID()
+		if unwrapped := errors.Unwrap(deviceErr); unwrapped != nil {
+			report.Message = unwrapped.Error()
+		}
+	}
+	return report
+}
+
+// WriteJSON writes the reports for the specified error to the writer as JSON
+// objects, one per line.
+func WriteJSON(w io.Writer, code int, err error) error {
+	encoder := json.NewEncoder(w)
+	for _, report := range NewReports(code, err) {
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to write error report: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package errorformat
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+func TestNewReports(t *testing.T) {
+	testCases := []struct {
+		description     string
+		code            int
+		err             error
+		expectedReports []Report
+	}{
+		{
+			description: "untyped error",
+			code:        1,
+			err:         errors.New("something failed"),
+			expectedReports: []Report{
+				{Code: 1, Message: "something failed"},
+			},
+		},
+		{
+			description: "component is reported",
+			code:        2,
+			err:         fmt.Errorf("failed to generate CDI spec: %w", WithComponent("common-edits", errors.New("no libraries"))),
+			expectedReports: []Report{
+				{Code: 2, Component: "common-edits", Message: "failed to generate CDI spec: no libraries"},
+			},
+		},
+		{
+			description: "device error is reported",
+			code:        2,
+			err:         fmt.Errorf("failed: %w", nvcdi.DeviceError{ID: "1", Err: errors.New("ERROR_GPU_IS_LOST")}),
+			expectedReports: []Report{
+				{Code: 2, Component: DevicesComponent, Device: "1", Message: "failed: device 1: ERROR_GPU_IS_LOST"},
+			},
+		},
+		{
+			description: "partial discovery error is reported per device",
+			code:        5,
+			err: fmt.Errorf("generated a partial CDI spec: %w", &nvcdi.PartialDiscoveryError{
+				Errors: []nvcdi.DeviceError{
+					{ID: "1", Err: errors.New("ERROR_GPU_IS_LOST")},
+					{ID: "3:0", Err: errors.New("ERROR_NOT_FOUND")},
+				},
+			}),
+			expectedReports: []Report{
+				{Code: 5, Component: DevicesComponent, Device: "1", Message: "ERROR_GPU_IS_LOST"},
+				{Code: 5, Component: DevicesComponent, Device: "3:0", Message: "ERROR_NOT_FOUND"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.EqualValues(t, tc.expectedReports, NewReports(tc.code, tc.err))
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	err := WithComponent("validation", errors.New("invalid discovery mode: foo"))
+	require.NoError(t, WriteJSON(&buf, 4, err))
+	require.Equal(t, `{"code":4,"component":"validation","message":"invalid discovery mode: foo"}`+"\n", buf.String())
+}
@@ -0,0 +1,71 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package fsutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteAtomic creates the file at the specified path by calling write to
+// create a file with the same name in a temporary directory and then renaming
+// it into place. This ensures that readers never observe a partially written
+// file. The temporary directory is created alongside the specified path, or in
+// tempDir if this is not empty. If tempDir is on a different filesystem, the
+// file is copied into place instead. The temporary directory is removed once
+// complete.
+func WriteAtomic(path string, tempDir string, write func(dir string, filename string) error) (rerr error) {
+	dir, filename := filepath.Split(path)
+	if tempDir != "" {
+		dir = tempDir
+	}
+	// Since the temporary directory does not have a .yaml or .json extension
+	// and spec directories are not scanned recursively, the file is not read
+	// as a CDI spec before it is complete.
+	tmpDir, err := os.MkdirTemp(filepath.Clean(dir), "."+filename+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil && rerr == nil {
+			rerr = fmt.Errorf("failed to remove temporary directory: %w", err)
+		}
+	}()
+
+	if err := write(tmpDir, filename); err != nil {
+		return err
+	}
+
+	if err := Rename(filepath.Join(tmpDir, filename), path); err != nil {
+		return fmt.Errorf("failed to move file into place: %w", err)
+	}
+	return nil
+}
+
+// WriteFileAtomic writes the specified data to the file at the specified path
+// using WriteAtomic. The file is created with the specified permissions
+// regardless of the umask.
+func WriteFileAtomic(path string, tempDir string, data []byte, perm os.FileMode) error {
+	return WriteAtomic(path, tempDir, func(dir string, filename string) error {
+		tmp := filepath.Join(dir, filename)
+		if err := os.WriteFile(tmp, data, perm); err != nil {
+			return err
+		}
+		return os.Chmod(tmp, perm)
+	})
+}
@@ -0,0 +1,92 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package fsutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	testCases := []struct {
+		description string
+		useTempDir  bool
+	}{
+		{
+			description: "temporary directory alongside the file",
+		},
+		{
+			description: "separate temporary directory",
+			useTempDir:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			dir := t.TempDir()
+			var tempDir string
+			if tc.useTempDir {
+				tempDir = t.TempDir()
+			}
+			path := filepath.Join(dir, "nvidia.yaml")
+			require.NoError(t, os.WriteFile(path, []byte("existing"), 0600))
+
+			require.NoError(t, WriteFileAtomic(path, tempDir, []byte("updated"), 0644))
+
+			contents, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, "updated", string(contents))
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			if tempDir != "" {
+				entries, err := os.ReadDir(tempDir)
+				require.NoError(t, err)
+				require.Empty(t, entries)
+			}
+		})
+	}
+}
+
+func TestWriteAtomicFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nvidia.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("existing"), 0600))
+
+	errWrite := errors.New("write failed")
+	err := WriteAtomic(path, "", func(string, string) error {
+		return errWrite
+	})
+	require.ErrorIs(t, err, errWrite)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "existing", string(contents))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
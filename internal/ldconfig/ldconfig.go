@@ -62,6 +62,7 @@ type Ldconfig struct {
 	inRoot                string
 	isDebianLikeHost      bool
 	isDebianLikeContainer bool
+	isMuslContainer       bool
 	noPivotRoot           bool
 	directories           []string
 }
@@ -77,6 +78,10 @@ func NewRunner(id string, ldconfigPath string, containerRoot string, additionala
 		args = append(args, "--is-debian-like-host")
 	}
 
+	if IsMuslContainer(containerRoot) {
+		args = append(args, "--is-musl-container")
+	}
+
 	if noPivotRoot() {
 		args = append(args, "--no-pivot")
 	}
@@ -103,6 +108,8 @@ func NewRunner(id string, ldconfigPath string, containerRoot string, additionala
 //	--is-debian-like-host	Indicates that the host system is debian-like (e.g. Debian, Ubuntu)
 //	                     	as opposed to non-Debian-like (e.g. RHEL, Fedora)
 //	                     	See https://github.com/NVIDIA/nvidia-container-toolkit/pull/1444
+//	--is-musl-container  	Indicates that the container uses musl instead of glibc. The
+//	                     	ldcache is not used by musl and ldconfig is not run.
 //	--no-pivot           	pivot_root should not be used to provide process isolation.
 //
 // The remaining args are folders where soname symlinks need to be created.
@@ -117,6 +124,7 @@ func NewFromArgs(args ...string) (*Ldconfig, error) {
 This allows us to handle the case where there are  differences in behavior
 between the ldconfig from the host (as executed from an update-ldcache hook) and
 ldconfig in the container. Such differences include system search paths.`)
+	isMuslContainer := fs.Bool("is-musl-container", false, "indicates that the container uses musl instead of glibc")
 	noPivot := fs.Bool("no-pivot", false, "don't use pivot_root to perform isolation")
 	if err := fs.Parse(args[1:]); err != nil {
 		return nil, err
@@ -133,6 +141,7 @@ ldconfig in the container. Such differences include system search paths.`)
 		ldconfigPath:     *ldconfigPath,
 		inRoot:           *containerRoot,
 		isDebianLikeHost: *isDebianLikeHost,
+		isMuslContainer:  *isMuslContainer,
 		noPivotRoot:      *noPivot,
 		directories:      fs.Args(),
 	}
@@ -148,6 +157,20 @@ func (l *Ldconfig) UpdateLDCache() error {
 	// `prepareRoot` pivots to the container root, so can now set the container "debian-ness".
 	l.isDebianLikeContainer = isDebianLike()
 
+	// Systems that use musl do not rely on the ldcache to discover libraries.
+	// Instead of running the (glibc) ldconfig, we only update the musl .path
+	// file.
+	if l.isMuslContainer {
+		filteredDirectories, err := l.filterDirectories(defaultTopLevelLdsoconfFilePath, l.directories...)
+		if err != nil {
+			return err
+		}
+		if err := createMuslPathFile(append(filteredDirectories, l.getSystemSearchPaths()...)...); err != nil {
+			return fmt.Errorf("failed to update .path file for musl: %w", err)
+		}
+		return nil
+	}
+
 	// Ensure that the top-level config file used specifies includes the
 	// defaultLdsoconfDir drop-in config folder.
 	if err := ensureLdsoconfFile(defaultTopLevelLdsoconfFilePath, defaultLdsoconfdDir); err != nil {
@@ -182,11 +205,6 @@ func (l *Ldconfig) UpdateLDCache() error {
 		return fmt.Errorf("failed to write %s drop-in: %w", ldsoconfdSystemDirsFilenamePattern, err)
 	}
 
-	// Also output the folders to the alpine .path file as required.
-	if err := createMuslPathFileIfRequired(append(filteredDirectories, systemSearchPaths...)...); err != nil {
-		return fmt.Errorf("failed to update .path file for musl: %w", err)
-	}
-
 	return SafeExec(ldconfigPath, args, nil)
 }
 
@@ -374,23 +392,17 @@ func processLdsoconfFile(ldsoconfFilename string) ([]string, []string, error) {
 	return directories, includedFilenames, nil
 }
 
-// createMuslPathFileIfRequired creates a musl .path file that allows libraries
-// from the specified directories to be discovered on the system.
+// createMuslPathFile creates a musl .path file that allows libraries from the
+// specified directories to be discovered on the system.
 // This is required because systems that use musl do not rely on the ldcache to
 // discover libraries.
-func createMuslPathFileIfRequired(dirs ...string) error {
-	if len(dirs) == 0 || !isMusl() {
+func createMuslPathFile(dirs ...string) error {
+	arch := muslArch()
+	if len(dirs) == 0 || arch == "" {
 		return nil
 	}
 
-	var pathFileName string
-	switch runtime.GOARCH {
-	case "amd64":
-		pathFileName = "/etc/ld-musl-x86_64.path"
-	case "arm64":
-		pathFileName = "/etc/ld-musl-aarch64.path"
-	}
-
+	pathFileName := "/etc/ld-musl-" + arch + ".path"
 	pathFile, err := os.OpenFile(pathFileName, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0644)
 	if err != nil {
 		return fmt.Errorf("could not open .path file: %w", err)
@@ -402,17 +414,43 @@ func createMuslPathFileIfRequired(dirs ...string) error {
 	return outputListToFile(pathFile, dirs...)
 }
 
-// isMusl checks whether the container is running musl instead of glibc.
-// Note that for the time being we only check whether `/etc/alpine-release` is
-// present in the container.
-func isMusl() bool {
-	info, err := os.Stat("/etc/alpine-release")
+// IsMuslContainer checks whether the container with the specified root uses
+// musl instead of glibc. This is the case if the musl dynamic linker is present
+// in the container or if the container is Alpine-based.
+func IsMuslContainer(containerRoot string) bool {
+	root, err := os.OpenRoot(containerRoot)
+	if err != nil {
+		return false
+	}
+	defer root.Close()
+
+	if arch := muslArch(); arch != "" {
+		// The dynamic linker is typically a symlink to the musl libc and we
+		// do not want to resolve this relative to the host.
+		if _, err := root.Lstat("lib/ld-musl-" + arch + ".so.1"); err == nil {
+			return true
+		}
+	}
+	info, err := root.Stat("etc/alpine-release")
 	if err != nil {
 		return false
 	}
 	return !info.IsDir()
 }
 
+// muslArch returns the architecture used in the names of the musl dynamic
+// linker and .path file. An empty string is returned for unsupported
+// architectures.
+func muslArch() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x86_64"
+	case "arm64":
+		return "aarch64"
+	}
+	return ""
+}
+
 // isDebianLike returns true if a Debian-like distribution is detected.
 // Debian-like distributions include Debian and Ubuntu, whereas non-Debian-like
 // distributions include RHEL and Fedora.
@@ -246,3 +246,58 @@ func TestEnsureLdsoconfFile(t *testing.T) {
 		})
 	}
 }
+
+func TestIsMuslContainer(t *testing.T) {
+	arch := muslArch()
+	if arch == "" {
+		t.Skip("musl detection is not supported on this architecture")
+	}
+
+	testCases := []struct {
+		description string
+		files       []string
+		symlinks    map[string]string
+		expected    bool
+	}{
+		{
+			description: "empty root",
+		},
+		{
+			description: "glibc root",
+			files:       []string{"etc/debian_version", "lib/libc.so.6"},
+		},
+		{
+			description: "alpine release file",
+			files:       []string{"etc/alpine-release"},
+			expected:    true,
+		},
+		{
+			description: "musl dynamic linker",
+			files:       []string{"lib/ld-musl-" + arch + ".so.1"},
+			expected:    true,
+		},
+		{
+			description: "dangling musl dynamic linker symlink",
+			symlinks:    map[string]string{"lib/ld-musl-" + arch + ".so.1": "/lib/libc.musl-" + arch + ".so.1"},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			root := t.TempDir()
+			for _, file := range tc.files {
+				path := filepath.Join(root, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0644)) //nolint:gosec
+			}
+			for link, target := range tc.symlinks {
+				path := filepath.Join(root, link)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.Symlink(target, path))
+			}
+
+			require.Equal(t, tc.expected, IsMuslContainer(root))
+		})
+	}
+}
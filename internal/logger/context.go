@@ -0,0 +1,38 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package logger
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of the parent context that carries the specified
+// logger.
+func NewContext(ctx context.Context, logger Interface) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by the specified context. If the
+// context does not carry a logger, the default logger is returned.
+func FromContext(ctx context.Context) Interface {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextKey{}).(Interface); ok && logger != nil {
+			return logger
+		}
+	}
+	return New()
+}
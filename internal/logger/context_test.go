@@ -0,0 +1,61 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromContext(t *testing.T) {
+	nullLogger := &NullLogger{}
+
+	testCases := []struct {
+		description    string
+		ctx            context.Context
+		expectedLogger Interface
+	}{
+		{
+			description:    "nil context returns default logger",
+			expectedLogger: logrus.StandardLogger(),
+		},
+		{
+			description:    "context without logger returns default logger",
+			ctx:            context.Background(),
+			expectedLogger: logrus.StandardLogger(),
+		},
+		{
+			description:    "context logger is returned",
+			ctx:            NewContext(context.Background(), nullLogger),
+			expectedLogger: nullLogger,
+		},
+		{
+			description:    "nil context logger returns default logger",
+			ctx:            NewContext(context.Background(), nil),
+			expectedLogger: logrus.StandardLogger(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Same(t, tc.expectedLogger, FromContext(tc.ctx))
+		})
+	}
+}
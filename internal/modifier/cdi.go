@@ -70,11 +70,17 @@ func (f *Factory) newCDIModifier(isJitCDI bool) (oci.SpecModifier, error) {
 	}
 
 	f.logger.Debugf("Creating CDI modifier for devices: %v", devices)
-	return cdi.New(
+	cdiModifier, err := cdi.New(
 		cdi.WithLogger(f.logger),
 		cdi.WithDevices(devices...),
 		cdi.WithSpecDirs(f.cfg.NVIDIAContainerRuntimeConfig.Modes.CDI.SpecDirs...),
 	)
+	if err != nil {
+		return nil, err
+	}
+	// Relative mount sources in the CDI specs are resolved against the
+	// driver root once the CDI devices have been injected.
+	return f.newRelativeMountResolver(cdiModifier), nil
 }
 
 // newJitCDIModifier creates a modifier that for a generated in-memory CDI spec for the specified CDI devices.
@@ -37,6 +37,7 @@ func (f *Factory) newGraphicsModifier() (oci.SpecModifier, error) {
 		f.logger,
 		f.driver,
 		f.hookCreator,
+		"",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create mounts discoverer: %v", err)
@@ -57,6 +58,7 @@ func (f *Factory) newGraphicsModifier() (oci.SpecModifier, error) {
 	d := discover.Merge(
 		drmNodes,
 		mounts,
+		discover.NewOpenCLDiscoverer(f.logger, f.driver),
 	)
 	return f.newModifierFromDiscoverer(d)
 }
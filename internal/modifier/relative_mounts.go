@@ -0,0 +1,89 @@
+/**
+# Copyright (c) 2022, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/oci"
+)
+
+// relativeMountResolver is a spec modifier that applies the CDI modifier and
+// re-anchors the relative sources of the bind mounts injected by it at the
+// driver root. Such mounts are included in CDI specs that were generated with
+// the --relative-to option so that the specs can be used on nodes with
+// different driver roots. Mounts that were already included in the spec, such
+// as user mounts with sources relative to the bundle, are not modified.
+type relativeMountResolver struct {
+	logger      logger.Interface
+	driverRoot  string
+	cdiModifier oci.SpecModifier
+}
+
+var _ oci.SpecModifier = (*relativeMountResolver)(nil)
+
+// newRelativeMountResolver creates a modifier that applies the specified CDI
+// modifier and re-anchors the relative bind mount sources injected by it at
+// the driver root.
+func (f *Factory) newRelativeMountResolver(cdiModifier oci.SpecModifier) oci.SpecModifier {
+	return relativeMountResolver{
+		logger:      f.logger,
+		driverRoot:  f.driver.Root,
+		cdiModifier: cdiModifier,
+	}
+}
+
+// Modify applies the CDI modifier and joins the driver root to the relative
+// sources of the bind mounts that were added.
+func (m relativeMountResolver) Modify(spec *specs.Spec) error {
+	if spec == nil {
+		return m.cdiModifier.Modify(spec)
+	}
+
+	existing := slices.Clone(spec.Mounts)
+	if err := m.cdiModifier.Modify(spec); err != nil {
+		return err
+	}
+
+	for i, mount := range spec.Mounts {
+		if mount.Source == "" || filepath.IsAbs(mount.Source) || !isBindMount(mount) {
+			continue
+		}
+		if slices.ContainsFunc(existing, func(e specs.Mount) bool { return isSameMount(e, mount) }) {
+			continue
+		}
+		source := filepath.Join(m.driverRoot, mount.Source)
+		m.logger.Debugf("Resolving relative mount source %v to %v", mount.Source, source)
+		spec.Mounts[i].Source = source
+	}
+	return nil
+}
+
+func isBindMount(mount specs.Mount) bool {
+	return mount.Type == "bind" || slices.Contains(mount.Options, "bind") || slices.Contains(mount.Options, "rbind")
+}
+
+func isSameMount(a specs.Mount, b specs.Mount) bool {
+	return a.Destination == b.Destination &&
+		a.Source == b.Source &&
+		a.Type == b.Type &&
+		slices.Equal(a.Options, b.Options)
+}
@@ -0,0 +1,158 @@
+/**
+# Copyright (c) 2022, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package modifier
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+// addMounts is a spec modifier that appends the mounts to the spec in the same
+// way that the mounts of CDI devices are injected.
+type addMounts []specs.Mount
+
+func (m addMounts) Modify(spec *specs.Spec) error {
+	if spec != nil {
+		spec.Mounts = append(spec.Mounts, m...)
+	}
+	return nil
+}
+
+func TestRelativeMountResolver(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description  string
+		driverRoot   string
+		spec         *specs.Spec
+		cdiMounts    addMounts
+		expectedSpec *specs.Spec
+	}{
+		{
+			description: "nil spec",
+			driverRoot:  "/run/nvidia/driver",
+		},
+		{
+			description: "relative bind mount sources are resolved",
+			driverRoot:  "/run/nvidia/driver",
+			spec:        &specs.Spec{},
+			cdiMounts: addMounts{
+				{
+					Destination: "/usr/lib64/libcuda.so.999.88.77",
+					Source:      "usr/lib64/libcuda.so.999.88.77",
+					Options:     []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+				},
+				{
+					Destination: "/usr/bin/nvidia-smi",
+					Source:      "usr/bin/nvidia-smi",
+					Type:        "bind",
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{
+						Destination: "/usr/lib64/libcuda.so.999.88.77",
+						Source:      "/run/nvidia/driver/usr/lib64/libcuda.so.999.88.77",
+						Options:     []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+					},
+					{
+						Destination: "/usr/bin/nvidia-smi",
+						Source:      "/run/nvidia/driver/usr/bin/nvidia-smi",
+						Type:        "bind",
+					},
+				},
+			},
+		},
+		{
+			description: "absolute and non-bind mounts are unchanged",
+			driverRoot:  "/run/nvidia/driver",
+			spec:        &specs.Spec{},
+			cdiMounts: addMounts{
+				{
+					Destination: "/usr/bin/nvidia-smi",
+					Source:      "/usr/bin/nvidia-smi",
+					Options:     []string{"ro", "rbind"},
+				},
+				{
+					Destination: "/proc",
+					Source:      "proc",
+					Type:        "proc",
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{
+						Destination: "/usr/bin/nvidia-smi",
+						Source:      "/usr/bin/nvidia-smi",
+						Options:     []string{"ro", "rbind"},
+					},
+					{
+						Destination: "/proc",
+						Source:      "proc",
+						Type:        "proc",
+					},
+				},
+			},
+		},
+		{
+			description: "existing relative bind mounts are unchanged",
+			driverRoot:  "/run/nvidia/driver",
+			spec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{
+						Destination: "/data",
+						Source:      "data",
+						Options:     []string{"rbind"},
+					},
+				},
+			},
+			cdiMounts: addMounts{
+				{
+					Destination: "/usr/bin/nvidia-smi",
+					Source:      "usr/bin/nvidia-smi",
+					Options:     []string{"ro", "rbind"},
+				},
+			},
+			expectedSpec: &specs.Spec{
+				Mounts: []specs.Mount{
+					{
+						Destination: "/data",
+						Source:      "data",
+						Options:     []string{"rbind"},
+					},
+					{
+						Destination: "/usr/bin/nvidia-smi",
+						Source:      "/run/nvidia/driver/usr/bin/nvidia-smi",
+						Options:     []string{"ro", "rbind"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			m := relativeMountResolver{logger: logger, driverRoot: tc.driverRoot, cdiModifier: tc.cdiMounts}
+
+			require.NoError(t, m.Modify(tc.spec))
+			require.EqualValues(t, tc.expectedSpec, tc.spec)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// notSupportedTypes maps the names of the generated types to the NVML
+// interfaces that they implement.
+var notSupportedTypes = []struct {
+	Type      string
+	Interface reflect.Type
+}{
+	{"notSupportedInterface", reflect.TypeOf((*nvml.Interface)(nil)).Elem()},
+	{"notSupportedDevice", reflect.TypeOf((*nvml.Device)(nil)).Elem()},
+	{"notSupportedGpuInstance", reflect.TypeOf((*nvml.GpuInstance)(nil)).Elem()},
+	{"notSupportedComputeInstance", reflect.TypeOf((*nvml.ComputeInstance)(nil)).Elem()},
+}
+
+var returnType = reflect.TypeOf(nvml.SUCCESS)
+
+func main() {
+	output := flag.String("output", "", "Path to the output file (default: stdout)")
+	flag.Parse()
+
+	source, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		fmt.Print(string(source))
+		return
+	}
+	if err := os.WriteFile(*output, source, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	for _, t := range notSupportedTypes {
+		fmt.Fprintf(&b, "\n// %s implements nvml.%s with every method returning\n", t.Type, t.Interface.Name())
+		fmt.Fprintf(&b, "// nvml.ERROR_NOT_SUPPORTED where an nvml.Return is returned.\n")
+		fmt.Fprintf(&b, "type %s struct{}\n\n", t.Type)
+		fmt.Fprintf(&b, "var _ nvml.%s = (*%s)(nil)\n", t.Interface.Name(), t.Type)
+		for i := 0; i < t.Interface.NumMethod(); i++ {
+			b.WriteString("\n")
+			b.WriteString(generateMethod(t.Type, t.Interface.Method(i)))
+		}
+	}
+	return format.Source(b.Bytes())
+}
+
+// generateMethod returns a method that returns the zero value for each of its
+// results. If the last result is an nvml.Return, nvml.ERROR_NOT_SUPPORTED is
+// returned instead.
+func generateMethod(receiver string, m reflect.Method) string {
+	var params []string
+	for i := 0; i < m.Type.NumIn(); i++ {
+		param := m.Type.In(i).String()
+		if m.Type.IsVariadic() && i == m.Type.NumIn()-1 {
+			param = "..." + m.Type.In(i).Elem().String()
+		}
+		params = append(params, param)
+	}
+
+	var results []string
+	var values []string
+	for i := 0; i < m.Type.NumOut(); i++ {
+		out := m.Type.Out(i)
+		name := fmt.Sprintf("r%d", i)
+		results = append(results, name+" "+out.String())
+		if i == m.Type.NumOut()-1 && out == returnType {
+			values = append(values, "nvml.ERROR_NOT_SUPPORTED")
+			continue
+		}
+		values = append(values, name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "func (%s) %s(%s)", receiver, m.Name, strings.Join(params, ", "))
+	if len(results) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(results, ", "))
+	}
+	b.WriteString(" {\n")
+	if len(values) > 0 {
+		fmt.Fprintf(&b, "\treturn %s\n", strings.Join(values, ", "))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+const header = `/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Generated Code; DO NOT EDIT.
+
+package snapshot
+
+import (
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+`
@@ -0,0 +1,375 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package snapshot
+
+import (
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+//go:generate go run ./gen/notsupported -output zz_generated.notsupported.go
+
+// maxMigDevices is the number of MIG device handles reported for a device with
+// MIG mode enabled.
+const maxMigDevices = 8
+
+// encoderCapacity is the encoder capacity in percent that is reported for
+// devices with an NVENC engine.
+const encoderCapacity = 100
+
+// decoderSamplingPeriodUs is the decoder utilization sampling period in
+// microseconds that is reported for devices with an NVDEC engine.
+const decoderSamplingPeriodUs = 1000000
+
+// supportedSymbols lists the optional NVML symbols that are reported as
+// present by a snapshot-backed NVML library.
+var supportedSymbols = map[string]bool{
+	"nvmlDeviceGetMigMode":    true,
+	"nvmlDeviceGetNumaNodeId": true,
+}
+
+// NvmlLib returns an NVML library that reports the devices recorded in the
+// snapshot. Only the subset of the NVML API that is used to generate CDI
+// specifications is implemented. All other calls return
+// nvml.ERROR_NOT_SUPPORTED.
+func (s *Snapshot) NvmlLib() nvml.Interface {
+	l := &nvmlLib{
+		snapshot: s,
+	}
+	for i := range s.Devices {
+		l.devices = append(l.devices, newNvmlDevice(&s.Devices[i]))
+	}
+	return l
+}
+
+type nvmlLib struct {
+	notSupportedInterface
+	snapshot *Snapshot
+	devices  []*nvmlDevice
+}
+
+var _ nvml.Interface = (*nvmlLib)(nil)
+
+func (l *nvmlLib) Init() nvml.Return {
+	return nvml.SUCCESS
+}
+
+func (l *nvmlLib) Shutdown() nvml.Return {
+	return nvml.SUCCESS
+}
+
+func (l *nvmlLib) Extensions() nvml.ExtendedInterface {
+	return extendedInterface{}
+}
+
+func (l *nvmlLib) SystemGetDriverVersion() (string, nvml.Return) {
+	return l.snapshot.DriverVersion, nvml.SUCCESS
+}
+
+func (l *nvmlLib) SystemGetCudaDriverVersion() (int, nvml.Return) {
+	return l.snapshot.CudaDriverVersion, nvml.SUCCESS
+}
+
+func (l *nvmlLib) DeviceGetCount() (int, nvml.Return) {
+	return len(l.devices), nvml.SUCCESS
+}
+
+func (l *nvmlLib) DeviceGetHandleByIndex(n int) (nvml.Device, nvml.Return) {
+	if n < 0 || n >= len(l.devices) {
+		return nil, nvml.ERROR_INVALID_ARGUMENT
+	}
+	return l.devices[n], nvml.SUCCESS
+}
+
+func (l *nvmlLib) DeviceGetHandleByUUID(uuid string) (nvml.Device, nvml.Return) {
+	for _, d := range l.devices {
+		if d.device.UUID == uuid {
+			return d, nvml.SUCCESS
+		}
+		for _, mig := range d.migs {
+			if mig.device.UUID == uuid {
+				return mig, nvml.SUCCESS
+			}
+		}
+	}
+	return nil, nvml.ERROR_NOT_FOUND
+}
+
+// extendedInterface reports the symbols in supportedSymbols as present.
+type extendedInterface struct{}
+
+func (extendedInterface) LookupSymbol(symbol string) error {
+	if supportedSymbols[symbol] {
+		return nil
+	}
+	return nvml.ERROR_FUNCTION_NOT_FOUND
+}
+
+// nvmlDevice is a full GPU backed by a device in a snapshot.
+type nvmlDevice struct {
+	notSupportedDevice
+	device       *Device
+	migs         map[int]*nvmlMigDevice
+	gpuInstances map[int]*gpuInstance
+}
+
+var _ nvml.Device = (*nvmlDevice)(nil)
+
+func newNvmlDevice(d *Device) *nvmlDevice {
+	parent := &nvmlDevice{
+		device:       d,
+		migs:         make(map[int]*nvmlMigDevice),
+		gpuInstances: make(map[int]*gpuInstance),
+	}
+
+	migsByGpuInstance := make(map[int][]MigDevice)
+	for i, m := range d.MigDevices {
+		parent.migs[m.Index] = &nvmlMigDevice{
+			device: &d.MigDevices[i],
+			parent: parent,
+		}
+		migsByGpuInstance[m.GpuInstanceID] = append(migsByGpuInstance[m.GpuInstanceID], m)
+	}
+	for id, migDevices := range migsByGpuInstance {
+		parent.gpuInstances[id] = newGpuInstance(migDevices)
+	}
+
+	return parent
+}
+
+func (d *nvmlDevice) GetIndex() (int, nvml.Return) {
+	return d.device.Index, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetUUID() (string, nvml.Return) {
+	return d.device.UUID, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetName() (string, nvml.Return) {
+	return d.device.Name, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetMinorNumber() (int, nvml.Return) {
+	return d.device.Minor, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetPciInfo() (nvml.PciInfo, nvml.Return) {
+	var info nvml.PciInfo
+	for i := 0; i < len(d.device.PCIBusID) && i < len(info.BusId)-1; i++ {
+		info.BusId[i] = int8(d.device.PCIBusID[i])
+	}
+	return info, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetSerial() (string, nvml.Return) {
+	if d.device.Serial == "" {
+		return "", nvml.ERROR_NOT_SUPPORTED
+	}
+	return d.device.Serial, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetMemoryInfo() (nvml.Memory, nvml.Return) {
+	return nvml.Memory{Total: d.device.MemoryTotal}, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetDisplayMode() (nvml.EnableState, nvml.Return) {
+	if d.device.DisplayEnabled {
+		return nvml.FEATURE_ENABLED, nvml.SUCCESS
+	}
+	return nvml.FEATURE_DISABLED, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetCudaComputeCapability() (int, int, nvml.Return) {
+	if d.device.ComputeCapability == nil {
+		return 0, 0, nvml.ERROR_NOT_SUPPORTED
+	}
+	return d.device.ComputeCapability.Major, d.device.ComputeCapability.Minor, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetNumaNodeId() (int, nvml.Return) {
+	if d.device.NUMANode == nil {
+		return 0, nvml.ERROR_NOT_SUPPORTED
+	}
+	return *d.device.NUMANode, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetEncoderCapacity(nvml.EncoderType) (int, nvml.Return) {
+	if !d.device.Engines.NVENC {
+		return 0, nvml.ERROR_NOT_SUPPORTED
+	}
+	return encoderCapacity, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetDecoderUtilization() (uint32, uint32, nvml.Return) {
+	if !d.device.Engines.NVDEC {
+		return 0, 0, nvml.ERROR_NOT_SUPPORTED
+	}
+	return 0, decoderSamplingPeriodUs, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) IsMigDeviceHandle() (bool, nvml.Return) {
+	return false, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetMigMode() (int, int, nvml.Return) {
+	migMode := nvml.DEVICE_MIG_DISABLE
+	if d.device.MigEnabled {
+		migMode = nvml.DEVICE_MIG_ENABLE
+	}
+	return migMode, migMode, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetMaxMigDeviceCount() (int, nvml.Return) {
+	if !d.device.MigEnabled {
+		return 0, nvml.SUCCESS
+	}
+	return maxMigDevices, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetMigDeviceHandleByIndex(n int) (nvml.Device, nvml.Return) {
+	mig, exists := d.migs[n]
+	if !exists {
+		return nil, nvml.ERROR_NOT_FOUND
+	}
+	return mig, nvml.SUCCESS
+}
+
+func (d *nvmlDevice) GetGpuInstanceById(id int) (nvml.GpuInstance, nvml.Return) {
+	gi, exists := d.gpuInstances[id]
+	if !exists {
+		return nil, nvml.ERROR_NOT_FOUND
+	}
+	return gi, nvml.SUCCESS
+}
+
+// GetGpuInstanceProfileInfo uses the GPU instance profile ID as the profile
+// index. This means that all profiles are reported as supported.
+func (d *nvmlDevice) GetGpuInstanceProfileInfo(profile int) (nvml.GpuInstanceProfileInfo, nvml.Return) {
+	return nvml.GpuInstanceProfileInfo{Id: uint32(profile)}, nvml.SUCCESS
+}
+
+// nvmlMigDevice is a MIG device backed by a MIG device in a snapshot.
+type nvmlMigDevice struct {
+	notSupportedDevice
+	device *MigDevice
+	parent *nvmlDevice
+}
+
+var _ nvml.Device = (*nvmlMigDevice)(nil)
+
+func (m *nvmlMigDevice) GetUUID() (string, nvml.Return) {
+	return m.device.UUID, nvml.SUCCESS
+}
+
+func (m *nvmlMigDevice) IsMigDeviceHandle() (bool, nvml.Return) {
+	return true, nvml.SUCCESS
+}
+
+func (m *nvmlMigDevice) GetDeviceHandleFromMigDeviceHandle() (nvml.Device, nvml.Return) {
+	return m.parent, nvml.SUCCESS
+}
+
+func (m *nvmlMigDevice) GetGpuInstanceId() (int, nvml.Return) {
+	return m.device.GpuInstanceID, nvml.SUCCESS
+}
+
+func (m *nvmlMigDevice) GetComputeInstanceId() (int, nvml.Return) {
+	return m.device.ComputeInstanceID, nvml.SUCCESS
+}
+
+func (m *nvmlMigDevice) GetAttributes() (nvml.DeviceAttributes, nvml.Return) {
+	return nvml.DeviceAttributes{MemorySizeMB: m.device.MemorySizeMB}, nvml.SUCCESS
+}
+
+func (m *nvmlMigDevice) GetMemoryInfo() (nvml.Memory, nvml.Return) {
+	return nvml.Memory{Total: m.device.MemorySizeMB * 1024 * 1024}, nvml.SUCCESS
+}
+
+// GetCudaComputeCapability returns the compute capability of the parent
+// device.
+func (m *nvmlMigDevice) GetCudaComputeCapability() (int, int, nvml.Return) {
+	return m.parent.GetCudaComputeCapability()
+}
+
+// gpuInstance is a GPU instance containing a compute instance for each of its
+// MIG devices.
+type gpuInstance struct {
+	notSupportedGpuInstance
+	info             nvml.GpuInstanceInfo
+	computeInstances map[int]*computeInstance
+}
+
+var _ nvml.GpuInstance = (*gpuInstance)(nil)
+
+// newGpuInstance returns a GPU instance containing a compute instance for each
+// of the specified MIG devices. All MIG devices are expected to share the same
+// GPU instance.
+func newGpuInstance(migDevices []MigDevice) *gpuInstance {
+	gi := &gpuInstance{
+		info: nvml.GpuInstanceInfo{
+			Id:        uint32(migDevices[0].GpuInstanceID),
+			ProfileId: uint32(migDevices[0].GIProfileID),
+		},
+		computeInstances: make(map[int]*computeInstance),
+	}
+	for _, m := range migDevices {
+		gi.computeInstances[m.ComputeInstanceID] = &computeInstance{
+			info: nvml.ComputeInstanceInfo{
+				Id:        uint32(m.ComputeInstanceID),
+				ProfileId: computeInstanceProfileID(m.CIProfileID, m.CIEngProfileID),
+			},
+		}
+	}
+	return gi
+}
+
+func (gi *gpuInstance) GetInfo() (nvml.GpuInstanceInfo, nvml.Return) {
+	return gi.info, nvml.SUCCESS
+}
+
+func (gi *gpuInstance) GetComputeInstanceById(id int) (nvml.ComputeInstance, nvml.Return) {
+	ci, exists := gi.computeInstances[id]
+	if !exists {
+		return nil, nvml.ERROR_NOT_FOUND
+	}
+	return ci, nvml.SUCCESS
+}
+
+func (gi *gpuInstance) GetComputeInstanceProfileInfo(profile int, engineProfile int) (nvml.ComputeInstanceProfileInfo, nvml.Return) {
+	info := nvml.ComputeInstanceProfileInfo{
+		Id: computeInstanceProfileID(profile, engineProfile),
+	}
+	return info, nvml.SUCCESS
+}
+
+// computeInstance is a compute instance of a MIG device.
+type computeInstance struct {
+	notSupportedComputeInstance
+	info nvml.ComputeInstanceInfo
+}
+
+var _ nvml.ComputeInstance = (*computeInstance)(nil)
+
+func (ci *computeInstance) GetInfo() (nvml.ComputeInstanceInfo, nvml.Return) {
+	return ci.info, nvml.SUCCESS
+}
+
+// computeInstanceProfileID returns a unique ID for the specified compute
+// instance profile and engine profile indices.
+func computeInstanceProfileID(profile int, engineProfile int) uint32 {
+	return uint32(profile*nvml.COMPUTE_INSTANCE_ENGINE_PROFILE_COUNT + engineProfile)
+}
@@ -0,0 +1,267 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/engines"
+)
+
+// A Snapshot records the NVML device data that is required to generate CDI
+// specifications. This allows specifications to be generated on a system
+// where NVML is not available.
+type Snapshot struct {
+	DriverVersion     string   `json:"driverVersion"`
+	CudaDriverVersion int      `json:"cudaDriverVersion"`
+	Devices           []Device `json:"devices"`
+}
+
+// A Device represents a full GPU in a snapshot.
+type Device struct {
+	Index             int                `json:"index"`
+	UUID              string             `json:"uuid"`
+	Name              string             `json:"name"`
+	Minor             int                `json:"minor"`
+	PCIBusID          string             `json:"pciBusID"`
+	Serial            string             `json:"serial,omitempty"`
+	MemoryTotal       uint64             `json:"memoryTotal"`
+	DisplayEnabled    bool               `json:"displayEnabled,omitempty"`
+	NUMANode          *int               `json:"numaNode,omitempty"`
+	ComputeCapability *ComputeCapability `json:"computeCapability,omitempty"`
+	Engines           Engines            `json:"engines"`
+	MigEnabled        bool               `json:"migEnabled,omitempty"`
+	MigDevices        []MigDevice        `json:"migDevices,omitempty"`
+}
+
+// A ComputeCapability represents the CUDA compute capability of a device.
+type ComputeCapability struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+}
+
+// Engines records which optional hardware engines a device supports.
+type Engines struct {
+	NVENC bool `json:"nvenc"`
+	NVDEC bool `json:"nvdec"`
+}
+
+// A MigDevice represents a MIG device in a snapshot.
+// The profile IDs are the indices used to construct the MIG profile for the
+// device and not the IDs reported by NVML.
+type MigDevice struct {
+	Index             int    `json:"index"`
+	UUID              string `json:"uuid"`
+	GpuInstanceID     int    `json:"gpuInstanceID"`
+	ComputeInstanceID int    `json:"computeInstanceID"`
+	GIProfileID       int    `json:"giProfileID"`
+	CIProfileID       int    `json:"ciProfileID"`
+	CIEngProfileID    int    `json:"ciEngProfileID"`
+	MemorySizeMB      uint64 `json:"memorySizeMB"`
+}
+
+// Capture creates a snapshot of the devices reported by the specified NVML
+// library. NVML is initialized and shut down as part of the capture.
+func Capture(nvmllib nvml.Interface) (_ *Snapshot, rerr error) {
+	if r := nvmllib.Init(); r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to initialize NVML: %w", r)
+	}
+	defer func() {
+		if r := nvmllib.Shutdown(); r != nvml.SUCCESS && rerr == nil {
+			rerr = fmt.Errorf("failed to shutdown NVML: %w", r)
+		}
+	}()
+
+	driverVersion, r := nvmllib.SystemGetDriverVersion()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get driver version: %w", r)
+	}
+	cudaDriverVersion, r := nvmllib.SystemGetCudaDriverVersion()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get CUDA driver version: %w", r)
+	}
+
+	s := &Snapshot{
+		DriverVersion:     driverVersion,
+		CudaDriverVersion: cudaDriverVersion,
+	}
+
+	err := device.New(nvmllib).VisitDevices(func(i int, d device.Device) error {
+		captured, err := captureDevice(nvmllib, i, d)
+		if err != nil {
+			return fmt.Errorf("failed to capture device %d: %w", i, err)
+		}
+		s.Devices = append(s.Devices, *captured)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func captureDevice(nvmllib nvml.Interface, i int, d device.Device) (*Device, error) {
+	uuid, r := d.GetUUID()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get UUID: %w", r)
+	}
+	name, r := d.GetName()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get name: %w", r)
+	}
+	minor, r := d.GetMinorNumber()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get minor number: %w", r)
+	}
+	pciBusID, err := d.GetPCIBusID()
+	if err != nil {
+		return nil, err
+	}
+	memory, r := d.GetMemoryInfo()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get memory info: %w", r)
+	}
+	migEnabled, err := d.IsMigEnabled()
+	if err != nil {
+		return nil, err
+	}
+	serial, r := d.GetSerial()
+	if r != nvml.SUCCESS && r != nvml.ERROR_NOT_SUPPORTED {
+		return nil, fmt.Errorf("failed to get serial number: %w", r)
+	}
+	displayMode, r := d.GetDisplayMode()
+	if r != nvml.SUCCESS && r != nvml.ERROR_NOT_SUPPORTED {
+		return nil, fmt.Errorf("failed to get display mode: %w", r)
+	}
+
+	captured := &Device{
+		Index:          i,
+		UUID:           uuid,
+		Name:           name,
+		Minor:          minor,
+		PCIBusID:       pciBusID,
+		Serial:         serial,
+		MemoryTotal:    memory.Total,
+		DisplayEnabled: r == nvml.SUCCESS && displayMode == nvml.FEATURE_ENABLED,
+		Engines: Engines{
+			NVENC: engines.SupportsNVENC(d),
+			NVDEC: engines.SupportsNVDEC(d),
+		},
+		MigEnabled: migEnabled,
+	}
+	if nvmllib.Extensions().LookupSymbol("nvmlDeviceGetNumaNodeId") == nil {
+		numaNode, r := d.GetNumaNodeId()
+		if r != nvml.SUCCESS && r != nvml.ERROR_NOT_SUPPORTED {
+			return nil, fmt.Errorf("failed to get NUMA node: %w", r)
+		}
+		if r == nvml.SUCCESS && numaNode >= 0 {
+			captured.NUMANode = &numaNode
+		}
+	}
+	major, minor, r := d.GetCudaComputeCapability()
+	if r != nvml.SUCCESS && r != nvml.ERROR_NOT_SUPPORTED {
+		return nil, fmt.Errorf("failed to get compute capability: %w", r)
+	}
+	if r == nvml.SUCCESS {
+		captured.ComputeCapability = &ComputeCapability{Major: major, Minor: minor}
+	}
+	if !migEnabled {
+		return captured, nil
+	}
+
+	err = d.VisitMigDevices(func(j int, mig device.MigDevice) error {
+		capturedMig, err := captureMigDevice(j, mig)
+		if err != nil {
+			return fmt.Errorf("failed to capture MIG device %d: %w", j, err)
+		}
+		captured.MigDevices = append(captured.MigDevices, *capturedMig)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return captured, nil
+}
+
+func captureMigDevice(j int, mig device.MigDevice) (*MigDevice, error) {
+	uuid, r := mig.GetUUID()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get UUID: %w", r)
+	}
+	giID, r := mig.GetGpuInstanceId()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get GPU instance ID: %w", r)
+	}
+	ciID, r := mig.GetComputeInstanceId()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get compute instance ID: %w", r)
+	}
+	attributes, r := mig.GetAttributes()
+	if r != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get attributes: %w", r)
+	}
+	profile, err := mig.GetProfile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	info := profile.GetInfo()
+
+	return &MigDevice{
+		Index:             j,
+		UUID:              uuid,
+		GpuInstanceID:     giID,
+		ComputeInstanceID: ciID,
+		GIProfileID:       info.GIProfileID,
+		CIProfileID:       info.CIProfileID,
+		CIEngProfileID:    info.CIEngProfileID,
+		MemorySizeMB:      attributes.MemorySizeMB,
+	}, nil
+}
+
+// Load reads a snapshot from the specified file.
+func Load(filename string) (*Snapshot, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(contents, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %v: %w", filename, err)
+	}
+	return &s, nil
+}
+
+// Save writes the snapshot to the specified file.
+func (s *Snapshot) Save(filename string) error {
+	contents, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.WriteFile(filename, append(contents, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package snapshot
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureRoundTrip(t *testing.T) {
+	server := dgxa100.New()
+	for i, d := range server.Devices {
+		d.(*dgxa100.Device).GetDisplayModeFunc = func() (nvml.EnableState, nvml.Return) {
+			return nvml.FEATURE_DISABLED, nvml.SUCCESS
+		}
+		d.(*dgxa100.Device).GetNumaNodeIdFunc = func() (int, nvml.Return) {
+			return i / 4, nvml.SUCCESS
+		}
+		d.(*dgxa100.Device).GetSerialFunc = func() (string, nvml.Return) {
+			return fmt.Sprintf("%010d", i), nvml.SUCCESS
+		}
+		d.(*dgxa100.Device).GetEncoderCapacityFunc = func(nvml.EncoderType) (int, nvml.Return) {
+			if i%2 != 0 {
+				return 0, nvml.ERROR_NOT_SUPPORTED
+			}
+			return 100, nvml.SUCCESS
+		}
+		d.(*dgxa100.Device).GetDecoderUtilizationFunc = func() (uint32, uint32, nvml.Return) {
+			if i%4 == 3 {
+				return 0, 0, nvml.ERROR_NOT_SUPPORTED
+			}
+			return 0, 167000, nvml.SUCCESS
+		}
+	}
+
+	captured, err := Capture(server)
+	require.NoError(t, err)
+	require.Equal(t, "550.54.15", captured.DriverVersion)
+	require.Len(t, captured.Devices, 8)
+	for i, d := range server.Devices {
+		require.Equal(t, d.(*dgxa100.Device).UUID, captured.Devices[i].UUID)
+		require.Equal(t, i/4, *captured.Devices[i].NUMANode)
+		require.Equal(t, fmt.Sprintf("%010d", i), captured.Devices[i].Serial)
+		require.Equal(t, &ComputeCapability{Major: 8, Minor: 0}, captured.Devices[i].ComputeCapability)
+		require.Equal(t, Engines{NVENC: i%2 == 0, NVDEC: i%4 != 3}, captured.Devices[i].Engines)
+	}
+
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, captured.Save(filename))
+
+	loaded, err := Load(filename)
+	require.NoError(t, err)
+	require.Equal(t, captured, loaded)
+
+	recaptured, err := Capture(loaded.NvmlLib())
+	require.NoError(t, err)
+	require.Equal(t, captured, recaptured)
+}
+
+func TestMigDevices(t *testing.T) {
+	s := &Snapshot{
+		DriverVersion: "999.88.77",
+		Devices: []Device{
+			{
+				UUID:        "GPU-0",
+				Name:        "NVIDIA A100-SXM4-40GB",
+				PCIBusID:    "0000:07:00.0",
+				MemoryTotal: 42949672960,
+				MigEnabled:  true,
+				MigDevices: []MigDevice{
+					{
+						Index:             0,
+						UUID:              "MIG-0",
+						GpuInstanceID:     7,
+						ComputeInstanceID: 0,
+						GIProfileID:       nvml.GPU_INSTANCE_PROFILE_1_SLICE,
+						CIProfileID:       nvml.COMPUTE_INSTANCE_PROFILE_1_SLICE,
+						MemorySizeMB:      4864,
+					},
+					{
+						Index:             1,
+						UUID:              "MIG-1",
+						GpuInstanceID:     1,
+						ComputeInstanceID: 0,
+						GIProfileID:       nvml.GPU_INSTANCE_PROFILE_3_SLICE,
+						CIProfileID:       nvml.COMPUTE_INSTANCE_PROFILE_3_SLICE,
+						MemorySizeMB:      19968,
+					},
+				},
+			},
+		},
+	}
+
+	var profiles []string
+	err := device.New(s.NvmlLib()).VisitMigDevices(func(_ int, _ device.Device, _ int, mig device.MigDevice) error {
+		profile, err := mig.GetProfile()
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile.String())
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1g.5gb", "3g.20gb"}, profiles)
+
+	recaptured, err := Capture(s.NvmlLib())
+	require.NoError(t, err)
+	require.Equal(t, s, recaptured)
+}
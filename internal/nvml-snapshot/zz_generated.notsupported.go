@@ -0,0 +1,2788 @@
+/**
+# SPDX-FileCopyrightText: Copyright (c) 2025 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+# SPDX-License-Identifier: Apache-2.0
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+// Generated Code; DO NOT EDIT.
+
+package snapshot
+
+import (
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// notSupportedInterface implements nvml.Interface with every method returning
+// nvml.ERROR_NOT_SUPPORTED where an nvml.Return is returned.
+type notSupportedInterface struct{}
+
+var _ nvml.Interface = (*notSupportedInterface)(nil)
+
+func (notSupportedInterface) ComputeInstanceDestroy(nvml.ComputeInstance) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) ComputeInstanceGetInfo(nvml.ComputeInstance) (r0 nvml.ComputeInstanceInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceClearAccountingPids(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceClearCpuAffinity(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceClearEccErrorCounts(nvml.Device, nvml.EccCounterType) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceClearFieldValues(nvml.Device, []nvml.FieldValue) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceCreateGpuInstance(nvml.Device, *nvml.GpuInstanceProfileInfo) (r0 nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceCreateGpuInstanceWithPlacement(nvml.Device, *nvml.GpuInstanceProfileInfo, *nvml.GpuInstancePlacement) (r0 nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceDiscoverGpus() (r0 nvml.PciInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceFreezeNvLinkUtilizationCounter(nvml.Device, int, int, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAPIRestriction(nvml.Device, nvml.RestrictedAPI) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAccountingBufferSize(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAccountingMode(nvml.Device) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAccountingPids(nvml.Device) (r0 []int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAccountingStats(nvml.Device, uint32) (r0 nvml.AccountingStats, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAccountingStats_v2(nvml.Device, uint32) (r0 nvml.AccountingStats_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetActiveVgpus(nvml.Device) (r0 []nvml.VgpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAdaptiveClockInfoStatus(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAddressingMode(nvml.Device) (r0 nvml.DeviceAddressingMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetApplicationsClock(nvml.Device, nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetArchitecture(nvml.Device) (r0 nvml.DeviceArchitecture, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAttributes(nvml.Device) (r0 nvml.DeviceAttributes, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetAutoBoostedClocksEnabled(nvml.Device) (r0 nvml.EnableState, r1 nvml.EnableState, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetBAR1MemoryInfo(nvml.Device) (r0 nvml.BAR1Memory, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetBBXTimeData_v1(nvml.Device) (r0 nvml.BBXTimeData_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetBoardId(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetBoardPartNumber(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetBrand(nvml.Device) (r0 nvml.BrandType, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetBridgeChipInfo(nvml.Device) (r0 nvml.BridgeChipHierarchy, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetBusType(nvml.Device) (r0 nvml.BusType, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetC2cModeInfoV(nvml.Device) (r0 nvml.C2cModeInfoHandler) {
+	return r0
+}
+
+func (notSupportedInterface) DeviceGetCapabilities(nvml.Device) (r0 nvml.DeviceCapabilities, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetClkMonStatus(nvml.Device) (r0 nvml.ClkMonStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetClock(nvml.Device, nvml.ClockType, nvml.ClockId) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetClockInfo(nvml.Device, nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetClockOffsets(nvml.Device) (r0 nvml.ClockOffset, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetComputeInstanceId(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetComputeMode(nvml.Device) (r0 nvml.ComputeMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetComputeRunningProcesses(nvml.Device) (r0 []nvml.ProcessInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetConfComputeGpuAttestationReport(nvml.Device, *nvml.ConfComputeGpuAttestationReport) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetConfComputeGpuCertificate(nvml.Device) (r0 nvml.ConfComputeGpuCertificate, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetConfComputeMemSizeInfo(nvml.Device) (r0 nvml.ConfComputeMemSizeInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetConfComputeProtectedMemoryUsage(nvml.Device) (r0 nvml.Memory, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCoolerInfo(nvml.Device) (r0 nvml.CoolerInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCount() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCpuAffinity(nvml.Device, int) (r0 []uint, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCpuAffinityWithinScope(nvml.Device, int, nvml.AffinityScope) (r0 []uint, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCreatableVgpus(nvml.Device) (r0 []nvml.VgpuTypeId, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCudaComputeCapability(nvml.Device) (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCurrPcieLinkGeneration(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCurrPcieLinkWidth(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCurrentClockFreqs(nvml.Device) (r0 nvml.DeviceCurrentClockFreqs, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCurrentClocksEventReasons(nvml.Device) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetCurrentClocksThrottleReasons(nvml.Device) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDecoderUtilization(nvml.Device) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDefaultApplicationsClock(nvml.Device, nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDefaultEccMode(nvml.Device) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDetailedEccErrors(nvml.Device, nvml.MemoryErrorType, nvml.EccCounterType) (r0 nvml.EccErrorCounts, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDeviceHandleFromMigDeviceHandle(nvml.Device) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDisplayActive(nvml.Device) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDisplayMode(nvml.Device) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDramEncryptionMode(nvml.Device) (r0 nvml.DramEncryptionInfo, r1 nvml.DramEncryptionInfo, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDriverModel(nvml.Device) (r0 nvml.DriverModel, r1 nvml.DriverModel, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDriverModel_v2(nvml.Device) (r0 nvml.DriverModel, r1 nvml.DriverModel, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetDynamicPstatesInfo(nvml.Device) (r0 nvml.GpuDynamicPstatesInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetEccMode(nvml.Device) (r0 nvml.EnableState, r1 nvml.EnableState, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetEncoderCapacity(nvml.Device, nvml.EncoderType) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetEncoderSessions(nvml.Device) (r0 []nvml.EncoderSessionInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetEncoderStats(nvml.Device) (r0 int, r1 uint32, r2 uint32, r3 nvml.Return) {
+	return r0, r1, r2, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetEncoderUtilization(nvml.Device) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetEnforcedPowerLimit(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetFBCSessions(nvml.Device) (r0 []nvml.FBCSessionInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetFBCStats(nvml.Device) (r0 nvml.FBCStats, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetFanControlPolicy_v2(nvml.Device, int) (r0 nvml.FanControlPolicy, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetFanSpeed(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetFanSpeedRPM(nvml.Device) (r0 nvml.FanSpeedInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetFanSpeed_v2(nvml.Device, int) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetFieldValues(nvml.Device, []nvml.FieldValue) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpcClkMinMaxVfOffset(nvml.Device) (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpcClkVfOffset(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuFabricInfo(nvml.Device) (r0 nvml.GpuFabricInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuFabricInfoV(nvml.Device) (r0 nvml.GpuFabricInfoHandler) {
+	return r0
+}
+
+func (notSupportedInterface) DeviceGetGpuInstanceById(nvml.Device, int) (r0 nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuInstanceId(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuInstancePossiblePlacements(nvml.Device, *nvml.GpuInstanceProfileInfo) (r0 []nvml.GpuInstancePlacement, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuInstanceProfileInfo(nvml.Device, int) (r0 nvml.GpuInstanceProfileInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuInstanceProfileInfoByIdV(nvml.Device, int) (r0 nvml.GpuInstanceProfileInfoByIdHandler) {
+	return r0
+}
+
+func (notSupportedInterface) DeviceGetGpuInstanceProfileInfoV(nvml.Device, int) (r0 nvml.GpuInstanceProfileInfoHandler) {
+	return r0
+}
+
+func (notSupportedInterface) DeviceGetGpuInstanceRemainingCapacity(nvml.Device, *nvml.GpuInstanceProfileInfo) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuInstances(nvml.Device, *nvml.GpuInstanceProfileInfo) (r0 []nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuMaxPcieLinkGeneration(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGpuOperationMode(nvml.Device) (r0 nvml.GpuOperationMode, r1 nvml.GpuOperationMode, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGraphicsRunningProcesses(nvml.Device) (r0 []nvml.ProcessInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGridLicensableFeatures(nvml.Device) (r0 nvml.GridLicensableFeatures, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGspFirmwareMode(nvml.Device) (r0 bool, r1 bool, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetGspFirmwareVersion(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetHandleByIndex(int) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetHandleByPciBusId(string) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetHandleBySerial(string) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetHandleByUUID(string) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetHandleByUUIDV(*nvml.UUID) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetHostVgpuMode(nvml.Device) (r0 nvml.HostVgpuMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetHostname_v1(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetIndex(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetInforomConfigurationChecksum(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetInforomImageVersion(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetInforomVersion(nvml.Device, nvml.InforomObject) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetIrqNum(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetJpgUtilization(nvml.Device) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetLastBBXFlushTime(nvml.Device) (r0 uint64, r1 uint, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMPSComputeRunningProcesses(nvml.Device) (r0 []nvml.ProcessInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMarginTemperature(nvml.Device) (r0 nvml.MarginTemperature, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMaxClockInfo(nvml.Device, nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMaxCustomerBoostClock(nvml.Device, nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMaxMigDeviceCount(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMaxPcieLinkGeneration(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMaxPcieLinkWidth(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMemClkMinMaxVfOffset(nvml.Device) (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMemClkVfOffset(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMemoryAffinity(nvml.Device, int, nvml.AffinityScope) (r0 []uint, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMemoryBusWidth(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMemoryErrorCounter(nvml.Device, nvml.MemoryErrorType, nvml.EccCounterType, nvml.MemoryLocation) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMemoryInfo(nvml.Device) (r0 nvml.Memory, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMemoryInfo_v2(nvml.Device) (r0 nvml.Memory_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMigDeviceHandleByIndex(nvml.Device, int) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMigMode(nvml.Device) (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMinMaxClockOfPState(nvml.Device, nvml.ClockType, nvml.Pstates) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMinMaxFanSpeed(nvml.Device) (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMinorNumber(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetModuleId(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetMultiGpuBoard(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetName(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNumFans(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNumGpuCores(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNumaNodeId(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkCapability(nvml.Device, int, nvml.NvLinkCapability) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkErrorCounter(nvml.Device, int, nvml.NvLinkErrorCounter) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkInfo(nvml.Device) (r0 nvml.NvLinkInfoHandler) {
+	return r0
+}
+
+func (notSupportedInterface) DeviceGetNvLinkRemoteDeviceType(nvml.Device, int) (r0 nvml.IntNvLinkDeviceType, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkRemotePciInfo(nvml.Device, int) (r0 nvml.PciInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkState(nvml.Device, int) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkUtilizationControl(nvml.Device, int, int) (r0 nvml.NvLinkUtilizationControl, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkUtilizationCounter(nvml.Device, int, int) (r0 uint64, r1 uint64, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvLinkVersion(nvml.Device, int) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvlinkBwMode(nvml.Device) (r0 nvml.NvlinkGetBwMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetNvlinkSupportedBwModes(nvml.Device) (r0 nvml.NvlinkSupportedBwModes, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetOfaUtilization(nvml.Device) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetP2PStatus(nvml.Device, nvml.Device, nvml.GpuP2PCapsIndex) (r0 nvml.GpuP2PStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPciInfo(nvml.Device) (r0 nvml.PciInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPciInfoExt(nvml.Device) (r0 nvml.PciInfoExt, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPcieLinkMaxSpeed(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPcieReplayCounter(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPcieSpeed(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPcieThroughput(nvml.Device, nvml.PcieUtilCounter) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPdi(nvml.Device) (r0 nvml.Pdi, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPerformanceModes(nvml.Device) (r0 nvml.DevicePerfModes, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPerformanceState(nvml.Device) (r0 nvml.Pstates, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPersistenceMode(nvml.Device) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPgpuMetadataString(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPlatformInfo(nvml.Device) (r0 nvml.PlatformInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerManagementDefaultLimit(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerManagementLimit(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerManagementLimitConstraints(nvml.Device) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerManagementMode(nvml.Device) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerMizerMode_v1(nvml.Device) (r0 nvml.DevicePowerMizerModes_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerSource(nvml.Device) (r0 nvml.PowerSource, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerState(nvml.Device) (r0 nvml.Pstates, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetPowerUsage(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetProcessUtilization(nvml.Device, uint64) (r0 []nvml.ProcessUtilizationSample, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetProcessesUtilizationInfo(nvml.Device) (r0 nvml.ProcessesUtilizationInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRemappedRows(nvml.Device) (r0 int, r1 int, r2 bool, r3 bool, r4 nvml.Return) {
+	return r0, r1, r2, r3, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRemappedRows_v2(nvml.Device) (r0 nvml.RemappedRowsInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRepairStatus(nvml.Device) (r0 nvml.RepairStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRetiredPages(nvml.Device, nvml.PageRetirementCause) (r0 []uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRetiredPagesPendingStatus(nvml.Device) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRetiredPages_v2(nvml.Device, nvml.PageRetirementCause) (r0 []uint64, r1 []uint64, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRowRemapperHistogram(nvml.Device) (r0 nvml.RowRemapperHistogramValues, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetRunningProcessDetailList(nvml.Device) (r0 nvml.ProcessDetailList, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSamples(nvml.Device, nvml.SamplingType, uint64) (r0 nvml.ValueType, r1 []nvml.Sample, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSerial(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSramEccErrorStatus(nvml.Device) (r0 nvml.EccSramErrorStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSramUniqueUncorrectedEccErrorCounts(nvml.Device, *nvml.EccSramUniqueUncorrectedErrorCounts) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSupportedClocksEventReasons(nvml.Device) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSupportedClocksThrottleReasons(nvml.Device) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSupportedEventTypes(nvml.Device) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSupportedGraphicsClocks(nvml.Device, int) (r0 int, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSupportedMemoryClocks(nvml.Device) (r0 int, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSupportedPerformanceStates(nvml.Device) (r0 []nvml.Pstates, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetSupportedVgpus(nvml.Device) (r0 []nvml.VgpuTypeId, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTargetFanSpeed(nvml.Device, int) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTemperature(nvml.Device, nvml.TemperatureSensors) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTemperatureThreshold(nvml.Device, nvml.TemperatureThresholds) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTemperatureV(nvml.Device) (r0 nvml.TemperatureHandler) {
+	return r0
+}
+
+func (notSupportedInterface) DeviceGetThermalSettings(nvml.Device, uint32) (r0 nvml.GpuThermalSettings, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTopologyCommonAncestor(nvml.Device, nvml.Device) (r0 nvml.GpuTopologyLevel, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTopologyNearestGpus(nvml.Device, nvml.GpuTopologyLevel) (r0 []nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTotalEccErrors(nvml.Device, nvml.MemoryErrorType, nvml.EccCounterType) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetTotalEnergyConsumption(nvml.Device) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetUUID(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetUnrepairableMemoryFlag_v1(nvml.Device) (r0 nvml.UnrepairableMemoryStatus_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetUtilizationRates(nvml.Device) (r0 nvml.Utilization, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVbiosVersion(nvml.Device) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuCapabilities(nvml.Device, nvml.DeviceVgpuCapability) (r0 bool, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuHeterogeneousMode(nvml.Device) (r0 nvml.VgpuHeterogeneousMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuInstancesUtilizationInfo(nvml.Device) (r0 nvml.VgpuInstancesUtilizationInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuMetadata(nvml.Device) (r0 nvml.VgpuPgpuMetadata, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuProcessUtilization(nvml.Device, uint64) (r0 []nvml.VgpuProcessUtilizationSample, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuProcessesUtilizationInfo(nvml.Device) (r0 nvml.VgpuProcessesUtilizationInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuSchedulerCapabilities(nvml.Device) (r0 nvml.VgpuSchedulerCapabilities, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuSchedulerLog(nvml.Device) (r0 nvml.VgpuSchedulerLog, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuSchedulerLog_v2(nvml.Device, nvml.VgpuSchedulerLogInfo_v2) (r0 nvml.VgpuSchedulerLogInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuSchedulerState(nvml.Device) (r0 nvml.VgpuSchedulerGetState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuSchedulerState_v2(nvml.Device, nvml.VgpuSchedulerStateInfo_v2) (r0 nvml.VgpuSchedulerStateInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuTypeCreatablePlacements(nvml.Device, nvml.VgpuTypeId) (r0 nvml.VgpuPlacementList, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuTypeSupportedPlacements(nvml.Device, nvml.VgpuTypeId) (r0 nvml.VgpuPlacementList, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVgpuUtilization(nvml.Device, uint64) (r0 nvml.ValueType, r1 []nvml.VgpuInstanceUtilizationSample, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetViolationStatus(nvml.Device, nvml.PerfPolicyType) (r0 nvml.ViolationTime, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceGetVirtualizationMode(nvml.Device) (r0 nvml.GpuVirtualizationMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceIsMigDeviceHandle(nvml.Device) (r0 bool, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceModifyDrainState(*nvml.PciInfo, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceOnSameBoard(nvml.Device, nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DevicePowerSmoothingActivatePresetProfile(nvml.Device, *nvml.PowerSmoothingProfile) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DevicePowerSmoothingSetState(nvml.Device, *nvml.PowerSmoothingState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DevicePowerSmoothingUpdatePresetProfileParam(nvml.Device, *nvml.PowerSmoothingProfile) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceQueryDrainState(*nvml.PciInfo) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceReadPRMCounters_v1(nvml.Device, []nvml.PRMCounterId, int) (r0 []nvml.PRMCounter_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceReadWritePRM_v1(nvml.Device, *nvml.PRMTLV_v1) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceRegisterEvents(nvml.Device, uint64, nvml.EventSet) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceRemoveGpu(*nvml.PciInfo) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceRemoveGpu_v2(*nvml.PciInfo, nvml.DetachGpuState, nvml.PcieLinkState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceResetApplicationsClocks(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceResetGpuLockedClocks(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceResetMemoryLockedClocks(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceResetNvLinkErrorCounters(nvml.Device, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceResetNvLinkUtilizationCounter(nvml.Device, int, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetAPIRestriction(nvml.Device, nvml.RestrictedAPI, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetAccountingMode(nvml.Device, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetApplicationsClocks(nvml.Device, uint32, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetAutoBoostedClocksEnabled(nvml.Device, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetClockOffsets(nvml.Device, nvml.ClockOffset) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetComputeMode(nvml.Device, nvml.ComputeMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetConfComputeUnprotectedMemSize(nvml.Device, uint64) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetCpuAffinity(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetDefaultAutoBoostedClocksEnabled(nvml.Device, nvml.EnableState, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetDefaultFanSpeed_v2(nvml.Device, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetDramEncryptionMode(nvml.Device, *nvml.DramEncryptionInfo) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetDriverModel(nvml.Device, nvml.DriverModel, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetEccMode(nvml.Device, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetFanControlPolicy(nvml.Device, int, nvml.FanControlPolicy) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetFanSpeed_v2(nvml.Device, int, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetGpcClkVfOffset(nvml.Device, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetGpuLockedClocks(nvml.Device, uint32, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetGpuOperationMode(nvml.Device, nvml.GpuOperationMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetHostname_v1(nvml.Device, string) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetMemClkVfOffset(nvml.Device, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetMemoryLockedClocks(nvml.Device, uint32, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetMigMode(nvml.Device, int) (r0 nvml.Return, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetNvLinkDeviceLowPowerThreshold(nvml.Device, *nvml.NvLinkPowerThres) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetNvLinkUtilizationControl(nvml.Device, int, int, *nvml.NvLinkUtilizationControl, bool) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetNvlinkBwMode(nvml.Device, *nvml.NvlinkSetBwMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetPersistenceMode(nvml.Device, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetPowerManagementLimit(nvml.Device, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetPowerManagementLimit_v2(nvml.Device, *nvml.PowerValue_v2) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetRusdSettings_v1(nvml.Device, nvml.RusdSettings_v1) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetTemperatureThreshold(nvml.Device, nvml.TemperatureThresholds, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetVgpuCapabilities(nvml.Device, nvml.DeviceVgpuCapability, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetVgpuHeterogeneousMode(nvml.Device, nvml.VgpuHeterogeneousMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetVgpuSchedulerState(nvml.Device, *nvml.VgpuSchedulerSetState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetVgpuSchedulerState_v2(nvml.Device, *nvml.VgpuSchedulerState_v2) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceSetVirtualizationMode(nvml.Device, nvml.GpuVirtualizationMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceValidateInforom(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceVgpuForceGspUnload(nvml.Device) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceWorkloadPowerProfileClearRequestedProfiles(nvml.Device, *nvml.WorkloadPowerProfileRequestedProfiles) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceWorkloadPowerProfileGetCurrentProfiles(nvml.Device) (r0 nvml.WorkloadPowerProfileCurrentProfiles, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceWorkloadPowerProfileGetProfilesInfo(nvml.Device) (r0 nvml.WorkloadPowerProfileProfilesInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceWorkloadPowerProfileSetRequestedProfiles(nvml.Device, *nvml.WorkloadPowerProfileRequestedProfiles) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) DeviceWorkloadPowerProfileUpdateProfiles_v1(nvml.Device, nvml.PowerProfileOperation, []nvml.PowerProfileType) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) ErrorString(nvml.Return) (r0 string) {
+	return r0
+}
+
+func (notSupportedInterface) EventSetCreate() (r0 nvml.EventSet, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) EventSetFree(nvml.EventSet) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) EventSetWait(nvml.EventSet, uint32) (r0 nvml.EventData, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) Extensions() (r0 nvml.ExtendedInterface) {
+	return r0
+}
+
+func (notSupportedInterface) GetExcludedDeviceCount() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GetExcludedDeviceInfoByIndex(int) (r0 nvml.ExcludedDeviceInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GetVgpuCompatibility(*nvml.VgpuMetadata, *nvml.VgpuPgpuMetadata) (r0 nvml.VgpuPgpuCompatibility, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GetVgpuDriverCapabilities(nvml.VgpuDriverCapability) (r0 bool, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GetVgpuVersion() (r0 nvml.VgpuVersion, r1 nvml.VgpuVersion, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmMetricsGet(*nvml.GpmMetricsGetType) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmMetricsGetV(*nvml.GpmMetricsGetType) (r0 nvml.GpmMetricsGetVType) {
+	return r0
+}
+
+func (notSupportedInterface) GpmMigSampleGet(nvml.Device, int, nvml.GpmSample) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmQueryDeviceSupport(nvml.Device) (r0 nvml.GpmSupport, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmQueryDeviceSupportV(nvml.Device) (r0 nvml.GpmSupportV) {
+	return r0
+}
+
+func (notSupportedInterface) GpmQueryIfStreamingEnabled(nvml.Device) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmSampleAlloc() (r0 nvml.GpmSample, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmSampleFree(nvml.GpmSample) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmSampleGet(nvml.Device, nvml.GpmSample) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpmSetStreamingEnabled(nvml.Device, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceCreateComputeInstance(nvml.GpuInstance, *nvml.ComputeInstanceProfileInfo) (r0 nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceCreateComputeInstanceWithPlacement(nvml.GpuInstance, *nvml.ComputeInstanceProfileInfo, *nvml.ComputeInstancePlacement) (r0 nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceDestroy(nvml.GpuInstance) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetActiveVgpus(nvml.GpuInstance) (r0 nvml.ActiveVgpuInstanceInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetComputeInstanceById(nvml.GpuInstance, int) (r0 nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetComputeInstancePossiblePlacements(nvml.GpuInstance, *nvml.ComputeInstanceProfileInfo) (r0 []nvml.ComputeInstancePlacement, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetComputeInstanceProfileInfo(nvml.GpuInstance, int, int) (r0 nvml.ComputeInstanceProfileInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetComputeInstanceProfileInfoV(nvml.GpuInstance, int, int) (r0 nvml.ComputeInstanceProfileInfoHandler) {
+	return r0
+}
+
+func (notSupportedInterface) GpuInstanceGetComputeInstanceRemainingCapacity(nvml.GpuInstance, *nvml.ComputeInstanceProfileInfo) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetComputeInstances(nvml.GpuInstance, *nvml.ComputeInstanceProfileInfo) (r0 []nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetCreatableVgpus(nvml.GpuInstance) (r0 nvml.VgpuTypeIdInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetInfo(nvml.GpuInstance) (r0 nvml.GpuInstanceInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetVgpuHeterogeneousMode(nvml.GpuInstance) (r0 nvml.VgpuHeterogeneousMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetVgpuSchedulerLog(nvml.GpuInstance) (r0 nvml.VgpuSchedulerLogInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetVgpuSchedulerLog_v2(nvml.GpuInstance, nvml.VgpuSchedulerLogInfo_v2) (r0 nvml.VgpuSchedulerLogInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetVgpuSchedulerState(nvml.GpuInstance) (r0 nvml.VgpuSchedulerStateInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetVgpuSchedulerState_v2(nvml.GpuInstance, nvml.VgpuSchedulerStateInfo_v2) (r0 nvml.VgpuSchedulerStateInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceGetVgpuTypeCreatablePlacements(nvml.GpuInstance) (r0 nvml.VgpuCreatablePlacementInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceSetVgpuHeterogeneousMode(nvml.GpuInstance, *nvml.VgpuHeterogeneousMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceSetVgpuSchedulerState(nvml.GpuInstance, *nvml.VgpuSchedulerState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) GpuInstanceSetVgpuSchedulerState_v2(nvml.GpuInstance, *nvml.VgpuSchedulerState_v2) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) Init() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) InitWithFlags(uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SetVgpuVersion(*nvml.VgpuVersion) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) Shutdown() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemEventSetCreate(*nvml.SystemEventSetCreateRequest) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemEventSetFree(*nvml.SystemEventSetFreeRequest) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemEventSetWait(*nvml.SystemEventSetWaitRequest) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetCPER_v1(*nvml.GetCPER_v1) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetConfComputeCapabilities() (r0 nvml.ConfComputeSystemCaps, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetConfComputeGpusReadyState() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetConfComputeKeyRotationThresholdInfo() (r0 nvml.ConfComputeGetKeyRotationThresholdInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetConfComputeSettings() (r0 nvml.SystemConfComputeSettings, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetConfComputeState() (r0 nvml.ConfComputeSystemState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetCudaDriverVersion() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetCudaDriverVersion_v2() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetDriverBranch() (r0 nvml.SystemDriverBranchInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetDriverVersion() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetHicVersion() (r0 []nvml.HwbcEntry, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetNVMLVersion() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetNvlinkBwMode() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetProcessName(int) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemGetTopologyGpuSet(int) (r0 []nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemRegisterEvents(*nvml.SystemRegisterEventRequest) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemSetConfComputeGpusReadyState(uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemSetConfComputeKeyRotationThresholdInfo(nvml.ConfComputeSetKeyRotationThresholdInfo) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) SystemSetNvlinkBwMode(uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetCount() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetDevices(nvml.Unit) (r0 []nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetFanSpeedInfo(nvml.Unit) (r0 nvml.UnitFanSpeeds, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetHandleByIndex(int) (r0 nvml.Unit, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetLedState(nvml.Unit) (r0 nvml.LedState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetPsuInfo(nvml.Unit) (r0 nvml.PSUInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetTemperature(nvml.Unit, int) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitGetUnitInfo(nvml.Unit) (r0 nvml.UnitInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) UnitSetLedState(nvml.Unit, nvml.LedColor) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceClearAccountingPids(nvml.VgpuInstance) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetAccountingMode(nvml.VgpuInstance) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetAccountingPids(nvml.VgpuInstance) (r0 []int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetAccountingStats(nvml.VgpuInstance, int) (r0 nvml.AccountingStats, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetEccMode(nvml.VgpuInstance) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetEncoderCapacity(nvml.VgpuInstance) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetEncoderSessions(nvml.VgpuInstance) (r0 int, r1 nvml.EncoderSessionInfo, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetEncoderStats(nvml.VgpuInstance) (r0 int, r1 uint32, r2 uint32, r3 nvml.Return) {
+	return r0, r1, r2, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetFBCSessions(nvml.VgpuInstance) (r0 int, r1 nvml.FBCSessionInfo, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetFBCStats(nvml.VgpuInstance) (r0 nvml.FBCStats, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetFbUsage(nvml.VgpuInstance) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetFrameRateLimit(nvml.VgpuInstance) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetGpuInstanceId(nvml.VgpuInstance) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetGpuPciId(nvml.VgpuInstance) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetLicenseInfo(nvml.VgpuInstance) (r0 nvml.VgpuLicenseInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetLicenseStatus(nvml.VgpuInstance) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetMdevUUID(nvml.VgpuInstance) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetMetadata(nvml.VgpuInstance) (r0 nvml.VgpuMetadata, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetRuntimeStateSize(nvml.VgpuInstance) (r0 nvml.VgpuRuntimeState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetType(nvml.VgpuInstance) (r0 nvml.VgpuTypeId, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetUUID(nvml.VgpuInstance) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetVmDriverVersion(nvml.VgpuInstance) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceGetVmID(nvml.VgpuInstance) (r0 string, r1 nvml.VgpuVmIdType, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuInstanceSetEncoderCapacity(nvml.VgpuInstance, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetBAR1Info(nvml.VgpuTypeId) (r0 nvml.VgpuTypeBar1Info, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetCapabilities(nvml.VgpuTypeId, nvml.VgpuCapability) (r0 bool, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetClass(nvml.VgpuTypeId) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetDeviceID(nvml.VgpuTypeId) (r0 uint64, r1 uint64, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetFrameRateLimit(nvml.VgpuTypeId) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetFramebufferSize(nvml.VgpuTypeId) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetGpuInstanceProfileId(nvml.VgpuTypeId) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetLicense(nvml.VgpuTypeId) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetMaxInstances(nvml.Device, nvml.VgpuTypeId) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetMaxInstancesPerGpuInstance(*nvml.VgpuTypeMaxInstance) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetMaxInstancesPerVm(nvml.VgpuTypeId) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetName(nvml.VgpuTypeId) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetNumDisplayHeads(nvml.VgpuTypeId) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedInterface) VgpuTypeGetResolution(nvml.VgpuTypeId, int) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+// notSupportedDevice implements nvml.Device with every method returning
+// nvml.ERROR_NOT_SUPPORTED where an nvml.Return is returned.
+type notSupportedDevice struct{}
+
+var _ nvml.Device = (*notSupportedDevice)(nil)
+
+func (notSupportedDevice) ClearAccountingPids() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ClearCpuAffinity() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ClearEccErrorCounts(nvml.EccCounterType) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ClearFieldValues([]nvml.FieldValue) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) CreateGpuInstance(*nvml.GpuInstanceProfileInfo) (r0 nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) CreateGpuInstanceWithPlacement(*nvml.GpuInstanceProfileInfo, *nvml.GpuInstancePlacement) (r0 nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) FreezeNvLinkUtilizationCounter(int, int, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAPIRestriction(nvml.RestrictedAPI) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAccountingBufferSize() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAccountingMode() (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAccountingPids() (r0 []int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAccountingStats(uint32) (r0 nvml.AccountingStats, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAccountingStats_v2(uint32) (r0 nvml.AccountingStats_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetActiveVgpus() (r0 []nvml.VgpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAdaptiveClockInfoStatus() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAddressingMode() (r0 nvml.DeviceAddressingMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetApplicationsClock(nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetArchitecture() (r0 nvml.DeviceArchitecture, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAttributes() (r0 nvml.DeviceAttributes, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetAutoBoostedClocksEnabled() (r0 nvml.EnableState, r1 nvml.EnableState, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetBAR1MemoryInfo() (r0 nvml.BAR1Memory, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetBBXTimeData_v1() (r0 nvml.BBXTimeData_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetBoardId() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetBoardPartNumber() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetBrand() (r0 nvml.BrandType, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetBridgeChipInfo() (r0 nvml.BridgeChipHierarchy, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetBusType() (r0 nvml.BusType, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetC2cModeInfoV() (r0 nvml.C2cModeInfoHandler) {
+	return r0
+}
+
+func (notSupportedDevice) GetCapabilities() (r0 nvml.DeviceCapabilities, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetClkMonStatus() (r0 nvml.ClkMonStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetClock(nvml.ClockType, nvml.ClockId) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetClockInfo(nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetClockOffsets() (r0 nvml.ClockOffset, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetComputeInstanceId() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetComputeMode() (r0 nvml.ComputeMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetComputeRunningProcesses() (r0 []nvml.ProcessInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetConfComputeGpuAttestationReport(*nvml.ConfComputeGpuAttestationReport) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetConfComputeGpuCertificate() (r0 nvml.ConfComputeGpuCertificate, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetConfComputeMemSizeInfo() (r0 nvml.ConfComputeMemSizeInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetConfComputeProtectedMemoryUsage() (r0 nvml.Memory, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCoolerInfo() (r0 nvml.CoolerInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCpuAffinity(int) (r0 []uint, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCpuAffinityWithinScope(int, nvml.AffinityScope) (r0 []uint, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCreatableVgpus() (r0 []nvml.VgpuTypeId, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCudaComputeCapability() (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCurrPcieLinkGeneration() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCurrPcieLinkWidth() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCurrentClockFreqs() (r0 nvml.DeviceCurrentClockFreqs, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCurrentClocksEventReasons() (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetCurrentClocksThrottleReasons() (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDecoderUtilization() (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDefaultApplicationsClock(nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDefaultEccMode() (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDetailedEccErrors(nvml.MemoryErrorType, nvml.EccCounterType) (r0 nvml.EccErrorCounts, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDeviceHandleFromMigDeviceHandle() (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDisplayActive() (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDisplayMode() (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDramEncryptionMode() (r0 nvml.DramEncryptionInfo, r1 nvml.DramEncryptionInfo, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDriverModel() (r0 nvml.DriverModel, r1 nvml.DriverModel, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDriverModel_v2() (r0 nvml.DriverModel, r1 nvml.DriverModel, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetDynamicPstatesInfo() (r0 nvml.GpuDynamicPstatesInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetEccMode() (r0 nvml.EnableState, r1 nvml.EnableState, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetEncoderCapacity(nvml.EncoderType) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetEncoderSessions() (r0 []nvml.EncoderSessionInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetEncoderStats() (r0 int, r1 uint32, r2 uint32, r3 nvml.Return) {
+	return r0, r1, r2, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetEncoderUtilization() (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetEnforcedPowerLimit() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetFBCSessions() (r0 []nvml.FBCSessionInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetFBCStats() (r0 nvml.FBCStats, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetFanControlPolicy_v2(int) (r0 nvml.FanControlPolicy, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetFanSpeed() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetFanSpeedRPM() (r0 nvml.FanSpeedInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetFanSpeed_v2(int) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetFieldValues([]nvml.FieldValue) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpcClkMinMaxVfOffset() (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpcClkVfOffset() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuFabricInfo() (r0 nvml.GpuFabricInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuFabricInfoV() (r0 nvml.GpuFabricInfoHandler) {
+	return r0
+}
+
+func (notSupportedDevice) GetGpuInstanceById(int) (r0 nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuInstanceId() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuInstancePossiblePlacements(*nvml.GpuInstanceProfileInfo) (r0 []nvml.GpuInstancePlacement, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuInstanceProfileInfo(int) (r0 nvml.GpuInstanceProfileInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuInstanceProfileInfoByIdV(int) (r0 nvml.GpuInstanceProfileInfoByIdHandler) {
+	return r0
+}
+
+func (notSupportedDevice) GetGpuInstanceProfileInfoV(int) (r0 nvml.GpuInstanceProfileInfoHandler) {
+	return r0
+}
+
+func (notSupportedDevice) GetGpuInstanceRemainingCapacity(*nvml.GpuInstanceProfileInfo) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuInstances(*nvml.GpuInstanceProfileInfo) (r0 []nvml.GpuInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuMaxPcieLinkGeneration() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGpuOperationMode() (r0 nvml.GpuOperationMode, r1 nvml.GpuOperationMode, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGraphicsRunningProcesses() (r0 []nvml.ProcessInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGridLicensableFeatures() (r0 nvml.GridLicensableFeatures, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGspFirmwareMode() (r0 bool, r1 bool, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetGspFirmwareVersion() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetHostVgpuMode() (r0 nvml.HostVgpuMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetHostname_v1() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetIndex() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetInforomConfigurationChecksum() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetInforomImageVersion() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetInforomVersion(nvml.InforomObject) (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetIrqNum() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetJpgUtilization() (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetLastBBXFlushTime() (r0 uint64, r1 uint, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMPSComputeRunningProcesses() (r0 []nvml.ProcessInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMarginTemperature() (r0 nvml.MarginTemperature, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMaxClockInfo(nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMaxCustomerBoostClock(nvml.ClockType) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMaxMigDeviceCount() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMaxPcieLinkGeneration() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMaxPcieLinkWidth() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMemClkMinMaxVfOffset() (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMemClkVfOffset() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMemoryAffinity(int, nvml.AffinityScope) (r0 []uint, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMemoryBusWidth() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMemoryErrorCounter(nvml.MemoryErrorType, nvml.EccCounterType, nvml.MemoryLocation) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMemoryInfo() (r0 nvml.Memory, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMemoryInfo_v2() (r0 nvml.Memory_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMigDeviceHandleByIndex(int) (r0 nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMigMode() (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMinMaxClockOfPState(nvml.ClockType, nvml.Pstates) (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMinMaxFanSpeed() (r0 int, r1 int, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMinorNumber() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetModuleId() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetMultiGpuBoard() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetName() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNumFans() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNumGpuCores() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNumaNodeId() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkCapability(int, nvml.NvLinkCapability) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkErrorCounter(int, nvml.NvLinkErrorCounter) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkInfo() (r0 nvml.NvLinkInfoHandler) {
+	return r0
+}
+
+func (notSupportedDevice) GetNvLinkRemoteDeviceType(int) (r0 nvml.IntNvLinkDeviceType, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkRemotePciInfo(int) (r0 nvml.PciInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkState(int) (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkUtilizationControl(int, int) (r0 nvml.NvLinkUtilizationControl, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkUtilizationCounter(int, int) (r0 uint64, r1 uint64, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvLinkVersion(int) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvlinkBwMode() (r0 nvml.NvlinkGetBwMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetNvlinkSupportedBwModes() (r0 nvml.NvlinkSupportedBwModes, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetOfaUtilization() (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetP2PStatus(nvml.Device, nvml.GpuP2PCapsIndex) (r0 nvml.GpuP2PStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPciInfo() (r0 nvml.PciInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPciInfoExt() (r0 nvml.PciInfoExt, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPcieLinkMaxSpeed() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPcieReplayCounter() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPcieSpeed() (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPcieThroughput(nvml.PcieUtilCounter) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPdi() (r0 nvml.Pdi, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPerformanceModes() (r0 nvml.DevicePerfModes, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPerformanceState() (r0 nvml.Pstates, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPersistenceMode() (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPgpuMetadataString() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPlatformInfo() (r0 nvml.PlatformInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerManagementDefaultLimit() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerManagementLimit() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerManagementLimitConstraints() (r0 uint32, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerManagementMode() (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerMizerMode_v1() (r0 nvml.DevicePowerMizerModes_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerSource() (r0 nvml.PowerSource, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerState() (r0 nvml.Pstates, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetPowerUsage() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetProcessUtilization(uint64) (r0 []nvml.ProcessUtilizationSample, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetProcessesUtilizationInfo() (r0 nvml.ProcessesUtilizationInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRemappedRows() (r0 int, r1 int, r2 bool, r3 bool, r4 nvml.Return) {
+	return r0, r1, r2, r3, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRemappedRows_v2() (r0 nvml.RemappedRowsInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRepairStatus() (r0 nvml.RepairStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRetiredPages(nvml.PageRetirementCause) (r0 []uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRetiredPagesPendingStatus() (r0 nvml.EnableState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRetiredPages_v2(nvml.PageRetirementCause) (r0 []uint64, r1 []uint64, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRowRemapperHistogram() (r0 nvml.RowRemapperHistogramValues, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetRunningProcessDetailList() (r0 nvml.ProcessDetailList, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSamples(nvml.SamplingType, uint64) (r0 nvml.ValueType, r1 []nvml.Sample, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSerial() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSramEccErrorStatus() (r0 nvml.EccSramErrorStatus, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSramUniqueUncorrectedEccErrorCounts(*nvml.EccSramUniqueUncorrectedErrorCounts) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSupportedClocksEventReasons() (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSupportedClocksThrottleReasons() (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSupportedEventTypes() (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSupportedGraphicsClocks(int) (r0 int, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSupportedMemoryClocks() (r0 int, r1 uint32, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSupportedPerformanceStates() (r0 []nvml.Pstates, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetSupportedVgpus() (r0 []nvml.VgpuTypeId, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTargetFanSpeed(int) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTemperature(nvml.TemperatureSensors) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTemperatureThreshold(nvml.TemperatureThresholds) (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTemperatureV() (r0 nvml.TemperatureHandler) {
+	return r0
+}
+
+func (notSupportedDevice) GetThermalSettings(uint32) (r0 nvml.GpuThermalSettings, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTopologyCommonAncestor(nvml.Device) (r0 nvml.GpuTopologyLevel, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTopologyNearestGpus(nvml.GpuTopologyLevel) (r0 []nvml.Device, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTotalEccErrors(nvml.MemoryErrorType, nvml.EccCounterType) (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetTotalEnergyConsumption() (r0 uint64, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetUUID() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetUnrepairableMemoryFlag_v1() (r0 nvml.UnrepairableMemoryStatus_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetUtilizationRates() (r0 nvml.Utilization, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVbiosVersion() (r0 string, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuCapabilities(nvml.DeviceVgpuCapability) (r0 bool, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuHeterogeneousMode() (r0 nvml.VgpuHeterogeneousMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuInstancesUtilizationInfo() (r0 nvml.VgpuInstancesUtilizationInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuMetadata() (r0 nvml.VgpuPgpuMetadata, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuProcessUtilization(uint64) (r0 []nvml.VgpuProcessUtilizationSample, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuProcessesUtilizationInfo() (r0 nvml.VgpuProcessesUtilizationInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuSchedulerCapabilities() (r0 nvml.VgpuSchedulerCapabilities, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuSchedulerLog() (r0 nvml.VgpuSchedulerLog, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuSchedulerLog_v2(nvml.VgpuSchedulerLogInfo_v2) (r0 nvml.VgpuSchedulerLogInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuSchedulerState() (r0 nvml.VgpuSchedulerGetState, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuSchedulerState_v2(nvml.VgpuSchedulerStateInfo_v2) (r0 nvml.VgpuSchedulerStateInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuTypeCreatablePlacements(nvml.VgpuTypeId) (r0 nvml.VgpuPlacementList, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuTypeSupportedPlacements(nvml.VgpuTypeId) (r0 nvml.VgpuPlacementList, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVgpuUtilization(uint64) (r0 nvml.ValueType, r1 []nvml.VgpuInstanceUtilizationSample, r2 nvml.Return) {
+	return r0, r1, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetViolationStatus(nvml.PerfPolicyType) (r0 nvml.ViolationTime, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GetVirtualizationMode() (r0 nvml.GpuVirtualizationMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GpmMigSampleGet(int, nvml.GpmSample) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GpmQueryDeviceSupport() (r0 nvml.GpmSupport, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GpmQueryDeviceSupportV() (r0 nvml.GpmSupportV) {
+	return r0
+}
+
+func (notSupportedDevice) GpmQueryIfStreamingEnabled() (r0 uint32, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GpmSampleGet(nvml.GpmSample) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) GpmSetStreamingEnabled(uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) IsMigDeviceHandle() (r0 bool, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) OnSameBoard(nvml.Device) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) PowerSmoothingActivatePresetProfile(*nvml.PowerSmoothingProfile) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) PowerSmoothingSetState(*nvml.PowerSmoothingState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) PowerSmoothingUpdatePresetProfileParam(*nvml.PowerSmoothingProfile) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ReadPRMCounters_v1([]nvml.PRMCounterId, int) (r0 []nvml.PRMCounter_v1, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ReadWritePRM_v1(*nvml.PRMTLV_v1) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) RegisterEvents(uint64, nvml.EventSet) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ResetApplicationsClocks() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ResetGpuLockedClocks() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ResetMemoryLockedClocks() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ResetNvLinkErrorCounters(int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ResetNvLinkUtilizationCounter(int, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetAPIRestriction(nvml.RestrictedAPI, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetAccountingMode(nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetApplicationsClocks(uint32, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetAutoBoostedClocksEnabled(nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetClockOffsets(nvml.ClockOffset) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetComputeMode(nvml.ComputeMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetConfComputeUnprotectedMemSize(uint64) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetCpuAffinity() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetDefaultAutoBoostedClocksEnabled(nvml.EnableState, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetDefaultFanSpeed_v2(int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetDramEncryptionMode(*nvml.DramEncryptionInfo) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetDriverModel(nvml.DriverModel, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetEccMode(nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetFanControlPolicy(int, nvml.FanControlPolicy) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetFanSpeed_v2(int, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetGpcClkVfOffset(int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetGpuLockedClocks(uint32, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetGpuOperationMode(nvml.GpuOperationMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetHostname_v1(string) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetMemClkVfOffset(int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetMemoryLockedClocks(uint32, uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetMigMode(int) (r0 nvml.Return, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetNvLinkDeviceLowPowerThreshold(*nvml.NvLinkPowerThres) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetNvLinkUtilizationControl(int, int, *nvml.NvLinkUtilizationControl, bool) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetNvlinkBwMode(*nvml.NvlinkSetBwMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetPersistenceMode(nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetPowerManagementLimit(uint32) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetPowerManagementLimit_v2(*nvml.PowerValue_v2) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetRusdSettings_v1(nvml.RusdSettings_v1) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetTemperatureThreshold(nvml.TemperatureThresholds, int) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetVgpuCapabilities(nvml.DeviceVgpuCapability, nvml.EnableState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetVgpuHeterogeneousMode(nvml.VgpuHeterogeneousMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetVgpuSchedulerState(*nvml.VgpuSchedulerSetState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetVgpuSchedulerState_v2(*nvml.VgpuSchedulerState_v2) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) SetVirtualizationMode(nvml.GpuVirtualizationMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) ValidateInforom() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) VgpuForceGspUnload() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) VgpuTypeGetMaxInstances(nvml.VgpuTypeId) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) WorkloadPowerProfileClearRequestedProfiles(*nvml.WorkloadPowerProfileRequestedProfiles) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) WorkloadPowerProfileGetCurrentProfiles() (r0 nvml.WorkloadPowerProfileCurrentProfiles, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) WorkloadPowerProfileGetProfilesInfo() (r0 nvml.WorkloadPowerProfileProfilesInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) WorkloadPowerProfileSetRequestedProfiles(*nvml.WorkloadPowerProfileRequestedProfiles) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedDevice) WorkloadPowerProfileUpdateProfiles_v1(nvml.PowerProfileOperation, []nvml.PowerProfileType) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+// notSupportedGpuInstance implements nvml.GpuInstance with every method returning
+// nvml.ERROR_NOT_SUPPORTED where an nvml.Return is returned.
+type notSupportedGpuInstance struct{}
+
+var _ nvml.GpuInstance = (*notSupportedGpuInstance)(nil)
+
+func (notSupportedGpuInstance) CreateComputeInstance(*nvml.ComputeInstanceProfileInfo) (r0 nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) CreateComputeInstanceWithPlacement(*nvml.ComputeInstanceProfileInfo, *nvml.ComputeInstancePlacement) (r0 nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) Destroy() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetActiveVgpus() (r0 nvml.ActiveVgpuInstanceInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetComputeInstanceById(int) (r0 nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetComputeInstancePossiblePlacements(*nvml.ComputeInstanceProfileInfo) (r0 []nvml.ComputeInstancePlacement, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetComputeInstanceProfileInfo(int, int) (r0 nvml.ComputeInstanceProfileInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetComputeInstanceProfileInfoV(int, int) (r0 nvml.ComputeInstanceProfileInfoHandler) {
+	return r0
+}
+
+func (notSupportedGpuInstance) GetComputeInstanceRemainingCapacity(*nvml.ComputeInstanceProfileInfo) (r0 int, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetComputeInstances(*nvml.ComputeInstanceProfileInfo) (r0 []nvml.ComputeInstance, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetCreatableVgpus() (r0 nvml.VgpuTypeIdInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetInfo() (r0 nvml.GpuInstanceInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetVgpuHeterogeneousMode() (r0 nvml.VgpuHeterogeneousMode, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetVgpuSchedulerLog() (r0 nvml.VgpuSchedulerLogInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetVgpuSchedulerLog_v2(nvml.VgpuSchedulerLogInfo_v2) (r0 nvml.VgpuSchedulerLogInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetVgpuSchedulerState() (r0 nvml.VgpuSchedulerStateInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetVgpuSchedulerState_v2(nvml.VgpuSchedulerStateInfo_v2) (r0 nvml.VgpuSchedulerStateInfo_v2, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) GetVgpuTypeCreatablePlacements() (r0 nvml.VgpuCreatablePlacementInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) SetVgpuHeterogeneousMode(*nvml.VgpuHeterogeneousMode) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) SetVgpuSchedulerState(*nvml.VgpuSchedulerState) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedGpuInstance) SetVgpuSchedulerState_v2(*nvml.VgpuSchedulerState_v2) (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+// notSupportedComputeInstance implements nvml.ComputeInstance with every method returning
+// nvml.ERROR_NOT_SUPPORTED where an nvml.Return is returned.
+type notSupportedComputeInstance struct{}
+
+var _ nvml.ComputeInstance = (*notSupportedComputeInstance)(nil)
+
+func (notSupportedComputeInstance) Destroy() (r0 nvml.Return) {
+	return nvml.ERROR_NOT_SUPPORTED
+}
+
+func (notSupportedComputeInstance) GetInfo() (r0 nvml.ComputeInstanceInfo, r1 nvml.Return) {
+	return r0, nvml.ERROR_NOT_SUPPORTED
+}
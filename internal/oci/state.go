@@ -56,6 +56,39 @@ func ReadContainerState(reader io.Reader) (*State, error) {
 	return &s, nil
 }
 
+// ResolveContainerRoot returns the root filesystem of a container. If the
+// specified root is not empty, it is returned. Otherwise the OCI container
+// state that is passed to OCI hooks is loaded from the specified file (or STDIN
+// if this is empty or '-') and the root is determined from the spec in the
+// bundle referenced by the state.
+func ResolveContainerRoot(root string, stateFilename string) (string, error) {
+	if root != "" {
+		return root, nil
+	}
+
+	s, err := LoadContainerState(stateFilename)
+	if err != nil {
+		return "", fmt.Errorf("failed to load container state: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return "", fmt.Errorf("invalid container state: %w", err)
+	}
+
+	return s.GetContainerRoot()
+}
+
+// Validate checks that the container state includes the fields required to
+// locate the container bundle.
+func (s *State) Validate() error {
+	if s.Bundle == "" {
+		return fmt.Errorf("missing bundle path")
+	}
+	if !filepath.IsAbs(s.Bundle) {
+		return fmt.Errorf("bundle path %q is not absolute", s.Bundle)
+	}
+	return nil
+}
+
 // GetContainerRoot returns the root for the container from the associated spec. If the spec is not yet loaded, it is
 // loaded and cached.
 func (s *State) GetContainerRoot() (string, error) {
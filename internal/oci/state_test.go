@@ -0,0 +1,80 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveContainerRoot(t *testing.T) {
+	bundle := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bundle, "config.json"), []byte(`{"root": {"path": "rootfs"}}`), 0600))
+
+	testCases := []struct {
+		description   string
+		root          string
+		state         string
+		expectedRoot  string
+		expectedError string
+	}{
+		{
+			description:  "explicit root is used",
+			root:         "/container/root",
+			state:        `not json`,
+			expectedRoot: "/container/root",
+		},
+		{
+			description:  "root is determined from the bundle",
+			state:        `{"ociVersion": "1.0.2", "id": "test", "bundle": "` + bundle + `"}`,
+			expectedRoot: filepath.Join(bundle, "rootfs"),
+		},
+		{
+			description:   "invalid json is rejected",
+			state:         `not json`,
+			expectedError: "failed to load container state: failed to decode container state: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			description:   "missing bundle is rejected",
+			state:         `{"ociVersion": "1.0.2", "id": "test"}`,
+			expectedError: "invalid container state: missing bundle path",
+		},
+		{
+			description:   "relative bundle is rejected",
+			state:         `{"ociVersion": "1.0.2", "id": "test", "bundle": "bundle"}`,
+			expectedError: `invalid container state: bundle path "bundle" is not absolute`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			stateFile := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(stateFile, []byte(tc.state), 0600))
+
+			root, err := ResolveContainerRoot(tc.root, stateFile)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedRoot, root)
+		})
+	}
+}
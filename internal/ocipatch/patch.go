@@ -0,0 +1,147 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ocipatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	ocispecs "github.com/opencontainers/runtime-spec/specs-go"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+// A Patch is the subset of an OCI runtime specification that is modified to
+// make the requested devices available in a container. The lists in a patch
+// are intended to be appended to the corresponding lists of a container's
+// config.json.
+type Patch struct {
+	Process *ProcessPatch    `json:"process,omitempty"`
+	Mounts  []ocispecs.Mount `json:"mounts,omitempty"`
+	Hooks   *ocispecs.Hooks  `json:"hooks,omitempty"`
+	Linux   *LinuxPatch      `json:"linux,omitempty"`
+}
+
+// ProcessPatch is the subset of the OCI process configuration included in a
+// patch.
+type ProcessPatch struct {
+	Env []string `json:"env,omitempty"`
+}
+
+// LinuxPatch is the subset of the OCI linux configuration included in a
+// patch.
+type LinuxPatch struct {
+	Devices   []ocispecs.LinuxDevice `json:"devices,omitempty"`
+	Resources *ResourcesPatch        `json:"resources,omitempty"`
+}
+
+// ResourcesPatch is the subset of the OCI linux resources included in a
+// patch.
+type ResourcesPatch struct {
+	Devices []ocispecs.LinuxDeviceCgroup `json:"devices,omitempty"`
+}
+
+// Generate applies the common container edits and the container edits of the
+// specified devices to an empty OCI runtime specification and returns the
+// resulting modifications. Since the common edits and the device edits may
+// include the same hooks, duplicate hooks are only included once.
+func Generate(lib nvcdi.Interface, devices ...string) (*Patch, error) {
+	edits, err := lib.GetCommonEdits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get common container edits: %w", err)
+	}
+
+	deviceSpecs, err := lib.GetDeviceSpecsByID(devices...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device specs: %w", err)
+	}
+	for _, d := range deviceSpecs {
+		edits.Append(&cdi.ContainerEdits{ContainerEdits: &d.ContainerEdits})
+	}
+	if edits.ContainerEdits != nil {
+		edits.Hooks = uniqueHooks(edits.Hooks)
+	}
+
+	spec := &ocispecs.Spec{}
+	if err := edits.Apply(spec); err != nil {
+		return nil, fmt.Errorf("failed to apply container edits: %w", err)
+	}
+
+	return newPatch(spec), nil
+}
+
+// newPatch extracts the patch from an OCI runtime specification to which only
+// the container edits have been applied.
+func newPatch(spec *ocispecs.Spec) *Patch {
+	p := &Patch{
+		Mounts: spec.Mounts,
+		Hooks:  spec.Hooks,
+	}
+	if spec.Process != nil && len(spec.Process.Env) > 0 {
+		p.Process = &ProcessPatch{
+			Env: spec.Process.Env,
+		}
+	}
+	if spec.Linux != nil {
+		l := &LinuxPatch{
+			Devices: spec.Linux.Devices,
+		}
+		if spec.Linux.Resources != nil && len(spec.Linux.Resources.Devices) > 0 {
+			l.Resources = &ResourcesPatch{
+				Devices: spec.Linux.Resources.Devices,
+			}
+		}
+		if len(l.Devices) > 0 || l.Resources != nil {
+			p.Linux = l
+		}
+	}
+	return p
+}
+
+// Write outputs the patch as indented JSON to the specified writer.
+func (p *Patch) Write(w io.Writer) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write patch: %w", err)
+	}
+	return nil
+}
+
+func uniqueHooks(hooks []*specs.Hook) []*specs.Hook {
+	var unique []*specs.Hook
+	for _, hook := range hooks {
+		if slices.ContainsFunc(unique, func(h *specs.Hook) bool { return isSameHook(h, hook) }) {
+			continue
+		}
+		unique = append(unique, hook)
+	}
+	return unique
+}
+
+func isSameHook(a *specs.Hook, b *specs.Hook) bool {
+	return a.HookName == b.HookName &&
+		a.Path == b.Path &&
+		slices.Equal(a.Args, b.Args) &&
+		slices.Equal(a.Env, b.Env)
+}
@@ -0,0 +1,181 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package ocipatch
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi"
+)
+
+type fakeLib struct {
+	nvcdi.Interface
+	commonEdits specs.ContainerEdits
+	devices     []specs.Device
+}
+
+func (l *fakeLib) GetCommonEdits() (*cdi.ContainerEdits, error) {
+	return &cdi.ContainerEdits{ContainerEdits: &l.commonEdits}, nil
+}
+
+func (l *fakeLib) GetDeviceSpecsByID(...string) ([]specs.Device, error) {
+	return l.devices, nil
+}
+
+func TestGeneratePatch(t *testing.T) {
+	testCases := []struct {
+		description   string
+		lib           *fakeLib
+		expectedPatch string
+	}{
+		{
+			description:   "no edits",
+			lib:           &fakeLib{},
+			expectedPatch: "{}\n",
+		},
+		{
+			description: "common and device edits are combined",
+			lib: &fakeLib{
+				commonEdits: specs.ContainerEdits{
+					Env: []string{"NVIDIA_VISIBLE_DEVICES=void"},
+					Mounts: []*specs.Mount{
+						{HostPath: "/usr/lib/libcuda.so.1", ContainerPath: "/usr/lib/libcuda.so.1", Options: []string{"ro", "nosuid", "nodev", "bind"}},
+					},
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "update-ldcache"}},
+					},
+				},
+				devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia-patch-test0", Type: "c", Major: 195, Minor: 0},
+							},
+						},
+					},
+				},
+			},
+			expectedPatch: `{
+  "process": {
+    "env": [
+      "NVIDIA_VISIBLE_DEVICES=void"
+    ]
+  },
+  "mounts": [
+    {
+      "destination": "/usr/lib/libcuda.so.1",
+      "source": "/usr/lib/libcuda.so.1",
+      "options": [
+        "ro",
+        "nosuid",
+        "nodev",
+        "bind"
+      ]
+    }
+  ],
+  "hooks": {
+    "createContainer": [
+      {
+        "path": "/usr/bin/nvidia-cdi-hook",
+        "args": [
+          "nvidia-cdi-hook",
+          "update-ldcache"
+        ]
+      }
+    ]
+  },
+  "linux": {
+    "devices": [
+      {
+        "path": "/dev/nvidia-patch-test0",
+        "type": "c",
+        "major": 195,
+        "minor": 0
+      }
+    ],
+    "resources": {
+      "devices": [
+        {
+          "allow": true,
+          "type": "c",
+          "major": 195,
+          "minor": 0,
+          "access": "rwm"
+        }
+      ]
+    }
+  }
+}
+`,
+		},
+		{
+			description: "common and device hooks are deduplicated",
+			lib: &fakeLib{
+				commonEdits: specs.ContainerEdits{
+					Hooks: []*specs.Hook{
+						{HookName: "createContainer", Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "chmod", "--mode", "755", "--path", "/dev/dri"}},
+					},
+				},
+				devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							Hooks: []*specs.Hook{
+								{HookName: "createContainer", Path: "/usr/bin/nvidia-cdi-hook", Args: []string{"nvidia-cdi-hook", "chmod", "--mode", "755", "--path", "/dev/dri"}},
+							},
+						},
+					},
+				},
+			},
+			expectedPatch: `{
+  "hooks": {
+    "createContainer": [
+      {
+        "path": "/usr/bin/nvidia-cdi-hook",
+        "args": [
+          "nvidia-cdi-hook",
+          "chmod",
+          "--mode",
+          "755",
+          "--path",
+          "/dev/dri"
+        ]
+      }
+    ]
+  }
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			p, err := Generate(tc.lib, "all")
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, p.Write(buf))
+			require.Equal(t, tc.expectedPatch, buf.String())
+		})
+	}
+}
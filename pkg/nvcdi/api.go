@@ -89,6 +89,14 @@ const (
 	// coherent or non-coherent devices.
 	FeatureEnableCoherentAnnotations = FeatureFlag("enable-coherent-annotations")
 
+	// FeatureEnableDisplayAnnotations enables the addition of annotations
+	// indicating whether a display is attached to a device.
+	FeatureEnableDisplayAnnotations = FeatureFlag("enable-display-annotations")
+
+	// FeatureEnableMIGAnnotations enables the addition of an annotation
+	// marking MIG devices so that these can be distinguished from full GPUs.
+	FeatureEnableMIGAnnotations = FeatureFlag("enable-mig-annotations")
+
 	// FeatureDisableMultipleCSVDevices disables the handling of multiple devices
 	// in CSV mode.
 	FeatureDisableMultipleCSVDevices = FeatureFlag("disable-multiple-csv-devices")
@@ -100,4 +108,60 @@ const (
 	// FeatureDisableIPCDiscoverer disables the inclusion of IPC sockets
 	// (nvidia-persistenced, nvidia-fabricmanager, MPS) in the CDI spec.
 	FeatureDisableIPCDiscoverer = FeatureFlag("disable-ipc-discoverer")
+
+	// FeatureStrictNVMLShutdown causes failures to shut down NVML (or
+	// nvsandboxutils) after querying devices to be returned as errors instead
+	// of being logged as warnings.
+	FeatureStrictNVMLShutdown = FeatureFlag("strict-nvml-shutdown")
+
+	// FeatureEmitCgroupDeviceRules includes the device type for device nodes
+	// so that the cgroup device rules for these are fully specified in the
+	// CDI spec and do not need to be derived from the host device nodes.
+	FeatureEmitCgroupDeviceRules = FeatureFlag("emit-cgroup-device-rules")
+
+	// FeatureEnableNvSwitchDevices enables the inclusion of NVSwitch device
+	// nodes and the nvidia-fabricmanager socket in the common edits of a CDI
+	// spec.
+	FeatureEnableNvSwitchDevices = FeatureFlag("enable-nvswitch-devices")
+
+	// FeatureDisablePersistencedSocket disables the inclusion of the
+	// nvidia-persistenced socket in the common edits of a CDI spec. The other
+	// IPC sockets are still included.
+	FeatureDisablePersistencedSocket = FeatureFlag("disable-persistenced-socket")
+
+	// FeatureEnableComputeCapabilityAnnotations enables the addition of
+	// annotations recording the CUDA compute capability of full GPU and MIG
+	// devices.
+	FeatureEnableComputeCapabilityAnnotations = FeatureFlag("enable-compute-capability-annotations")
+
+	// FeatureOnlyMIGParents causes only full GPU devices to be generated when
+	// all devices are requested. A full GPU device is generated even if MIG
+	// mode is enabled, and MIG devices are never included.
+	FeatureOnlyMIGParents = FeatureFlag("only-mig-parents")
+
+	// FeatureDisableNUMAAnnotations disables the addition of annotations
+	// recording the NUMA node of full GPU and MIG devices.
+	FeatureDisableNUMAAnnotations = FeatureFlag("disable-numa-annotations")
+
+	// FeatureDisablePCIAddressAndSerialAnnotations disables the addition of
+	// annotations recording the PCI address and board serial number of a GPU.
+	FeatureDisablePCIAddressAndSerialAnnotations = FeatureFlag("disable-pci-serial-annotations")
+
+	// FeatureDisableLibraryDeduplication disables the collapsing of driver
+	// library mounts that resolve to the same file on the host.
+	FeatureDisableLibraryDeduplication = FeatureFlag("disable-library-deduplication")
+
+	// FeatureEnableCUDACompatLibraries enables the injection of the CUDA
+	// forward compatibility libraries installed under the driver root.
+	FeatureEnableCUDACompatLibraries = FeatureFlag("enable-cuda-compat-libraries")
+
+	// FeatureEnableUUIDAnnotations enables the addition of an annotation
+	// recording the UUID of full GPU and MIG devices.
+	FeatureEnableUUIDAnnotations = FeatureFlag("enable-uuid-annotations")
+
+	// FeatureEnableMIGParentPlaceholders enables the generation of a
+	// placeholder device for each GPU with MIG mode enabled when all devices
+	// are requested. A placeholder device is annotated as non-allocatable and
+	// does not include the device nodes or driver files of the GPU.
+	FeatureEnableMIGParentPlaceholders = FeatureFlag("enable-mig-parent-placeholders")
 )
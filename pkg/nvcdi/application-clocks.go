@@ -0,0 +1,52 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// ApplicationClocks defines the memory and graphics application clocks in MHz.
+type ApplicationClocks struct {
+	Memory   uint32
+	Graphics uint32
+}
+
+// WithApplicationClocks sets the application clocks that are set for full GPUs
+// when a container is started. If this is nil, the application clocks are not
+// modified.
+func WithApplicationClocks(clocks *ApplicationClocks) Option {
+	return func(o *options) {
+		o.applicationClocks = clocks
+	}
+}
+
+// newApplicationClocksHookDiscoverer returns a discoverer for the hook that
+// sets the application clocks of the device with the specified UUID. Nil is
+// returned if no application clocks were requested.
+func (l *nvcdilib) newApplicationClocksHookDiscoverer(uuid string) discover.Discover {
+	if l.applicationClocks == nil {
+		return nil
+	}
+	return l.hookCreator.Create(discover.SetApplicationClocksHook,
+		"--device-uuid="+uuid,
+		fmt.Sprintf("--memory-clock=%d", l.applicationClocks.Memory),
+		fmt.Sprintf("--graphics-clock=%d", l.applicationClocks.Graphics),
+	)
+}
@@ -0,0 +1,153 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"strings"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// A DeviceError records a failure to generate the CDI device specs for a
+// single device.
+type DeviceError struct {
+	// ID is the index of the GPU or MIG device that the error applies to.
+	ID  string
+	Err error
+}
+
+func (e DeviceError) Error() string {
+	return fmt.Sprintf("device %v: %v", e.ID, e.Err)
+}
+
+func (e DeviceError) Unwrap() error {
+	return e.Err
+}
+
+// DeviceID returns the ID of the device that the error applies to.
+func (e DeviceError) DeviceID() string {
+	return e.ID
+}
+
+// A PartialDiscoveryError is returned in best-effort mode if the CDI device
+// specs could not be generated for some of the requested devices. The device
+// specs for the remaining devices are returned along with the error.
+type PartialDiscoveryError struct {
+	Errors []DeviceError
+}
+
+func (e *PartialDiscoveryError) Error() string {
+	var errs []string
+	for _, err := range e.Errors {
+		errs = append(errs, err.Error())
+	}
+	return fmt.Sprintf("failed to generate CDI specs for %d device(s): %v", len(e.Errors), strings.Join(errs, "; "))
+}
+
+func (e *PartialDiscoveryError) Unwrap() []error {
+	return e.DeviceErrors()
+}
+
+// DeviceErrors returns the errors for the individual devices.
+func (e *PartialDiscoveryError) DeviceErrors() []error {
+	var errs []error
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// A deviceSpecGeneratorCollector collects the device spec generators for the
+// visited devices and determines how failures for specific devices are
+// handled.
+type deviceSpecGeneratorCollector interface {
+	// add adds the generator for the device with the specified ID.
+	add(id string, generator DeviceSpecGenerator)
+	// fail handles a failure for the device with the specified ID. If an
+	// error is returned, the visitation of devices is aborted.
+	fail(id string, err error) error
+	// deviceSpecGenerators returns the generator for the collected devices.
+	deviceSpecGenerators() DeviceSpecGenerator
+}
+
+// strictDeviceSpecGenerators aborts the visitation of devices on the first
+// failure.
+type strictDeviceSpecGenerators struct {
+	generators DeviceSpecGenerators
+}
+
+func (g *strictDeviceSpecGenerators) add(_ string, generator DeviceSpecGenerator) {
+	g.generators = append(g.generators, generator)
+}
+
+func (g *strictDeviceSpecGenerators) fail(_ string, err error) error {
+	return err
+}
+
+func (g *strictDeviceSpecGenerators) deviceSpecGenerators() DeviceSpecGenerator {
+	return g.generators
+}
+
+// bestEffortDeviceSpecGenerators generates the CDI device specs for a set of
+// devices, skipping devices for which the specs cannot be generated.
+type bestEffortDeviceSpecGenerators struct {
+	ids        []string
+	generators DeviceSpecGenerators
+	// errors records the devices for which no generator could be constructed.
+	errors []DeviceError
+}
+
+// add adds a generator for the device with the specified ID.
+func (g *bestEffortDeviceSpecGenerators) add(id string, generator DeviceSpecGenerator) {
+	g.ids = append(g.ids, id)
+	g.generators = append(g.generators, generator)
+}
+
+// fail records the failure for the device with the specified ID so that it
+// is reported when the device specs are generated. The visitation of devices
+// continues.
+func (g *bestEffortDeviceSpecGenerators) fail(id string, err error) error {
+	g.errors = append(g.errors, DeviceError{ID: id, Err: err})
+	return nil
+}
+
+func (g *bestEffortDeviceSpecGenerators) deviceSpecGenerators() DeviceSpecGenerator {
+	return g
+}
+
+// GetDeviceSpecs returns the device specs for all devices for which these
+// could be generated. If any device failed, a *PartialDiscoveryError is
+// returned along with the device specs.
+func (g *bestEffortDeviceSpecGenerators) GetDeviceSpecs() ([]specs.Device, error) {
+	errors := append([]DeviceError{}, g.errors...)
+
+	var allDeviceSpecs []specs.Device
+	for i, dsg := range g.generators {
+		deviceSpecs, err := dsg.GetDeviceSpecs()
+		if err != nil {
+			errors = append(errors, DeviceError{ID: g.ids[i], Err: err})
+			continue
+		}
+		allDeviceSpecs = append(allDeviceSpecs, deviceSpecs...)
+	}
+
+	if len(errors) > 0 {
+		return allDeviceSpecs, &PartialDiscoveryError{Errors: errors}
+	}
+	return allDeviceSpecs, nil
+}
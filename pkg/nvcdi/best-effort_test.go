@@ -0,0 +1,97 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+type fakeDeviceSpecGenerator struct {
+	name string
+	err  error
+}
+
+func (f fakeDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []specs.Device{{Name: f.name}}, nil
+}
+
+func TestBestEffortDeviceSpecGenerators(t *testing.T) {
+	errConstruct := errors.New("construct")
+	errGenerate := errors.New("generate")
+
+	g := &bestEffortDeviceSpecGenerators{}
+	g.add("0", fakeDeviceSpecGenerator{name: "gpu0"})
+	require.NoError(t, g.fail("1", errConstruct))
+	g.add("2", fakeDeviceSpecGenerator{err: errGenerate})
+	g.add("3", fakeDeviceSpecGenerator{name: "gpu3"})
+
+	deviceSpecs, err := g.GetDeviceSpecs()
+	require.Equal(t, []specs.Device{{Name: "gpu0"}, {Name: "gpu3"}}, deviceSpecs)
+
+	var partial *PartialDiscoveryError
+	require.ErrorAs(t, err, &partial)
+	require.Equal(t, []DeviceError{{ID: "1", Err: errConstruct}, {ID: "2", Err: errGenerate}}, partial.Errors)
+	require.ErrorIs(t, err, errGenerate)
+	require.EqualError(t, err, "failed to generate CDI specs for 2 device(s): device 1: construct; device 2: generate")
+}
+
+func TestBestEffortDeviceSpecGeneratorsForAllDevices(t *testing.T) {
+	server := dgxa100.New()
+	mockOverrides(server)
+	server.Devices[1].(*mockserver.Device).GetUUIDFunc = func() (string, nvml.Return) {
+		return "", nvml.ERROR_UNKNOWN
+	}
+	server.Devices[2].(*mockserver.Device).MigMode = nvml.DEVICE_MIG_ENABLE
+	// The UUID of a MIG-enabled GPU is required to track its MIG devices.
+	server.Devices[3].(*mockserver.Device).MigMode = nvml.DEVICE_MIG_ENABLE
+	server.Devices[3].(*mockserver.Device).GetUUIDFunc = func() (string, nvml.Return) {
+		return "", nvml.ERROR_UNKNOWN
+	}
+
+	logger, _ := testlog.NewNullLogger()
+	l := &nvmllib{
+		logger: logger,
+		platformlibs: platformlibs{
+			nvmllib:   server,
+			devicelib: device.New(server),
+		},
+		bestEffort: true,
+	}
+
+	generators, err := l.getDeviceSpecGeneratorsForIDs("all")
+	require.NoError(t, err)
+
+	g := generators.(*bestEffortDeviceSpecGenerators)
+	require.Equal(t, []string{"0", "4", "5", "6", "7"}, g.ids)
+	require.Len(t, g.errors, 3)
+	require.Equal(t, "1", g.errors[0].ID)
+	require.Equal(t, "3", g.errors[1].ID)
+	require.EqualError(t, g.errors[1], "device 3: failed to get device UUID: ERROR_UNKNOWN")
+	require.Equal(t, "2", g.errors[2].ID)
+}
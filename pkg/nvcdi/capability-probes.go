@@ -0,0 +1,140 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/engines"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// WithProbeCapabilities sets whether the devices are probed using NVML to
+// determine whether driver libraries for optional hardware engines (such as
+// NVENC) are included. If this is enabled, such libraries are only included if
+// at least one device supports the associated engine.
+func WithProbeCapabilities(probe bool) Option {
+	return func(o *options) {
+		o.probeCapabilities = probe
+	}
+}
+
+// A capabilityProbe checks whether a device supports the hardware engine that
+// is used by the associated driver libraries.
+type capabilityProbe struct {
+	engine    string
+	supported func(nvml.Device) bool
+}
+
+var (
+	nvencProbe = capabilityProbe{
+		engine:    "NVENC",
+		supported: engines.SupportsNVENC,
+	}
+	nvdecProbe = capabilityProbe{
+		engine:    "NVDEC",
+		supported: engines.SupportsNVDEC,
+	}
+)
+
+// driverFileProbes maps the driver libraries that are only useful if a device
+// supports a specific hardware engine to the probe for that engine. Libraries
+// are identified by their name up to and including the .so suffix.
+var driverFileProbes = map[string]capabilityProbe{
+	"libnvidia-encode.so": nvencProbe,
+	"libnvcuvid.so":       nvdecProbe,
+}
+
+// probeCapabilities probes the devices on the system and records the driver
+// libraries for which none of the devices support the associated engine.
+// Probes are only run if this was requested.
+func (l *nvmllib) probeCapabilities() (rerr error) {
+	if !l.shouldProbeCapabilities {
+		return nil
+	}
+	if err := l.init(); err != nil {
+		return err
+	}
+	defer func() {
+		rerr = l.shutdownWithError(rerr)
+	}()
+
+	supported := make(map[string]bool)
+	err := l.devicelib.VisitDevices(func(i int, d device.Device) error {
+		for _, probe := range driverFileProbes {
+			if !supported[probe.engine] && probe.supported(d) {
+				l.logger.Debugf("GPU %d supports %v", i, probe.engine)
+				supported[probe.engine] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	l.unsupportedDriverFiles = make(map[string]string)
+	for name, probe := range driverFileProbes {
+		if supported[probe.engine] {
+			continue
+		}
+		l.logger.Infof("Excluding %v: no device supports %v", name, probe.engine)
+		l.unsupportedDriverFiles[name] = probe.engine
+	}
+	return nil
+}
+
+// filterMountsByProbedCapabilities wraps the specified discoverer so that
+// mounts for driver libraries that require an engine that is not supported by
+// any device are removed.
+func (l *nvcdilib) filterMountsByProbedCapabilities(d discover.Discover) discover.Discover {
+	if d == nil || len(l.unsupportedDriverFiles) == 0 {
+		return d
+	}
+	return &probeFilteredMounts{
+		Discover:    d,
+		logger:      l.logger,
+		unsupported: l.unsupportedDriverFiles,
+	}
+}
+
+type probeFilteredMounts struct {
+	discover.Discover
+	logger      logger.Interface
+	unsupported map[string]string
+}
+
+// Mounts returns the mounts of the wrapped discoverer for driver files whose
+// engine is supported.
+func (d *probeFilteredMounts) Mounts() ([]discover.Mount, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []discover.Mount
+	for _, m := range mounts {
+		if engine, ok := d.unsupported[driverFileName(m.Path)]; ok {
+			d.logger.Debugf("Skipping %v which requires %v", m.Path, engine)
+			continue
+		}
+		selected = append(selected, m)
+	}
+	return selected, nil
+}
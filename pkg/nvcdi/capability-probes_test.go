@@ -0,0 +1,189 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestCapabilityProbes(t *testing.T) {
+	testCases := []struct {
+		description string
+		probe       capabilityProbe
+		device      *mock.Device
+		expected    bool
+	}{
+		{
+			description: "NVENC is supported for non-zero capacity",
+			probe:       nvencProbe,
+			device: &mock.Device{
+				GetEncoderCapacityFunc: func(nvml.EncoderType) (int, nvml.Return) {
+					return 100, nvml.SUCCESS
+				},
+			},
+			expected: true,
+		},
+		{
+			description: "NVENC is not supported for zero capacity",
+			probe:       nvencProbe,
+			device: &mock.Device{
+				GetEncoderCapacityFunc: func(nvml.EncoderType) (int, nvml.Return) {
+					return 0, nvml.SUCCESS
+				},
+			},
+			expected: false,
+		},
+		{
+			description: "NVENC is not supported if the capacity is not supported",
+			probe:       nvencProbe,
+			device: &mock.Device{
+				GetEncoderCapacityFunc: func(nvml.EncoderType) (int, nvml.Return) {
+					return 0, nvml.ERROR_NOT_SUPPORTED
+				},
+			},
+			expected: false,
+		},
+		{
+			description: "NVDEC is supported if the utilization is sampled",
+			probe:       nvdecProbe,
+			device: &mock.Device{
+				GetDecoderUtilizationFunc: func() (uint32, uint32, nvml.Return) {
+					return 0, 167000, nvml.SUCCESS
+				},
+			},
+			expected: true,
+		},
+		{
+			description: "NVDEC is not supported for a zero sampling period",
+			probe:       nvdecProbe,
+			device: &mock.Device{
+				GetDecoderUtilizationFunc: func() (uint32, uint32, nvml.Return) {
+					return 0, 0, nvml.SUCCESS
+				},
+			},
+			expected: false,
+		},
+		{
+			description: "NVDEC is not supported if the utilization is not supported",
+			probe:       nvdecProbe,
+			device: &mock.Device{
+				GetDecoderUtilizationFunc: func() (uint32, uint32, nvml.Return) {
+					return 0, 0, nvml.ERROR_NOT_SUPPORTED
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.probe.supported(tc.device))
+		})
+	}
+}
+
+func TestProbeCapabilities(t *testing.T) {
+	libraries := []discover.Mount{
+		{Path: "/usr/lib64/libcuda.so.999.88.77"},
+		{Path: "/usr/lib64/libnvidia-encode.so.999.88.77"},
+		{Path: "/usr/lib64/libnvcuvid.so.999.88.77"},
+	}
+
+	testCases := []struct {
+		description     string
+		probe           bool
+		nvencDevice     int
+		expectedMounts  []discover.Mount
+		expectedSkipped map[string]string
+	}{
+		{
+			description:    "probes are not run by default",
+			nvencDevice:    -1,
+			expectedMounts: libraries,
+		},
+		{
+			description:     "libraries are included if any device supports the engine",
+			probe:           true,
+			nvencDevice:     3,
+			expectedMounts:  libraries,
+			expectedSkipped: map[string]string{},
+		},
+		{
+			description: "libraries are excluded if no device supports the engine",
+			probe:       true,
+			nvencDevice: -1,
+			expectedMounts: []discover.Mount{
+				{Path: "/usr/lib64/libcuda.so.999.88.77"},
+				{Path: "/usr/lib64/libnvcuvid.so.999.88.77"},
+			},
+			expectedSkipped: map[string]string{
+				"libnvidia-encode.so": "NVENC",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockNvml := dgxa100.New()
+			for i, d := range mockNvml.Devices {
+				d.(*mockserver.Device).GetEncoderCapacityFunc = func(nvml.EncoderType) (int, nvml.Return) {
+					if i != tc.nvencDevice {
+						return 0, nvml.ERROR_NOT_SUPPORTED
+					}
+					return 100, nvml.SUCCESS
+				}
+				d.(*mockserver.Device).GetDecoderUtilizationFunc = func() (uint32, uint32, nvml.Return) {
+					return 0, 167000, nvml.SUCCESS
+				}
+			}
+
+			logger, _ := testlog.NewNullLogger()
+			l := &nvmllib{
+				logger: logger,
+				platformlibs: platformlibs{
+					nvmllib:   mockNvml,
+					devicelib: device.New(mockNvml),
+				},
+				shouldProbeCapabilities: tc.probe,
+			}
+
+			require.NoError(t, l.probeCapabilities())
+			require.EqualValues(t, tc.expectedSkipped, l.unsupportedDriverFiles)
+
+			d := (*nvcdilib)(l).filterMountsByProbedCapabilities(
+				&discover.DiscoverMock{
+					MountsFunc: func() ([]discover.Mount, error) {
+						return libraries, nil
+					},
+				},
+			)
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, mounts)
+		})
+	}
+}
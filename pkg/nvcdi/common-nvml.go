@@ -19,6 +19,7 @@ package nvcdi
 import (
 	"fmt"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 )
 
@@ -27,9 +28,18 @@ import (
 func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
 	metaDevices := l.controlDeviceNodeDiscoverer()
 
-	graphicsMounts, err := discover.NewGraphicsMountsDiscoverer(l.logger, l.driver, l.hookCreator)
-	if err != nil {
-		l.logger.Warningf("failed to create discoverer for graphics mounts: %v", err)
+	var graphicsMounts discover.Discover
+	if (*nvcdilib)(l).hasDriverCapabilities(image.DriverCapabilityGraphics, image.DriverCapabilityDisplay) {
+		var err error
+		graphicsMounts, err = discover.NewGraphicsMountsDiscoverer(l.logger, l.driver, l.hookCreator, l.vulkanManifestDir)
+		if err != nil {
+			l.logger.Warningf("failed to create discoverer for graphics mounts: %v", err)
+		}
+	}
+
+	var openCL discover.Discover
+	if (*nvcdilib)(l).hasDriverCapabilities(image.DriverCapabilityGraphics, image.DriverCapabilityCompute) {
+		openCL = discover.NewOpenCLDiscoverer(l.logger, l.driver)
 	}
 
 	driverFiles, err := l.NewDriverDiscoverer()
@@ -39,25 +49,54 @@ func (l *nvmllib) newCommonNVMLDiscoverer() (discover.Discover, error) {
 
 	applicationProfileHook := discover.NewApplicationProfileHookDiscoverer(l.hookCreator)
 
+	waitForDeviceNodesHook := (*nvcdilib)(l).newWaitForDeviceNodesHookDiscoverer()
+
+	nvswitches, err := l.newNvSwitchDiscoverer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discoverer for NVSwitch devices: %v", err)
+	}
+
 	d := discover.Merge(
 		metaDevices,
 		graphicsMounts,
+		openCL,
 		driverFiles,
 		applicationProfileHook,
+		waitForDeviceNodesHook,
+		nvswitches,
 	)
 
 	return d, nil
 }
 
 func (l *nvmllib) controlDeviceNodeDiscoverer() discover.Discover {
+	var deviceNodes []string
+	// The modeset device node is only required for graphics and display
+	// applications.
+	if (*nvcdilib)(l).hasDriverCapabilities(image.DriverCapabilityGraphics, image.DriverCapabilityDisplay) {
+		deviceNodes = append(deviceNodes, "/dev/nvidia-modeset")
+	}
+	deviceNodes = append(deviceNodes,
+		"/dev/nvidia-uvm-tools",
+		"/dev/nvidia-uvm",
+		"/dev/nvidiactl",
+	)
 	return discover.NewCharDeviceDiscoverer(
 		l.logger,
 		l.driver.DevRoot,
-		[]string{
-			"/dev/nvidia-modeset",
-			"/dev/nvidia-uvm-tools",
-			"/dev/nvidia-uvm",
-			"/dev/nvidiactl",
-		},
+		deviceNodes,
 	)
 }
+
+// newNvSwitchDiscoverer returns a discoverer for the NVSwitch device nodes and
+// the nvidia-fabricmanager socket if these have been requested.
+func (l *nvmllib) newNvSwitchDiscoverer() (discover.Discover, error) {
+	if !l.featureFlags[FeatureEnableNvSwitchDevices] {
+		return nil, nil
+	}
+	nvswitches, err := discover.NewNvSwitchFabricDiscoverer(l.logger, l.driver)
+	if err != nil {
+		return nil, err
+	}
+	return (*nvcdilib)(l).withDebugDump("nvswitch", nvswitches), nil
+}
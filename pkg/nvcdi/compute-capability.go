@@ -0,0 +1,97 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// ComputeCapabilityAnnotation is the device annotation used to record the CUDA
+// compute capability of a GPU as major.minor. For MIG devices, the compute
+// capability of the parent GPU is recorded.
+const ComputeCapabilityAnnotation = "nvidia.com/compute-capability"
+
+// A ComputeCapability represents the CUDA compute capability of a device.
+type ComputeCapability struct {
+	Major int
+	Minor int
+}
+
+// String returns the compute capability formatted as major.minor.
+func (c ComputeCapability) String() string {
+	return fmt.Sprintf("%d.%d", c.Major, c.Minor)
+}
+
+// ParseComputeCapability parses a compute capability specified as major.minor.
+func ParseComputeCapability(value string) (*ComputeCapability, error) {
+	majorValue, minorValue, found := strings.Cut(value, ".")
+	if !found {
+		return nil, fmt.Errorf("invalid compute capability %q: expected major.minor", value)
+	}
+	major, err := strconv.ParseUint(majorValue, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compute capability %q: %w", value, err)
+	}
+	minor, err := strconv.ParseUint(minorValue, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compute capability %q: %w", value, err)
+	}
+	c := ComputeCapability{
+		Major: int(major),
+		Minor: int(minor),
+	}
+	return &c, nil
+}
+
+// GetComputeCapability returns the compute capability recorded in the
+// annotations of the specified device spec. If the annotation is not present,
+// nil is returned.
+func GetComputeCapability(d specs.Device) (*ComputeCapability, error) {
+	value, ok := d.Annotations[ComputeCapabilityAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	return ParseComputeCapability(value)
+}
+
+// getComputeCapabilityAnnotations returns the annotations recording the
+// compute capability of the specified device. No annotations are returned if
+// the feature is not enabled or if the compute capability cannot be determined.
+func (l *nvmllib) getComputeCapabilityAnnotations(d nvml.Device) map[string]string {
+	if !l.featureFlags[FeatureEnableComputeCapabilityAnnotations] {
+		return nil
+	}
+	major, minor, ret := d.GetCudaComputeCapability()
+	if ret != nvml.SUCCESS {
+		if ret != nvml.ERROR_NOT_SUPPORTED {
+			l.logger.Warningf("Ignoring error getting compute capability of device: %v", ret)
+		}
+		return nil
+	}
+	c := ComputeCapability{
+		Major: major,
+		Minor: minor,
+	}
+	return map[string]string{
+		ComputeCapabilityAnnotation: c.String(),
+	}
+}
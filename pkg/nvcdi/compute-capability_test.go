@@ -0,0 +1,124 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestParseComputeCapability(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expected      *ComputeCapability
+		expectedError bool
+	}{
+		{value: "8.0", expected: &ComputeCapability{Major: 8, Minor: 0}},
+		{value: "12.1", expected: &ComputeCapability{Major: 12, Minor: 1}},
+		{value: "8", expectedError: true},
+		{value: "8.x", expectedError: true},
+		{value: "-1.0", expectedError: true},
+		{value: "", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			c, err := ParseComputeCapability(tc.value)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, c)
+			require.Equal(t, tc.value, c.String())
+		})
+	}
+}
+
+func TestGetComputeCapabilityAnnotations(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description         string
+		featureFlags        map[FeatureFlag]bool
+		ret                 nvml.Return
+		expectedAnnotations map[string]string
+	}{
+		{
+			description:  "compute capability is annotated",
+			featureFlags: map[FeatureFlag]bool{FeatureEnableComputeCapabilityAnnotations: true},
+			ret:          nvml.SUCCESS,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/compute-capability": "9.0",
+			},
+		},
+		{
+			description: "feature is disabled by default",
+			ret:         nvml.SUCCESS,
+		},
+		{
+			description:  "not supported is ignored",
+			featureFlags: map[FeatureFlag]bool{FeatureEnableComputeCapabilityAnnotations: true},
+			ret:          nvml.ERROR_NOT_SUPPORTED,
+		},
+		{
+			description:  "error is ignored",
+			featureFlags: map[FeatureFlag]bool{FeatureEnableComputeCapabilityAnnotations: true},
+			ret:          nvml.ERROR_UNKNOWN,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvmllib{
+				logger:       logger,
+				featureFlags: tc.featureFlags,
+			}
+			d := &mock.Device{
+				GetCudaComputeCapabilityFunc: func() (int, int, nvml.Return) {
+					return 9, 0, tc.ret
+				},
+			}
+
+			require.EqualValues(t, tc.expectedAnnotations, l.getComputeCapabilityAnnotations(d))
+		})
+	}
+}
+
+func TestGetComputeCapability(t *testing.T) {
+	c, err := GetComputeCapability(specs.Device{
+		Name:        "0",
+		Annotations: map[string]string{"nvidia.com/compute-capability": "8.0"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &ComputeCapability{Major: 8, Minor: 0}, c)
+
+	c, err = GetComputeCapability(specs.Device{Name: "0"})
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	_, err = GetComputeCapability(specs.Device{
+		Name:        "0",
+		Annotations: map[string]string{"nvidia.com/compute-capability": "invalid"},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,219 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+)
+
+// pciBusIDFilter wraps a device library so that only devices matching the
+// configured PCI bus IDs are visited.
+type pciBusIDFilter struct {
+	device.Interface
+	selection deviceSelectionLogger
+	// included is the set of PCI bus IDs of devices that are visited. If this
+	// is empty, all devices that are not excluded are visited.
+	included map[string]bool
+	// excluded is the set of PCI bus IDs of devices that are not visited.
+	excluded map[string]bool
+}
+
+// newPCIBusIDFilter returns a device library that only visits the devices
+// with the specified PCI bus IDs. Devices with excluded bus IDs are skipped.
+// If no bus IDs are specified, the device library is returned unchanged.
+func newPCIBusIDFilter(selection deviceSelectionLogger, devicelib device.Interface, included []string, excluded []string) device.Interface {
+	if len(included) == 0 && len(excluded) == 0 {
+		return devicelib
+	}
+	f := &pciBusIDFilter{
+		Interface: devicelib,
+		selection: selection,
+		included:  make(map[string]bool),
+		excluded:  make(map[string]bool),
+	}
+	for _, id := range included {
+		f.included[normalizePCIBusID(id)] = true
+	}
+	for _, id := range excluded {
+		f.excluded[normalizePCIBusID(id)] = true
+	}
+	return f
+}
+
+// normalizePCIBusID converts the specified PCI bus ID to the form returned by
+// device.GetPCIBusID. This is a lower-case ID with a 4-digit domain.
+func normalizePCIBusID(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	parts := strings.Split(id, ":")
+	switch {
+	case len(parts) == 2:
+		return "0000:" + id
+	case len(parts) == 3 && len(parts[0]) == 8:
+		return strings.TrimPrefix(id, "0000")
+	}
+	return id
+}
+
+// VisitDevices visits the devices that match the filter. An error is returned
+// if a device with an included PCI bus ID is not found.
+func (f *pciBusIDFilter) VisitDevices(visit func(int, device.Device) error) error {
+	found := make(map[string]bool)
+	err := f.Interface.VisitDevices(func(i int, d device.Device) error {
+		busID, err := d.GetPCIBusID()
+		if err != nil {
+			return fmt.Errorf("failed to get PCI bus ID of device %d: %w", i, err)
+		}
+		busID = normalizePCIBusID(busID)
+		if f.excluded[busID] {
+			f.selection.logf("Skipping GPU %d: PCI bus ID %v is excluded", i, busID)
+			return nil
+		}
+		if len(f.included) > 0 && !f.included[busID] {
+			f.selection.logf("Skipping GPU %d: PCI bus ID %v is not selected", i, busID)
+			return nil
+		}
+		found[busID] = true
+		return visit(i, d)
+	})
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for busID := range f.included {
+		if f.excluded[busID] || found[busID] {
+			continue
+		}
+		missing = append(missing, busID)
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("no devices found with PCI bus IDs %v", missing)
+	}
+	return nil
+}
+
+// VisitMigDevices visits the MIG devices of the devices that match the filter.
+func (f *pciBusIDFilter) VisitMigDevices(visit func(int, device.Device, int, device.MigDevice) error) error {
+	return f.VisitDevices(func(i int, d device.Device) error {
+		return d.VisitMigDevices(func(j int, mig device.MigDevice) error {
+			return visit(i, d, j, mig)
+		})
+	})
+}
+
+// GetDevices returns the devices that match the filter.
+func (f *pciBusIDFilter) GetDevices() ([]device.Device, error) {
+	var devices []device.Device
+	err := f.VisitDevices(func(_ int, d device.Device) error {
+		devices = append(devices, d)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// GetMigDevices returns the MIG devices of the devices that match the filter.
+func (f *pciBusIDFilter) GetMigDevices() ([]device.MigDevice, error) {
+	var migDevices []device.MigDevice
+	err := f.VisitMigDevices(func(_ int, _ device.Device, _ int, mig device.MigDevice) error {
+		migDevices = append(migDevices, mig)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return migDevices, nil
+}
+
+// maxDevicesFilter wraps a device library so that at most a fixed number of
+// full GPUs are visited. The limit applies to physical GPUs. All MIG devices of
+// a visited GPU are also visited.
+type maxDevicesFilter struct {
+	device.Interface
+	selection  deviceSelectionLogger
+	maxDevices int
+}
+
+// newMaxDevicesFilter returns a device library that only visits the first
+// maxDevices devices in the order in which they are visited by the wrapped
+// library. If maxDevices is not positive, the device library is returned
+// unchanged.
+func newMaxDevicesFilter(selection deviceSelectionLogger, devicelib device.Interface, maxDevices int) device.Interface {
+	if maxDevices <= 0 {
+		return devicelib
+	}
+	return &maxDevicesFilter{
+		Interface:  devicelib,
+		selection:  selection,
+		maxDevices: maxDevices,
+	}
+}
+
+// VisitDevices visits at most maxDevices devices.
+func (f *maxDevicesFilter) VisitDevices(visit func(int, device.Device) error) error {
+	var visited int
+	return f.Interface.VisitDevices(func(i int, d device.Device) error {
+		if visited >= f.maxDevices {
+			f.selection.logf("Skipping GPU %d: the maximum number of devices (%d) has been reached", i, f.maxDevices)
+			return nil
+		}
+		visited++
+		return visit(i, d)
+	})
+}
+
+// VisitMigDevices visits the MIG devices of the devices that are visited.
+func (f *maxDevicesFilter) VisitMigDevices(visit func(int, device.Device, int, device.MigDevice) error) error {
+	return f.VisitDevices(func(i int, d device.Device) error {
+		return d.VisitMigDevices(func(j int, mig device.MigDevice) error {
+			return visit(i, d, j, mig)
+		})
+	})
+}
+
+// GetDevices returns the devices that are visited.
+func (f *maxDevicesFilter) GetDevices() ([]device.Device, error) {
+	var devices []device.Device
+	err := f.VisitDevices(func(_ int, d device.Device) error {
+		devices = append(devices, d)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// GetMigDevices returns the MIG devices of the devices that are visited.
+func (f *maxDevicesFilter) GetMigDevices() ([]device.MigDevice, error) {
+	var migDevices []device.MigDevice
+	err := f.VisitMigDevices(func(_ int, _ device.Device, _ int, mig device.MigDevice) error {
+		migDevices = append(migDevices, mig)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return migDevices, nil
+}
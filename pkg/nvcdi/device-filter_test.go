@@ -0,0 +1,160 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPCIBusIDFilter(t *testing.T) {
+	testCases := []struct {
+		description     string
+		included        []string
+		excluded        []string
+		expectedError   error
+		expectedIndices []int
+	}{
+		{
+			description:     "no filter visits all devices",
+			expectedIndices: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			description:     "included devices are visited",
+			included:        []string{"0000:01:00.0", "0000:03:00.0"},
+			expectedIndices: []int{1, 3},
+		},
+		{
+			description:     "bus IDs are normalized",
+			included:        []string{"01:00.0", "00000000:03:00.0"},
+			expectedIndices: []int{1, 3},
+		},
+		{
+			description:     "excluded devices are skipped",
+			excluded:        []string{"0000:00:00.0", "0000:07:00.0"},
+			expectedIndices: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			description:     "exclusion takes precedence",
+			included:        []string{"0000:01:00.0", "0000:03:00.0"},
+			excluded:        []string{"0000:03:00.0"},
+			expectedIndices: []int{1},
+		},
+		{
+			description:   "missing included device is an error",
+			included:      []string{"0000:01:00.0", "0000:ff:00.0"},
+			expectedError: fmt.Errorf("no devices found with PCI bus IDs [0000:ff:00.0]"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			for i, d := range server.Devices {
+				d.(*dgxa100.Device).GetPciInfoFunc = func() (nvml.PciInfo, nvml.Return) {
+					var info nvml.PciInfo
+					for j, c := range fmt.Sprintf("00000000:%02X:00.0", i) {
+						info.BusId[j] = int8(c)
+					}
+					return info, nvml.SUCCESS
+				}
+			}
+
+			devicelib := newPCIBusIDFilter(deviceSelectionLogger{}, device.New(server), tc.included, tc.excluded)
+
+			var indices []int
+			err := devicelib.VisitDevices(func(i int, _ device.Device) error {
+				indices = append(indices, i)
+				return nil
+			})
+			if tc.expectedError != nil {
+				require.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedIndices, indices)
+		})
+	}
+}
+
+func TestMaxDevicesFilter(t *testing.T) {
+	testCases := []struct {
+		description     string
+		maxDevices      int
+		excluded        []string
+		expectedIndices []int
+	}{
+		{
+			description:     "no limit visits all devices",
+			expectedIndices: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			description:     "first devices are visited",
+			maxDevices:      3,
+			expectedIndices: []int{0, 1, 2},
+		},
+		{
+			description:     "limit larger than device count visits all devices",
+			maxDevices:      10,
+			expectedIndices: []int{0, 1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			description:     "limit is applied after PCI bus ID filtering",
+			maxDevices:      2,
+			excluded:        []string{"0000:00:00.0"},
+			expectedIndices: []int{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := dgxa100.New()
+			for i, d := range server.Devices {
+				d.(*dgxa100.Device).GetPciInfoFunc = func() (nvml.PciInfo, nvml.Return) {
+					var info nvml.PciInfo
+					for j, c := range fmt.Sprintf("00000000:%02X:00.0", i) {
+						info.BusId[j] = int8(c)
+					}
+					return info, nvml.SUCCESS
+				}
+			}
+
+			devicelib := newMaxDevicesFilter(
+				deviceSelectionLogger{},
+				newPCIBusIDFilter(deviceSelectionLogger{}, device.New(server), nil, tc.excluded),
+				tc.maxDevices,
+			)
+
+			var indices []int
+			err := devicelib.VisitDevices(func(i int, _ device.Device) error {
+				indices = append(indices, i)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedIndices, indices)
+
+			devices, err := devicelib.GetDevices()
+			require.NoError(t, err)
+			require.Len(t, devices, len(tc.expectedIndices))
+		})
+	}
+}
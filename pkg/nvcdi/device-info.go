@@ -0,0 +1,117 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+// DeviceInfo describes an allocatable device for which CDI device specs are
+// generated. Attributes that cannot be queried are left empty.
+type DeviceInfo struct {
+	// Names are the names of the generated CDI devices.
+	Names []string
+	// UUID is the UUID of the full GPU or MIG device.
+	UUID string
+	// ParentUUID is the UUID of the parent GPU of a MIG device.
+	ParentUUID string
+	// MemoryBytes is the total memory of the device in bytes.
+	MemoryBytes uint64
+	// MIGProfile is the name of the profile of a MIG device (e.g. 1g.5gb).
+	MIGProfile string
+	// ComputeCapability is the CUDA compute capability of the device. For MIG
+	// devices this is the compute capability of the parent GPU.
+	ComputeCapability *ComputeCapability
+}
+
+// A DeviceInfoRecorder is notified of the attributes of each full GPU or MIG
+// device for which CDI device specs are generated.
+type DeviceInfoRecorder func(DeviceInfo)
+
+// WithDeviceInfoRecorder sets the recorder that is notified of the attributes
+// of the devices for which CDI device specs are generated. The attributes are
+// queried using the same NVML device handles as used for generating the
+// device specs. If this is nil, no attributes are queried.
+func WithDeviceInfoRecorder(recorder DeviceInfoRecorder) Option {
+	return func(o *options) {
+		o.deviceInfoRecorder = recorder
+	}
+}
+
+// getDeviceInfo returns the attributes of the full GPU.
+func (l *fullGPUDeviceSpecGenerator) getDeviceInfo(names []string) DeviceInfo {
+	info := DeviceInfo{
+		Names: names,
+		UUID:  l.uuid,
+	}
+	device, err := l.device()
+	if err != nil {
+		l.logger.Warningf("Ignoring error getting device info for device %v: %v", l.uuid, err)
+		return info
+	}
+	info.MemoryBytes = l.getMemoryBytes(device)
+	info.ComputeCapability = l.getComputeCapability(device)
+	return info
+}
+
+// getDeviceInfo returns the attributes of the MIG device.
+func (l *migDeviceSpecGenerator) getDeviceInfo(names []string) DeviceInfo {
+	info := DeviceInfo{
+		Names:      names,
+		UUID:       l.migUUID,
+		ParentUUID: l.uuid,
+	}
+	if parent, err := l.device(); err == nil {
+		info.ComputeCapability = l.getComputeCapability(parent)
+	}
+	migDevice, err := l.migDevice()
+	if err != nil {
+		l.logger.Warningf("Ignoring error getting device info for MIG device %v: %v", l.migUUID, err)
+		return info
+	}
+	info.MemoryBytes = l.getMemoryBytes(migDevice)
+	if profile, err := migDevice.GetProfile(); err == nil {
+		info.MIGProfile = profile.String()
+	}
+	return info
+}
+
+// getMemoryBytes returns the total memory of the specified device. Zero is
+// returned if this cannot be determined.
+func (l *nvmllib) getMemoryBytes(d nvml.Device) uint64 {
+	memory, ret := d.GetMemoryInfo()
+	if ret != nvml.SUCCESS {
+		if ret != nvml.ERROR_NOT_SUPPORTED {
+			l.logger.Warningf("Ignoring error getting memory info of device: %v", ret)
+		}
+		return 0
+	}
+	return memory.Total
+}
+
+// getComputeCapability returns the compute capability of the specified
+// device. Nil is returned if this cannot be determined.
+func (l *nvmllib) getComputeCapability(d nvml.Device) *ComputeCapability {
+	major, minor, ret := d.GetCudaComputeCapability()
+	if ret != nvml.SUCCESS {
+		return nil
+	}
+	return &ComputeCapability{
+		Major: major,
+		Minor: minor,
+	}
+}
@@ -0,0 +1,61 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// A deviceSelectionLogger logs whether an enumerated device is included in
+// the generated spec or skipped, and why. Since devices may be enumerated more
+// than once, for example to discover MIG devices, each message is only logged
+// once.
+type deviceSelectionLogger struct {
+	logger  logger.Interface
+	verbose bool
+	logged  map[string]bool
+}
+
+// logf logs the specified message at info level if verbose device selection
+// logging was requested and at debug level otherwise.
+func (l deviceSelectionLogger) logf(format string, args ...any) {
+	if l.logger == nil {
+		return
+	}
+	message := fmt.Sprintf(format, args...)
+	if l.logged != nil {
+		if l.logged[message] {
+			return
+		}
+		l.logged[message] = true
+	}
+	if l.verbose {
+		l.logger.Infof("%s", message)
+		return
+	}
+	l.logger.Debugf("%s", message)
+}
+
+func (o *options) deviceSelectionLogger() deviceSelectionLogger {
+	return deviceSelectionLogger{
+		logger:  o.logger,
+		verbose: o.verboseDeviceSelection,
+		logged:  make(map[string]bool),
+	}
+}
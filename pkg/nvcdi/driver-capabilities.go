@@ -0,0 +1,148 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
+)
+
+// driverFileCapabilities maps the driver libraries and binaries to the driver
+// capability that they are associated with. This mirrors the classification
+// used for the NVIDIA_DRIVER_CAPABILITIES environment variable by the legacy
+// NVIDIA Container Runtime Hook. Libraries are identified by their name up to
+// and including the .so suffix.
+var driverFileCapabilities = map[string]image.DriverCapability{
+	// Utility binaries and libraries
+	"nvidia-smi":          image.DriverCapabilityUtility,
+	"nvidia-debugdump":    image.DriverCapabilityUtility,
+	"nvidia-persistenced": image.DriverCapabilityUtility,
+	"nvidia-imex":         image.DriverCapabilityUtility,
+	"nvidia-imex-ctl":     image.DriverCapabilityUtility,
+	"libnvidia-ml.so":     image.DriverCapabilityUtility,
+	"libnvidia-cfg.so":    image.DriverCapabilityUtility,
+	"libnvidia-nscq.so":   image.DriverCapabilityUtility,
+
+	// Compute binaries and libraries
+	"nvidia-cuda-mps-control":      image.DriverCapabilityCompute,
+	"nvidia-cuda-mps-server":       image.DriverCapabilityCompute,
+	"libcuda.so":                   image.DriverCapabilityCompute,
+	"libcudadebugger.so":           image.DriverCapabilityCompute,
+	"libnvidia-opencl.so":          image.DriverCapabilityCompute,
+	"libnvidia-gpucomp.so":         image.DriverCapabilityCompute,
+	"libnvidia-ptxjitcompiler.so":  image.DriverCapabilityCompute,
+	"libnvidia-fatbinaryloader.so": image.DriverCapabilityCompute,
+	"libnvidia-allocator.so":       image.DriverCapabilityCompute,
+	"libnvidia-compiler.so":        image.DriverCapabilityCompute,
+	"libnvidia-nvvm.so":            image.DriverCapabilityCompute,
+	"libnvidia-pkcs11.so":          image.DriverCapabilityCompute,
+	"libnvidia-pkcs11-openssl3.so": image.DriverCapabilityCompute,
+	"libnvidia-sandboxutils.so":    image.DriverCapabilityCompute,
+
+	// Video libraries
+	"libvdpau_nvidia.so":       image.DriverCapabilityVideo,
+	"libnvidia-encode.so":      image.DriverCapabilityVideo,
+	"libnvidia-opticalflow.so": image.DriverCapabilityVideo,
+	"libnvcuvid.so":            image.DriverCapabilityVideo,
+
+	// Graphics libraries
+	"libnvidia-eglcore.so":         image.DriverCapabilityGraphics,
+	"libnvidia-glcore.so":          image.DriverCapabilityGraphics,
+	"libnvidia-tls.so":             image.DriverCapabilityGraphics,
+	"libnvidia-glsi.so":            image.DriverCapabilityGraphics,
+	"libnvidia-fbc.so":             image.DriverCapabilityGraphics,
+	"libnvidia-ifr.so":             image.DriverCapabilityGraphics,
+	"libnvidia-rtcore.so":          image.DriverCapabilityGraphics,
+	"libnvoptix.so":                image.DriverCapabilityGraphics,
+	"libGLX_nvidia.so":             image.DriverCapabilityGraphics,
+	"libEGL_nvidia.so":             image.DriverCapabilityGraphics,
+	"libGLESv2_nvidia.so":          image.DriverCapabilityGraphics,
+	"libGLESv1_CM_nvidia.so":       image.DriverCapabilityGraphics,
+	"libnvidia-glvkspirv.so":       image.DriverCapabilityGraphics,
+	"libnvidia-cbl.so":             image.DriverCapabilityGraphics,
+	"libnvidia-egl-gbm.so":         image.DriverCapabilityGraphics,
+	"libnvidia-egl-wayland.so":     image.DriverCapabilityGraphics,
+	"libnvidia-vulkan-producer.so": image.DriverCapabilityGraphics,
+
+	// NGX libraries
+	"libnvidia-ngx.so": image.DriverCapabilityNgx,
+}
+
+// hasDriverCapabilities checks whether any of the specified capabilities
+// were requested. If no capabilities were requested, all capabilities are
+// assumed.
+func (l *nvcdilib) hasDriverCapabilities(capabilities ...image.DriverCapability) bool {
+	if l.driverCapabilities == nil {
+		return true
+	}
+	return l.driverCapabilities.Any(capabilities...)
+}
+
+// filterMountsByDriverCapabilities wraps the specified discoverer so that only
+// mounts for driver files associated with the requested driver capabilities
+// are returned. Mounts for files that are not associated with a specific
+// capability are always returned.
+func (l *nvcdilib) filterMountsByDriverCapabilities(d discover.Discover) discover.Discover {
+	if d == nil || l.driverCapabilities == nil || l.driverCapabilities.IsAll() {
+		return d
+	}
+	return &capabilityFilteredMounts{
+		Discover:     d,
+		logger:       l.logger,
+		capabilities: l.driverCapabilities,
+	}
+}
+
+type capabilityFilteredMounts struct {
+	discover.Discover
+	logger       logger.Interface
+	capabilities image.DriverCapabilities
+}
+
+// Mounts returns the mounts of the wrapped discoverer that are associated with
+// the requested driver capabilities.
+func (d *capabilityFilteredMounts) Mounts() ([]discover.Mount, error) {
+	mounts, err := d.Discover.Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []discover.Mount
+	for _, m := range mounts {
+		capability, ok := driverFileCapabilities[driverFileName(m.Path)]
+		if ok && !d.capabilities.Has(capability) {
+			d.logger.Debugf("Skipping %v which requires the %q capability", m.Path, capability)
+			continue
+		}
+		selected = append(selected, m)
+	}
+	return selected, nil
+}
+
+// driverFileName returns the name used to identify a driver file. For
+// libraries, the version suffix following the .so extension is removed.
+func driverFileName(path string) string {
+	name := filepath.Base(path)
+	if before, _, found := strings.Cut(name, ".so"); found {
+		return before + ".so"
+	}
+	return name
+}
@@ -0,0 +1,98 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestFilterMountsByDriverCapabilities(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	mounts := []discover.Mount{
+		{Path: "/usr/lib64/libcuda.so.999.88.77"},
+		{Path: "/usr/lib64/libnvidia-ml.so.999.88.77"},
+		{Path: "/usr/lib64/libnvidia-glcore.so.999.88.77"},
+		{Path: "/usr/lib64/libnvcuvid.so.999.88.77"},
+		{Path: "/usr/bin/nvidia-smi"},
+		{Path: "/usr/bin/nvidia-cuda-mps-control"},
+		{Path: "/lib/firmware/nvidia/999.88.77/gsp_ga10x.bin"},
+	}
+
+	testCases := []struct {
+		description    string
+		capabilities   image.DriverCapabilities
+		expectedMounts []discover.Mount
+	}{
+		{
+			description:    "no capabilities returns all mounts",
+			expectedMounts: mounts,
+		},
+		{
+			description:    "all returns all mounts",
+			capabilities:   image.NewDriverCapabilities("all"),
+			expectedMounts: mounts,
+		},
+		{
+			description:  "compute,utility",
+			capabilities: image.NewDriverCapabilities("compute,utility"),
+			expectedMounts: []discover.Mount{
+				{Path: "/usr/lib64/libcuda.so.999.88.77"},
+				{Path: "/usr/lib64/libnvidia-ml.so.999.88.77"},
+				{Path: "/usr/bin/nvidia-smi"},
+				{Path: "/usr/bin/nvidia-cuda-mps-control"},
+				{Path: "/lib/firmware/nvidia/999.88.77/gsp_ga10x.bin"},
+			},
+		},
+		{
+			description:  "graphics,video",
+			capabilities: image.NewDriverCapabilities("graphics,video"),
+			expectedMounts: []discover.Mount{
+				{Path: "/usr/lib64/libnvidia-glcore.so.999.88.77"},
+				{Path: "/usr/lib64/libnvcuvid.so.999.88.77"},
+				{Path: "/lib/firmware/nvidia/999.88.77/gsp_ga10x.bin"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvcdilib{
+				logger:             logger,
+				driverCapabilities: tc.capabilities,
+			}
+
+			d := l.filterMountsByDriverCapabilities(
+				&discover.DiscoverMock{
+					MountsFunc: func() ([]discover.Mount, error) {
+						return mounts, nil
+					},
+				},
+			)
+
+			filtered, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, filtered)
+		})
+	}
+}
@@ -56,13 +56,23 @@ func (l *nvcdilib) newDriverVersionDiscoverer() (discover.Discover, error) {
 		return nil, fmt.Errorf("failed to create discoverer for GSP firmware: %v", err)
 	}
 
-	binaries := l.newDriverBinariesDiscoverer()
+	binaries := discover.Merge(
+		l.filterMountsByDriverCapabilities(
+			discover.Merge(
+				l.newDriverBinariesDiscoverer(),
+				l.newNvidiaSMIDiscoverer(),
+			),
+		),
+		l.newMountBinariesDiscoverer(),
+	)
 
 	d := discover.Merge(
 		libraries,
 		ipcs,
 		firmwares,
 		binaries,
+		l.newVGPUGuestDiscoverer(),
+		l.newCUDACompatLibsDiscoverer(version),
 	)
 
 	return d, nil
@@ -72,7 +82,26 @@ func (l *nvcdilib) newIPCDiscoverer() (discover.Discover, error) {
 	if l.featureFlags[FeatureDisableIPCDiscoverer] {
 		return nil, nil
 	}
-	return discover.NewIPCDiscoverer(l.logger, l.driver.Root)
+	newIPCDiscoverer := discover.NewIPCDiscoverer
+	if l.featureFlags[FeatureDisablePersistencedSocket] {
+		newIPCDiscoverer = discover.NewIPCDiscovererWithoutPersistencedSocket
+	}
+	ipcs, err := newIPCDiscoverer(l.logger, l.driver.Root)
+	if err != nil {
+		return nil, err
+	}
+	return l.withDebugDump("ipc", ipcs), nil
+}
+
+// newCUDACompatLibsDiscoverer creates a discoverer for the CUDA forward
+// compatibility libraries installed under the driver root if these were
+// requested.
+func (l *nvcdilib) newCUDACompatLibsDiscoverer(version string) discover.Discover {
+	if !l.featureFlags[FeatureEnableCUDACompatLibraries] {
+		return nil
+	}
+	compatLibs := discover.NewCUDACompatLibsDiscoverer(l.logger, l.driver, l.hookCreator, version)
+	return l.withDebugDump("cuda-compat", compatLibs)
 }
 
 // NewDriverLibraryDiscoverer creates a discoverer for the libraries associated with the specified driver version.
@@ -90,11 +119,20 @@ func (l *nvcdilib) NewDriverLibraryDiscoverer(version string) (discover.Discover
 		return nil, err
 	}
 
-	libraries := discover.Merge(
-		versionSuffixLibraryMounts,
-		legacyNVVMLibraryMounts,
-		explicitLibraryMounts,
+	libraries := l.filterMountsByProbedCapabilities(
+		l.filterMountsByDriverCapabilities(
+			discover.Merge(
+				versionSuffixLibraryMounts,
+				legacyNVVMLibraryMounts,
+				explicitLibraryMounts,
+			),
+		),
 	)
+	libraries = discover.Merge(libraries, l.newMountBinaryLibrariesDiscoverer())
+
+	if !l.featureFlags[FeatureDisableLibraryDeduplication] {
+		libraries = discover.WithDedupedLibraries(l.logger, libraries, l.hookCreator)
+	}
 
 	var discoverers []discover.Discover
 
@@ -270,19 +308,25 @@ func (l *nvcdilib) newDriverFirmwareDiscoverer(version string) (discover.Discove
 
 // newDriverBinariesDiscoverer creates a discoverer for GSP firmware associated with the GPU driver.
 func (l *nvcdilib) newDriverBinariesDiscoverer() discover.Discover {
+	var binaries []string
+	// If an explicit nvidia-smi path is specified, this is handled by the
+	// nvidia-smi discoverer instead.
+	if l.nvidiaSMIPath == "" {
+		binaries = append(binaries, "nvidia-smi" /* System management interface */)
+	}
+	binaries = append(binaries,
+		"nvidia-debugdump",        /* GPU coredump utility */
+		"nvidia-persistenced",     /* Persistence mode utility */
+		"nvidia-cuda-mps-control", /* Multi process service CLI */
+		"nvidia-cuda-mps-server",  /* Multi process service server */
+		"nvidia-imex",             /* NVIDIA IMEX Daemon */
+		"nvidia-imex-ctl",         /* NVIDIA IMEX control */
+	)
 	return discover.NewMounts(
 		l.logger,
 		lookup.NewExecutableLocator(l.logger, l.driver.Root),
 		l.driver.Root,
-		[]string{
-			"nvidia-smi",              /* System management interface */
-			"nvidia-debugdump",        /* GPU coredump utility */
-			"nvidia-persistenced",     /* Persistence mode utility */
-			"nvidia-cuda-mps-control", /* Multi process service CLI */
-			"nvidia-cuda-mps-server",  /* Multi process service server */
-			"nvidia-imex",             /* NVIDIA IMEX Daemon */
-			"nvidia-imex-ctl",         /* NVIDIA IMEX control */
-		},
+		binaries,
 	)
 }
 
@@ -0,0 +1,42 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import "time"
+
+const (
+	// PhaseNVMLInit is the phase reported to a DurationRecorder for the time
+	// taken to initialize NVML.
+	PhaseNVMLInit = "nvml-init"
+	// PhaseMIGDiscovery is the phase reported to a DurationRecorder for the
+	// time taken to discover MIG devices and generate their CDI device specs.
+	PhaseMIGDiscovery = "mig-discovery"
+)
+
+// A DurationRecorder records the time taken by a phase of CDI spec generation.
+// A phase may be reported more than once, in which case the durations are
+// expected to be accumulated.
+type DurationRecorder func(phase string, d time.Duration)
+
+// recordDuration reports the time since the specified start to the configured
+// duration recorder, if any.
+func (l *nvmllib) recordDuration(phase string, start time.Time) {
+	if l.durationRecorder == nil {
+		return
+	}
+	l.durationRecorder(phase, time.Since(start))
+}
@@ -18,6 +18,7 @@ package nvcdi
 
 import (
 	"fmt"
+	"maps"
 
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
@@ -29,6 +30,10 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/dgpu"
 )
 
+// UUIDAnnotation is the device annotation used to record the UUID of a full
+// GPU or MIG device if UUID annotations are enabled.
+const UUIDAnnotation = "nvidia.com/uuid"
+
 // A fullGPUDeviceSpecGenerator generates the CDI device specifications for a
 // single full GPU.
 type fullGPUDeviceSpecGenerator struct {
@@ -94,6 +99,12 @@ func (l *fullGPUDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
 		l.logger.Warningf("Ignoring error getting device annotations for device(s) %v: %v", names, err)
 		annotations = nil
 	}
+	if l.featureFlags[FeatureEnableUUIDAnnotations] {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[UUIDAnnotation] = l.uuid
+	}
 	var deviceSpecs []specs.Device
 	for _, name := range names {
 		deviceSpec := specs.Device{
@@ -104,6 +115,10 @@ func (l *fullGPUDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
 		deviceSpecs = append(deviceSpecs, deviceSpec)
 	}
 
+	if l.deviceInfoRecorder != nil {
+		l.deviceInfoRecorder(l.getDeviceInfo(names))
+	}
+
 	return deviceSpecs, nil
 }
 
@@ -112,25 +127,35 @@ func (l *fullGPUDeviceSpecGenerator) device() (device.Device, error) {
 }
 
 func (l *fullGPUDeviceSpecGenerator) getDeviceAnnotations() (map[string]string, error) {
-	if !l.featureFlags[FeatureEnableCoherentAnnotations] {
-		return nil, nil
-	}
-
 	device, err := l.device()
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Should we distinguish between not-supported and disabled?
-	isCoherent, err := device.IsCoherent()
-	if err != nil {
-		return nil, fmt.Errorf("failed to check device coherence: %w", err)
+	annotations := make(map[string]string)
+	maps.Copy(annotations, l.getNUMANodeAnnotations(device))
+	maps.Copy(annotations, l.getPCIAddressAndSerialAnnotations(device))
+	maps.Copy(annotations, l.getComputeCapabilityAnnotations(device))
+	if l.featureFlags[FeatureEnableCoherentAnnotations] {
+		// TODO: Should we distinguish between not-supported and disabled?
+		isCoherent, err := device.IsCoherent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check device coherence: %w", err)
+		}
+		annotations["gpu.nvidia.com/coherent"] = fmt.Sprintf("%v", isCoherent)
 	}
 
-	annotations := map[string]string{
-		"gpu.nvidia.com/coherent": fmt.Sprintf("%v", isCoherent),
+	if l.featureFlags[FeatureEnableDisplayAnnotations] {
+		displayMode, ret := device.GetDisplayMode()
+		if ret != nvml.SUCCESS && ret != nvml.ERROR_NOT_SUPPORTED {
+			return nil, fmt.Errorf("failed to get device display mode: %v", ret)
+		}
+		annotations["gpu.nvidia.com/display"] = fmt.Sprintf("%v", displayMode == nvml.FEATURE_ENABLED)
 	}
 
+	if len(annotations) == 0 {
+		return nil, nil
+	}
 	return annotations, nil
 }
 
@@ -169,8 +194,11 @@ func (l *fullGPUDeviceSpecGenerator) newFullGPUDiscoverer(d device.Device) (disc
 		return nil, fmt.Errorf("failed to create device discoverer: %v", err)
 	}
 
-	deviceFolderPermissionHooks := (*nvcdilib)(l.nvmllib).newDeviceFolderPermissionHookDiscoverer(
-		deviceNodes,
+	deviceFolderPermissionHooks := (*nvcdilib)(l.nvmllib).withDebugDump(
+		fmt.Sprintf("device %d permission hooks", l.index),
+		(*nvcdilib)(l.nvmllib).newDeviceFolderPermissionHookDiscoverer(
+			deviceNodes,
+		),
 	)
 
 	var discoverers []discover.Discover
@@ -180,11 +208,15 @@ func (l *fullGPUDeviceSpecGenerator) newFullGPUDiscoverer(d device.Device) (disc
 		deviceFolderPermissionHooks,
 	)
 
+	if clocksHook := (*nvcdilib)(l.nvmllib).newApplicationClocksHookDiscoverer(l.uuid); clocksHook != nil {
+		discoverers = append(discoverers, clocksHook)
+	}
+
 	discoverers = append(discoverers, l.additionalDiscoverers...)
 
 	dd := discover.Merge(
 		discoverers...,
 	)
 
-	return dd, nil
+	return (*nvcdilib)(l.nvmllib).withDebugDump(fmt.Sprintf("device %d", l.index), dd), nil
 }
@@ -227,13 +227,15 @@ func (l *csvlib) GetCommonEdits() (*cdi.ContainerEdits, error) {
 	return l.editsFactory.FromDiscoverer(driverDiscoverer)
 }
 
-func (l *mixedcsvlib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, error) {
+func (l *mixedcsvlib) DeviceSpecGenerators(ids ...string) (_ DeviceSpecGenerator, rerr error) {
 	asNvmlLib := (*nvmllib)(l)
 	err := asNvmlLib.init()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize nvml: %w", err)
 	}
-	defer asNvmlLib.tryShutdown()
+	defer func() {
+		rerr = asNvmlLib.shutdownWithError(rerr)
+	}()
 
 	if slices.Contains(ids, "all") {
 		ids, err = l.getAllDeviceIndices()
@@ -464,7 +466,9 @@ func (l *csvlib) getEnableCUDACompatHookOptions() (*discover.EnableCUDACompatHoo
 		return nil, fmt.Errorf("failed to initialize NVML: %v", ret)
 	}
 	defer func() {
-		_ = l.nvmllib.Shutdown()
+		if r := l.nvmllib.Shutdown(); r != nvml.SUCCESS {
+			l.logger.Warningf("failed to shutdown NVML: %v", r)
+		}
 	}()
 
 	var cudaCompatContainerRoot string
@@ -357,6 +357,9 @@ func mockIGXServer() nvml.Interface {
 				Bus: 3,
 			}, nvml.SUCCESS
 		},
+		GetSerialFunc: func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		},
 		GetMinorNumberFunc: func() (int, nvml.Return) {
 			return 1, nvml.SUCCESS
 		},
@@ -369,6 +372,13 @@ func mockIGXServer() nvml.Interface {
 		ShutdownFunc: func() nvml.Return {
 			return nvml.SUCCESS
 		},
+		ExtensionsFunc: func() nvml.ExtendedInterface {
+			return &mock.ExtendedInterface{
+				LookupSymbolFunc: func(s string) error {
+					return nvml.ERROR_FUNCTION_NOT_FOUND
+				},
+			}
+		},
 		SystemGetDriverVersionFunc: func() (string, nvml.Return) {
 			return "540.3.0", nvml.SUCCESS
 		},
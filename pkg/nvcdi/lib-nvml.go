@@ -17,9 +17,13 @@
 package nvcdi
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
@@ -35,12 +39,16 @@ var _ deviceSpecGeneratorFactory = (*nvmllib)(nil)
 
 // GetCommonEdits generates a CDI specification that can be used for ANY devices
 func (l *nvmllib) GetCommonEdits() (*cdi.ContainerEdits, error) {
+	if err := l.probeCapabilities(); err != nil {
+		return nil, fmt.Errorf("failed to probe device capabilities: %w", err)
+	}
+
 	common, err := l.newCommonNVMLDiscoverer()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discoverer for common entities: %v", err)
 	}
 
-	return l.editsFactory.FromDiscoverer(common)
+	return l.editsFactory.FromDiscoverer((*nvcdilib)(l).withDebugDump("common", common))
 }
 
 // DeviceSpecGenerators returns the CDI device spec generators for NVML devices
@@ -49,11 +57,13 @@ func (l *nvmllib) GetCommonEdits() (*cdi.ContainerEdits, error) {
 // * an index of a GPU or MIG device
 // * a UUID of a GPU or MIG device
 // * the special ID 'all'
-func (l *nvmllib) DeviceSpecGenerators(ids ...string) (DeviceSpecGenerator, error) {
+func (l *nvmllib) DeviceSpecGenerators(ids ...string) (_ DeviceSpecGenerator, rerr error) {
 	if err := l.init(); err != nil {
 		return nil, err
 	}
-	defer l.tryShutdown()
+	defer func() {
+		rerr = l.shutdownWithError(rerr)
+	}()
 
 	dsgs, err := l.getDeviceSpecGeneratorsForIDs(ids...)
 	if err != nil {
@@ -79,11 +89,20 @@ func (l *nvmllib) getDeviceSpecGeneratorsForIDs(ids ...string) (DeviceSpecGenera
 		return nil, err
 	}
 
+	migDeviceSpecGenerators, err := l.getMIGDeviceSpecGeneratorsForUUIDs(uuids)
+	if err != nil {
+		return nil, err
+	}
+
 	var DeviceSpecGenerators DeviceSpecGenerators
 	for _, uuid := range uuids {
+		if generator, ok := migDeviceSpecGenerators[uuid]; ok {
+			DeviceSpecGenerators = append(DeviceSpecGenerators, generator)
+			continue
+		}
 		device, ret := l.nvmllib.DeviceGetHandleByUUID(string(uuid))
 		if ret != nvml.SUCCESS {
-			return nil, fmt.Errorf("failed to get device handle from UUID: %v", ret)
+			return nil, fmt.Errorf("failed to get device handle from UUID %q: %v", uuid, ret)
 		}
 		generator, err := l.newDeviceSpecGeneratorFromNVMLDevice(string(uuid), device)
 		if err != nil {
@@ -95,6 +114,54 @@ func (l *nvmllib) getDeviceSpecGeneratorsForIDs(ids ...string) (DeviceSpecGenera
 	return DeviceSpecGenerators, nil
 }
 
+// getMIGDeviceSpecGeneratorsForUUIDs returns the CDI device spec generators
+// for the MIG devices with the specified UUIDs. Instead of requesting a handle
+// by UUID, the MIG devices are visited and matched on the UUID of each MIG
+// device. Identifiers that are not MIG UUIDs, as well as MIG UUIDs in the
+// legacy MIG-GPU-<uuid>/<gi>/<ci> format, are ignored. An error is returned
+// if a requested MIG device is not found.
+func (l *nvmllib) getMIGDeviceSpecGeneratorsForUUIDs(uuids []device.Identifier) (map[device.Identifier]DeviceSpecGenerator, error) {
+	requested := make(map[device.Identifier]bool)
+	for _, uuid := range uuids {
+		if uuid.IsMigUUID() && !strings.Contains(string(uuid), "/") {
+			requested[uuid] = true
+		}
+	}
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	migDiscoveryStart := time.Now()
+	generators := make(map[device.Identifier]DeviceSpecGenerator)
+	err := l.devicelib.VisitMigDevices(func(i int, d device.Device, j int, mig device.MigDevice) error {
+		uuid, ret := mig.GetUUID()
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to get MIG UUID: %v", ret)
+		}
+		if !requested[device.Identifier(uuid)] {
+			return nil
+		}
+		migDevice, err := l.newMIGDeviceSpecGeneratorFromDevice(i, d, j, mig)
+		if err != nil {
+			return err
+		}
+		l.deviceSelection.logf("Including MIG device %d:%d (%v)", i, j, uuid)
+		generators[device.Identifier(uuid)] = migDevice
+		return nil
+	})
+	l.recordDuration(PhaseMIGDiscovery, migDiscoveryStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MIG device editors: %w", err)
+	}
+
+	for _, uuid := range uuids {
+		if requested[uuid] && generators[uuid] == nil {
+			return nil, fmt.Errorf("MIG device with UUID %q not found", uuid)
+		}
+	}
+	return generators, nil
+}
+
 func (l *nvmllib) newDeviceSpecGeneratorFromNVMLDevice(id string, nvmlDevice nvml.Device) (DeviceSpecGenerator, error) {
 	isMig, ret := nvmlDevice.IsMigDeviceHandle()
 	if ret != nvml.SUCCESS {
@@ -109,41 +176,135 @@ func (l *nvmllib) newDeviceSpecGeneratorFromNVMLDevice(id string, nvmlDevice nvm
 
 // getDeviceSpecGeneratorsForAllDevices returns the CDI device spec generators
 // for all NVML devices detected on the system.
-// This includes full GPUs as well as MIG devices.
+// This includes full GPUs as well as MIG devices. If only MIG parents are
+// requested, all full GPUs are included regardless of whether MIG mode is
+// enabled and MIG devices are never included.
+// In best-effort mode, a failure for a specific device does not abort the
+// generation. Instead the failure is recorded and reported when the device
+// specs are generated.
 func (l *nvmllib) getDeviceSpecGeneratorsForAllDevices() (DeviceSpecGenerator, error) {
-	var DeviceSpecGenerators DeviceSpecGenerators
+	collector := l.newDeviceSpecGeneratorCollector()
+	onlyMIGParents := l.featureFlags[FeatureOnlyMIGParents]
+
+	// migEnabledDevices tracks the UUIDs of MIG-enabled GPUs by index so that
+	// we can detect GPUs for which no MIG devices have been configured.
+	migEnabledDevices := make(map[int]string)
 	err := l.devicelib.VisitDevices(func(i int, d device.Device) error {
-		isMigEnabled, err := d.IsMigEnabled()
-		if err != nil {
-			return err
-		}
-		if isMigEnabled {
-			return nil
+		id := strconv.Itoa(i)
+		if !onlyMIGParents {
+			isMigEnabled, err := d.IsMigEnabled()
+			if err != nil {
+				return collector.fail(id, err)
+			}
+			if isMigEnabled {
+				uuid, ret := d.GetUUID()
+				if ret != nvml.SUCCESS {
+					return collector.fail(id, fmt.Errorf("failed to get device UUID: %v", ret))
+				}
+				migEnabledDevices[i] = uuid
+				if l.featureFlags[FeatureEnableMIGParentPlaceholders] {
+					placeholder, err := l.newMIGParentPlaceholderFromDevice(i, d)
+					if err != nil {
+						return collector.fail(id, err)
+					}
+					collector.add(id, placeholder)
+				}
+				l.logMIGEnabledDevice(i, uuid)
+				return nil
+			}
 		}
 		fullGPU, err := l.newFullGPUDeviceSpecGeneratorFromDevice(i, d, l.featureFlags)
 		if err != nil {
-			return err
+			return collector.fail(id, err)
+		}
+		if onlyMIGParents {
+			l.deviceSelection.logf("Including GPU %d (%v); MIG devices are not included", i, fullGPU.uuid)
+		} else {
+			l.deviceSelection.logf("Including GPU %d (%v)", i, fullGPU.uuid)
 		}
-		DeviceSpecGenerators = append(DeviceSpecGenerators, fullGPU)
+		collector.add(id, fullGPU)
 		return nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get full GPU device editors: %w", err)
 	}
 
-	err = l.devicelib.VisitMigDevices(func(i int, d device.Device, j int, mig device.MigDevice) error {
-		migDevice, err := l.newMIGDeviceSpecGeneratorFromDevice(i, d, j, mig)
+	// On nodes without MIG-enabled GPUs there are no MIG devices to visit. We
+	// return early so that the MIG mode of each GPU is not queried again.
+	if len(migEnabledDevices) == 0 {
+		return collector.deviceSpecGenerators(), nil
+	}
+
+	migDiscoveryStart := time.Now()
+	err = l.devicelib.VisitDevices(func(i int, d device.Device) error {
+		if _, isMigEnabled := migEnabledDevices[i]; !isMigEnabled {
+			return nil
+		}
+		err := d.VisitMigDevices(func(j int, mig device.MigDevice) error {
+			delete(migEnabledDevices, i)
+			id := fmt.Sprintf("%d:%d", i, j)
+			migDevice, err := l.newMIGDeviceSpecGeneratorFromDevice(i, d, j, mig)
+			if err != nil {
+				return collector.fail(id, err)
+			}
+			l.deviceSelection.logf("Including MIG device %d:%d (%v)", i, j, migDevice.migUUID)
+			collector.add(id, migDevice)
+			return nil
+		})
 		if err != nil {
-			return err
+			delete(migEnabledDevices, i)
+			return collector.fail(strconv.Itoa(i), fmt.Errorf("failed to get MIG devices: %w", err))
 		}
-		DeviceSpecGenerators = append(DeviceSpecGenerators, migDevice)
 		return nil
 	})
+	l.recordDuration(PhaseMIGDiscovery, migDiscoveryStart)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get MIG device editors: %w", err)
 	}
 
-	return DeviceSpecGenerators, nil
+	if err := l.checkUnconfiguredMigDevices(collector, migEnabledDevices); err != nil {
+		return nil, err
+	}
+
+	return collector.deviceSpecGenerators(), nil
+}
+
+// newDeviceSpecGeneratorCollector returns the collector for the device spec
+// generators of visited devices. In best-effort mode, failures for specific
+// devices are recorded instead of aborting the visitation of devices.
+func (l *nvmllib) newDeviceSpecGeneratorCollector() deviceSpecGeneratorCollector {
+	if l.bestEffort {
+		return &bestEffortDeviceSpecGenerators{}
+	}
+	return &strictDeviceSpecGenerators{}
+}
+
+// logMIGEnabledDevice logs that the specified MIG-enabled GPU is not included
+// as a full GPU.
+func (l *nvmllib) logMIGEnabledDevice(index int, uuid string) {
+	if l.featureFlags[FeatureEnableMIGParentPlaceholders] {
+		l.deviceSelection.logf("Including GPU %d (%v) as a non-allocatable placeholder: MIG mode is enabled; its MIG devices are included instead", index, uuid)
+		return
+	}
+	l.deviceSelection.logf("Skipping GPU %d (%v): MIG mode is enabled; its MIG devices are included instead", index, uuid)
+}
+
+// checkUnconfiguredMigDevices reports a failure for each of the specified
+// MIG-enabled GPUs. These are GPUs with MIG mode enabled for which no MIG
+// devices have been configured, meaning that they would not be included in the
+// generated spec at all.
+// If empty devices are allowed, a warning is logged instead.
+func (l *nvmllib) checkUnconfiguredMigDevices(collector deviceSpecGeneratorCollector, migEnabledDevices map[int]string) error {
+	var errs error
+	for _, i := range slices.Sorted(maps.Keys(migEnabledDevices)) {
+		err := fmt.Errorf("GPU %d (%v) has MIG mode enabled but no MIG devices are configured", i, migEnabledDevices[i])
+		if l.allowEmpty {
+			l.logger.Warningf("Skipping device: %v", err)
+			continue
+		}
+		errs = errors.Join(errs, collector.fail(strconv.Itoa(i), err))
+	}
+	return errs
 }
 
 // TODO: move this to go-nvlib?
@@ -212,7 +373,10 @@ func (l *nvmllib) normalizeDeviceID(id device.Identifier) (device.Identifier, er
 }
 
 func (l *nvmllib) init() error {
-	if r := l.nvmllib.Init(); r != nvml.SUCCESS {
+	start := time.Now()
+	r := l.nvmllib.Init()
+	l.recordDuration(PhaseNVMLInit, start)
+	if r != nvml.SUCCESS {
 		return fmt.Errorf("failed to initialize NVML: %w", r)
 	}
 
@@ -226,15 +390,44 @@ func (l *nvmllib) init() error {
 	return nil
 }
 
-func (l *nvmllib) tryShutdown() {
+// shutdown shuts down the NVML and nvsandboxutils libraries. The returned
+// error wraps the return codes of the failed shutdowns so that callers can
+// detect specific failures.
+func (l *nvmllib) shutdown() error {
+	var errs error
 	if l.nvsandboxutilslib != nil {
 		if r := l.nvsandboxutilslib.Shutdown(); r != nvsandboxutils.SUCCESS {
-			l.logger.Warningf("failed to shutdown nvsandboxutils: %v", r)
+			errs = errors.Join(errs, fmt.Errorf("failed to shutdown nvsandboxutils: %w", r))
 		}
 	}
 	if r := l.nvmllib.Shutdown(); r != nvml.SUCCESS {
-		l.logger.Warningf("failed to shutdown NVML: %v", r)
+		errs = errors.Join(errs, fmt.Errorf("failed to shutdown NVML: %w", r))
+	}
+	return errs
+}
+
+// tryShutdown shuts down the NVML and nvsandboxutils libraries, logging any
+// failures.
+func (l *nvmllib) tryShutdown() {
+	if err := l.shutdown(); err != nil {
+		l.logger.Warningf("%v", err)
+	}
+}
+
+// shutdownWithError shuts down the NVML and nvsandboxutils libraries and
+// combines any failures with the specified error. Shutdown failures are only
+// returned if the strict-nvml-shutdown feature flag is set and are otherwise
+// logged.
+func (l *nvmllib) shutdownWithError(err error) error {
+	shutdownErr := l.shutdown()
+	if shutdownErr == nil {
+		return err
+	}
+	if !l.featureFlags[FeatureStrictNVMLShutdown] {
+		l.logger.Warningf("%v", shutdownErr)
+		return err
 	}
+	return errors.Join(err, shutdownErr)
 }
 
 type deviceSpecGeneratorsWithAndShutdown struct {
@@ -251,11 +444,13 @@ func (l *nvmllib) withInit(dsg DeviceSpecGenerator) DeviceSpecGenerator {
 
 // GetDeviceSpecs ensures that the init and shutdown are called before (and
 // after) generating the required device specs.
-func (d *deviceSpecGeneratorsWithAndShutdown) GetDeviceSpecs() ([]specs.Device, error) {
+func (d *deviceSpecGeneratorsWithAndShutdown) GetDeviceSpecs() (_ []specs.Device, rerr error) {
 	if err := d.init(); err != nil {
 		return nil, err
 	}
-	defer d.tryShutdown()
+	defer func() {
+		rerr = d.shutdownWithError(rerr)
+	}()
 
 	return d.DeviceSpecGenerator.GetDeviceSpecs()
 }
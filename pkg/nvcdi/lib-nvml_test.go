@@ -18,12 +18,17 @@
 package nvcdi
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 	mocknvml "github.com/NVIDIA/go-nvml/pkg/nvml/mock"
 	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
 	mockserver "github.com/NVIDIA/go-nvml/pkg/nvml/mock/server"
+	"github.com/sirupsen/logrus"
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
@@ -33,6 +38,8 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 	testCases := []struct {
 		name               string
 		ids                []string
+		allowEmpty         bool
+		featureFlags       map[FeatureFlag]bool
 		setupMock          func(*mockserver.Server)
 		expectedError      error
 		expectedLength     int
@@ -115,6 +122,84 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 			expectedError:  nil,
 			expectedLength: 1,
 		},
+		{
+			name: "MIG UUID",
+			ids:  []string{"MIG-12345678-1234-1234-1234-123456789abc"},
+			setupMock: func(server *mockserver.Server) {
+				setupMIGDevice(server, "MIG-12345678-1234-1234-1234-123456789abc")
+				server.DeviceGetHandleByUUIDFunc = func(s string) (nvml.Device, nvml.Return) {
+					panic("MIG devices should be matched by visiting the MIG devices")
+				}
+			},
+			expectedError:  nil,
+			expectedLength: 1,
+		},
+		{
+			name: "MIG UUID and GPU index",
+			ids:  []string{"MIG-12345678-1234-1234-1234-123456789abc", "1"},
+			setupMock: func(server *mockserver.Server) {
+				setupMIGDevice(server, "MIG-12345678-1234-1234-1234-123456789abc")
+				for _, d := range server.Devices {
+					// TODO: This is not implemented in the mock.
+					(d.(*mockserver.Device)).IsMigDeviceHandleFunc = func() (bool, nvml.Return) {
+						return false, nvml.SUCCESS
+					}
+				}
+			},
+			expectedError:  nil,
+			expectedLength: 2,
+		},
+		{
+			name: "missing MIG UUID",
+			ids:  []string{"MIG-87654321-1234-1234-1234-123456789abc"},
+			setupMock: func(server *mockserver.Server) {
+				setupMIGDevice(server, "MIG-12345678-1234-1234-1234-123456789abc")
+			},
+			expectedError: errors.New(`MIG device with UUID "MIG-87654321-1234-1234-1234-123456789abc" not found`),
+		},
+		{
+			name: "MIG enabled GPU without MIG devices",
+			ids:  []string{"all"},
+			setupMock: func(server *mockserver.Server) {
+				server.Devices[0].(*mockserver.Device).UUID = "GPU-12345678-1234-1234-1234-123456789abc"
+				server.Devices[0].(*mockserver.Device).MigMode = nvml.DEVICE_MIG_ENABLE
+			},
+			expectedError: errors.Join(errors.New("GPU 0 (GPU-12345678-1234-1234-1234-123456789abc) has MIG mode enabled but no MIG devices are configured")),
+		},
+		{
+			name:       "MIG enabled GPU without MIG devices allowed",
+			ids:        []string{"all"},
+			allowEmpty: true,
+			setupMock: func(server *mockserver.Server) {
+				server.Devices[0].(*mockserver.Device).MigMode = nvml.DEVICE_MIG_ENABLE
+			},
+			expectedError:  nil,
+			expectedLength: 7,
+		},
+		{
+			name:         "MIG parent placeholder for MIG enabled GPU",
+			ids:          []string{"all"},
+			allowEmpty:   true,
+			featureFlags: map[FeatureFlag]bool{FeatureEnableMIGParentPlaceholders: true},
+			setupMock: func(server *mockserver.Server) {
+				server.Devices[0].(*mockserver.Device).MigMode = nvml.DEVICE_MIG_ENABLE
+			},
+			expectedError:  nil,
+			expectedLength: 8,
+		},
+		{
+			name:         "only MIG parents includes MIG enabled GPU",
+			ids:          []string{"all"},
+			featureFlags: map[FeatureFlag]bool{FeatureOnlyMIGParents: true},
+			setupMock: func(server *mockserver.Server) {
+				server.Devices[0].(*mockserver.Device).MigMode = nvml.DEVICE_MIG_ENABLE
+				server.Devices[0].(*mockserver.Device).GetMigDeviceHandleByIndexFunc = func(n int) (nvml.Device, nvml.Return) {
+					panic("MIG devices should not be visited")
+				}
+			},
+			expectedError:  nil,
+			expectedLength: 8,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -128,21 +213,58 @@ func TestNvmllibGetDeviceSpecGeneratorsForIDs(t *testing.T) {
 
 			mockDev := device.New(mockNvml)
 
+			logger, _ := testlog.NewNullLogger()
 			l := &nvmllib{
+				logger: logger,
 				platformlibs: platformlibs{
 					nvmllib:   mockNvml,
 					devicelib: mockDev,
 				},
+				allowEmpty:   tc.allowEmpty,
+				featureFlags: tc.featureFlags,
 			}
 			// Call the function under test
 			generators, err := l.getDeviceSpecGeneratorsForIDs(tc.ids...)
 
 			require.EqualValues(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
 			require.Len(t, generators, tc.expectedLength)
 		})
 	}
 }
 
+// setupMIGDevice enables MIG mode on the first GPU of the specified server
+// and configures a single MIG device with the specified UUID.
+func setupMIGDevice(server *mockserver.Server, uuid string) {
+	parent := server.Devices[0].(*mockserver.Device)
+	mig := &mocknvml.Device{
+		IsMigDeviceHandleFunc: func() (bool, nvml.Return) {
+			return true, nvml.SUCCESS
+		},
+		GetDeviceHandleFromMigDeviceHandleFunc: func() (nvml.Device, nvml.Return) {
+			return parent, nvml.SUCCESS
+		},
+		GetIndexFunc: func() (int, nvml.Return) {
+			return 0, nvml.SUCCESS
+		},
+		GetUUIDFunc: func() (string, nvml.Return) {
+			return uuid, nvml.SUCCESS
+		},
+	}
+	parent.MigMode = nvml.DEVICE_MIG_ENABLE
+	parent.GetMaxMigDeviceCountFunc = func() (int, nvml.Return) {
+		return 1, nvml.SUCCESS
+	}
+	parent.GetMigDeviceHandleByIndexFunc = func(n int) (nvml.Device, nvml.Return) {
+		if n != 0 {
+			return nil, nvml.ERROR_NOT_FOUND
+		}
+		return mig, nvml.SUCCESS
+	}
+}
+
 // TODO: These need to be implemented in go-nvlib
 func mockOverrides(server *mockserver.Server) {
 	for i, d := range server.Devices {
@@ -156,5 +278,216 @@ func mockOverrides(server *mockserver.Server) {
 		(d.(*mockserver.Device)).GetUUIDFunc = func() (string, nvml.Return) {
 			return d.(*mockserver.Device).UUID, nvml.SUCCESS
 		}
+		(d.(*mockserver.Device)).GetSerialFunc = func() (string, nvml.Return) {
+			return "", nvml.ERROR_NOT_SUPPORTED
+		}
+	}
+}
+
+func TestNvmllibDeviceSpecGeneratorsShutdownError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		featureFlags  map[FeatureFlag]bool
+		expectedError error
+	}{
+		{
+			name:          "shutdown error is logged",
+			expectedError: nil,
+		},
+		{
+			name: "shutdown error is returned in strict mode",
+			featureFlags: map[FeatureFlag]bool{
+				FeatureStrictNVMLShutdown: true,
+			},
+			expectedError: nvml.ERROR_UNINITIALIZED,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockNvml := dgxa100.New()
+			mockOverrides(mockNvml)
+			mockNvml.ShutdownFunc = func() nvml.Return {
+				return nvml.ERROR_UNINITIALIZED
+			}
+
+			logger, _ := testlog.NewNullLogger()
+			l := &nvmllib{
+				logger: logger,
+				platformlibs: platformlibs{
+					nvmllib:   mockNvml,
+					devicelib: device.New(mockNvml),
+				},
+				featureFlags: tc.featureFlags,
+			}
+
+			_, err := l.DeviceSpecGenerators("all")
+			if tc.expectedError == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestNvmllibMigModeQueriedOnceWithoutMig(t *testing.T) {
+	testCases := []struct {
+		description string
+		bestEffort  bool
+	}{
+		{
+			description: "default",
+		},
+		{
+			description: "best effort",
+			bestEffort:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockNvml := dgxa100.New()
+			mockOverrides(mockNvml)
+
+			logger, _ := testlog.NewNullLogger()
+			l := &nvmllib{
+				logger: logger,
+				platformlibs: platformlibs{
+					nvmllib:   mockNvml,
+					devicelib: device.New(mockNvml),
+				},
+				bestEffort: tc.bestEffort,
+			}
+
+			_, err := l.getDeviceSpecGeneratorsForIDs("all")
+			require.NoError(t, err)
+
+			require.Equal(t, len(mockNvml.Devices), countMigModeCalls(mockNvml))
+		})
+	}
+}
+
+func TestNvmllibRecordsDurations(t *testing.T) {
+	mockNvml := dgxa100.New()
+	mockOverrides(mockNvml)
+
+	recorded := make(map[string]int)
+	logger, _ := testlog.NewNullLogger()
+	l := &nvmllib{
+		logger: logger,
+		platformlibs: platformlibs{
+			nvmllib:   mockNvml,
+			devicelib: device.New(mockNvml),
+		},
+		durationRecorder: func(phase string, d time.Duration) {
+			require.GreaterOrEqual(t, d, time.Duration(0))
+			recorded[phase]++
+		},
+	}
+
+	require.NoError(t, l.init())
+	require.Equal(t, map[string]int{PhaseNVMLInit: 1}, recorded)
+
+	// Since no GPUs have MIG mode enabled, MIG discovery is skipped and is
+	// not recorded.
+	_, err := l.getDeviceSpecGeneratorsForIDs("all")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{PhaseNVMLInit: 1}, recorded)
+}
+
+func TestNvmllibLogsDeviceSelection(t *testing.T) {
+	testCases := []struct {
+		description   string
+		verbose       bool
+		expectedLevel logrus.Level
+	}{
+		{
+			description:   "selection is logged at debug level by default",
+			expectedLevel: logrus.DebugLevel,
+		},
+		{
+			description:   "selection is logged at info level if verbose",
+			verbose:       true,
+			expectedLevel: logrus.InfoLevel,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockNvml := dgxa100.New()
+			mockOverrides(mockNvml)
+			for i, d := range mockNvml.Devices {
+				d.(*dgxa100.Device).GetPciInfoFunc = func() (nvml.PciInfo, nvml.Return) {
+					var info nvml.PciInfo
+					for j, c := range fmt.Sprintf("00000000:%02X:00.0", i) {
+						info.BusId[j] = int8(c)
+					}
+					return info, nvml.SUCCESS
+				}
+			}
+
+			logger, hook := testlog.NewNullLogger()
+			logger.SetLevel(logrus.DebugLevel)
+			selection := (&options{logger: logger, verboseDeviceSelection: tc.verbose}).deviceSelectionLogger()
+			l := &nvmllib{
+				logger: logger,
+				platformlibs: platformlibs{
+					nvmllib: mockNvml,
+					devicelib: newMaxDevicesFilter(selection,
+						newPCIBusIDFilter(selection, device.New(mockNvml), nil, []string{"0000:00:00.0"}),
+						2,
+					),
+				},
+				deviceSelection: selection,
+			}
+
+			_, err := l.getDeviceSpecGeneratorsForIDs("all")
+			require.NoError(t, err)
+
+			var messages []string
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == tc.expectedLevel {
+					messages = append(messages, entry.Message)
+				}
+			}
+			require.Subset(t, messages, []string{
+				"Skipping GPU 0: PCI bus ID 0000:00:00.0 is excluded",
+				"Including GPU 1 (" + mockNvml.Devices[1].(*dgxa100.Device).UUID + ")",
+				"Including GPU 2 (" + mockNvml.Devices[2].(*dgxa100.Device).UUID + ")",
+				"Skipping GPU 3: the maximum number of devices (2) has been reached",
+			})
+		})
+	}
+}
+
+func BenchmarkNvmllibGetDeviceSpecGeneratorsWithoutMig(b *testing.B) {
+	mockNvml := dgxa100.New()
+	mockOverrides(mockNvml)
+
+	logger, _ := testlog.NewNullLogger()
+	l := &nvmllib{
+		logger: logger,
+		platformlibs: platformlibs{
+			nvmllib:   mockNvml,
+			devicelib: device.New(mockNvml),
+		},
+	}
+
+	for b.Loop() {
+		if _, err := l.getDeviceSpecGeneratorsForIDs("all"); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(countMigModeCalls(mockNvml))/float64(b.N), "mig-mode-queries/op")
+}
+
+// countMigModeCalls returns the number of times that the MIG mode was queried
+// for the devices of the specified mock server.
+func countMigModeCalls(server *mockserver.Server) int {
+	var calls int
+	for _, d := range server.Devices {
+		calls += len(d.(*mockserver.Device).GetMigModeCalls())
 	}
+	return calls
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
@@ -43,6 +44,67 @@ type nvcdilib struct {
 
 	featureFlags map[FeatureFlag]bool
 
+	// allowEmpty indicates whether devices that would result in no CDI devices
+	// (e.g. MIG-enabled GPUs without MIG devices) are skipped instead of
+	// triggering an error.
+	allowEmpty bool
+
+	// driverCapabilities restricts the driver files that are included to
+	// those associated with the specified capabilities. A nil value indicates
+	// that all capabilities are included.
+	driverCapabilities image.DriverCapabilities
+
+	// shouldProbeCapabilities indicates whether the devices are probed to
+	// determine which driver libraries for optional engines are included.
+	shouldProbeCapabilities bool
+	// unsupportedDriverFiles maps the driver libraries that are excluded since
+	// no device supports the required engine to the name of the engine.
+	unsupportedDriverFiles map[string]string
+
+	// dumpDiscovered indicates whether the discovered entities are logged.
+	dumpDiscovered bool
+
+	// bestEffort indicates whether devices for which CDI device specs cannot
+	// be generated are skipped instead of triggering an error.
+	bestEffort bool
+
+	// vgpuGuest indicates whether the files specific to the GRID guest driver
+	// in vGPU virtual machines are included.
+	vgpuGuest bool
+
+	// nvidiaSMIPath is the path to the nvidia-smi executable. If this is
+	// empty, nvidia-smi is located in the PATH.
+	nvidiaSMIPath string
+
+	// vulkanManifestDir is the directory in which rewritten Vulkan manifests
+	// are created.
+	vulkanManifestDir string
+
+	// mountBinaries are the names of additional host binaries that are
+	// mounted along with their library dependencies.
+	mountBinaries []string
+
+	// applicationClocks are the application clocks set for full GPUs when a
+	// container is started. A nil value indicates that these are not set.
+	applicationClocks *ApplicationClocks
+
+	// deviceNodeWait defines the device nodes that a container waits for when
+	// it is started. A nil value indicates that no wait hook is added.
+	deviceNodeWait *DeviceNodeWait
+
+	// deviceInfoRecorder is notified of the attributes of the devices for
+	// which device specs are generated. If this is nil, these attributes are
+	// not queried.
+	deviceInfoRecorder DeviceInfoRecorder
+
+	// deviceSelection logs whether enumerated devices are included in the
+	// generated spec or skipped.
+	deviceSelection deviceSelectionLogger
+
+	// durationRecorder is notified of the time taken by phases of CDI spec
+	// generation. If this is nil, durations are not recorded.
+	durationRecorder DurationRecorder
+
 	hookCreator  discover.HookCreator
 	editsFactory edits.Factory
 }
@@ -62,6 +124,21 @@ func New(opts ...Option) (Interface, error) {
 
 		librarySearchPaths: slices.Clone(o.librarySearchPaths),
 		featureFlags:       o.featureFlags,
+		allowEmpty:         o.allowEmpty,
+		driverCapabilities: o.driverCapabilities,
+		dumpDiscovered:     o.dumpDiscovered,
+		nvidiaSMIPath:      o.nvidiaSMIPath,
+		vulkanManifestDir:  o.vulkanManifestDir,
+		mountBinaries:      slices.Clone(o.mountBinaries),
+		vgpuGuest:          o.vgpuGuest,
+		applicationClocks:  o.applicationClocks,
+		deviceNodeWait:     o.deviceNodeWait,
+		bestEffort:         o.bestEffort,
+		durationRecorder:   o.durationRecorder,
+		deviceSelection:    o.deviceSelectionLogger(),
+		deviceInfoRecorder: o.deviceInfoRecorder,
+
+		shouldProbeCapabilities: o.probeCapabilities,
 
 		csv: o.csv,
 
@@ -70,6 +147,8 @@ func New(opts ...Option) (Interface, error) {
 			discover.WithEnabledHooks(o.enabledHooks...),
 			discover.WithLdconfigPath(o.ldconfigPath),
 			discover.WithDisabledHooks(o.disabledHooks...),
+			discover.WithChmodMode(o.deviceFolderMode),
+			discover.WithChmodGroup(o.deviceFolderGroup),
 		),
 		editsFactory: o.editsFactory,
 	}
@@ -104,6 +183,15 @@ func New(opts ...Option) (Interface, error) {
 	return &w, nil
 }
 
+// withDebugDump wraps the specified discoverer so that the discovered entities
+// are logged if this has been requested.
+func (l *nvcdilib) withDebugDump(name string, d discover.Discover) discover.Discover {
+	if !l.dumpDiscovered {
+		return d
+	}
+	return discover.WithDebugDump(l.logger, name, d)
+}
+
 type nvmllibAsVersioner struct {
 	nvml.Interface
 }
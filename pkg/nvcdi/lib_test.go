@@ -18,14 +18,18 @@
 package nvcdi
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	"github.com/sirupsen/logrus"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/devices"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/test"
 )
 
@@ -397,3 +401,47 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestLoggerFromContext(t *testing.T) {
+	explicitLogger, _ := testlog.NewNullLogger()
+	contextLogger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description    string
+		options        []Option
+		expectedLogger logger.Interface
+	}{
+		{
+			description:    "default logger is used",
+			expectedLogger: logrus.StandardLogger(),
+		},
+		{
+			description: "context logger is used",
+			options: []Option{
+				WithContext(ContextWithLogger(context.Background(), contextLogger)),
+			},
+			expectedLogger: contextLogger,
+		},
+		{
+			description: "explicit logger takes precedence",
+			options: []Option{
+				WithContext(ContextWithLogger(context.Background(), contextLogger)),
+				WithLogger(explicitLogger),
+			},
+			expectedLogger: explicitLogger,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			o := populateOptions(
+				append(tc.options,
+					WithMode(ModeNvml),
+					WithNvmlLib(dgxa100.New()),
+					WithFeatureFlags(FeatureDisableNvsandboxUtils),
+				)...,
+			)
+			require.Same(t, tc.expectedLogger, o.logger)
+		})
+	}
+}
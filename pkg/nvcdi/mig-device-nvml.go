@@ -18,6 +18,8 @@ package nvcdi
 
 import (
 	"fmt"
+	"maps"
+	"time"
 
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
@@ -27,6 +29,10 @@ import (
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/platform-support/dgpu"
 )
 
+// MIGAnnotation is the annotation used to mark MIG devices if MIG
+// annotations are enabled.
+const MIGAnnotation = "gpu.nvidia.com/mig"
+
 type migDeviceSpecGenerator struct {
 	*fullGPUDeviceSpecGenerator
 	migIndex int
@@ -39,6 +45,19 @@ func (l *migDeviceSpecGenerator) GetUUID() (string, error) {
 	return l.migUUID, nil
 }
 
+// GetProfile returns the name of the MIG profile for the MIG device.
+func (l *migDeviceSpecGenerator) GetProfile() (string, error) {
+	migDevice, err := l.migDevice()
+	if err != nil {
+		return "", err
+	}
+	profile, err := migDevice.GetProfile()
+	if err != nil {
+		return "", err
+	}
+	return profile.String(), nil
+}
+
 func (l *nvmllib) newMIGDeviceSpecGeneratorFromDevice(i int, d device.Device, j int, m device.MigDevice) (*migDeviceSpecGenerator, error) {
 	parent, err := l.newFullGPUDeviceSpecGeneratorFromDevice(i, d, make(map[FeatureFlag]bool))
 	if err != nil {
@@ -60,6 +79,8 @@ func (l *nvmllib) newMIGDeviceSpecGeneratorFromDevice(i int, d device.Device, j
 }
 
 func (l *nvmllib) newMIGDeviceSpecGeneratorFromNVMLDevice(uuid string, nvmlMIGDevice nvml.Device) (DeviceSpecGenerator, error) {
+	defer l.recordDuration(PhaseMIGDiscovery, time.Now())
+
 	migDevice, err := l.devicelib.NewMigDevice(nvmlMIGDevice)
 	if err != nil {
 		return nil, err
@@ -87,6 +108,8 @@ func (l *nvmllib) newMIGDeviceSpecGeneratorFromNVMLDevice(uuid string, nvmlMIGDe
 }
 
 func (l *migDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
+	defer l.recordDuration(PhaseMIGDiscovery, time.Now())
+
 	deviceEdits, err := l.getDeviceEdits()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CDI device edits: %w", err)
@@ -97,18 +120,50 @@ func (l *migDeviceSpecGenerator) GetDeviceSpecs() ([]specs.Device, error) {
 		return nil, fmt.Errorf("failed to get device names: %w", err)
 	}
 
+	annotations := l.getDeviceAnnotations()
+
 	var deviceSpecs []specs.Device
 	for _, name := range names {
 		deviceSpec := specs.Device{
 			Name:           name,
 			ContainerEdits: *deviceEdits.ContainerEdits,
+			Annotations:    annotations,
 		}
 		deviceSpecs = append(deviceSpecs, deviceSpec)
 	}
 
+	if l.deviceInfoRecorder != nil {
+		l.deviceInfoRecorder(l.getDeviceInfo(names))
+	}
+
 	return deviceSpecs, nil
 }
 
+// getDeviceAnnotations returns the annotations for the MIG device.
+// A MIG device has the same NUMA affinity, compute capability, PCI address,
+// and board serial number as its parent.
+func (l *migDeviceSpecGenerator) getDeviceAnnotations() map[string]string {
+	annotations := make(map[string]string)
+	if parent, err := l.device(); err == nil {
+		maps.Copy(annotations, l.getNUMANodeAnnotations(parent))
+		maps.Copy(annotations, l.getComputeCapabilityAnnotations(parent))
+		maps.Copy(annotations, l.getPCIAddressAndSerialAnnotations(parent))
+	}
+	// The feature flags of the parent generator are not propagated to MIG
+	// devices, so we check the feature flags of the library instead.
+	featureFlags := l.fullGPUDeviceSpecGenerator.nvmllib.featureFlags
+	if featureFlags[FeatureEnableMIGAnnotations] {
+		annotations[MIGAnnotation] = "true"
+	}
+	if featureFlags[FeatureEnableUUIDAnnotations] {
+		annotations[UUIDAnnotation] = l.migUUID
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
 func (l *migDeviceSpecGenerator) migDevice() (device.MigDevice, error) {
 	return l.devicelib.NewMigDeviceByUUID(l.migUUID)
 }
@@ -133,6 +188,8 @@ func (l *migDeviceSpecGenerator) getDeviceEdits() (*cdi.ContainerEdits, error) {
 		return nil, fmt.Errorf("failed to create device discoverer: %v", err)
 	}
 
+	deviceNodes = (*nvcdilib)(l.nvmllib).withDebugDump(fmt.Sprintf("device %d:%d", l.index, l.migIndex), deviceNodes)
+
 	editsForDevice, err := l.editsFactory.FromDiscoverer(deviceNodes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container edits for Compute Instance: %v", err)
@@ -0,0 +1,87 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMIGDeviceAnnotations(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description         string
+		featureFlags        map[FeatureFlag]bool
+		expectedAnnotations map[string]string
+	}{
+		{
+			description: "no annotations by default",
+		},
+		{
+			description:  "MIG annotation",
+			featureFlags: map[FeatureFlag]bool{FeatureEnableMIGAnnotations: true},
+			expectedAnnotations: map[string]string{
+				MIGAnnotation: "true",
+			},
+		},
+		{
+			description:  "UUID annotation",
+			featureFlags: map[FeatureFlag]bool{FeatureEnableUUIDAnnotations: true},
+			expectedAnnotations: map[string]string{
+				UUIDAnnotation: "MIG-4b0e6d8e-4a3b-5b5a-9c1c-0e1f2a3b4c5d",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// The parent device cannot be found so that only the annotations
+			// controlled by the feature flags are included.
+			mockNvml := &mock.Interface{
+				DeviceGetHandleByUUIDFunc: func(string) (nvml.Device, nvml.Return) {
+					return nil, nvml.ERROR_NOT_FOUND
+				},
+			}
+			l := &nvmllib{
+				logger:       logger,
+				featureFlags: tc.featureFlags,
+				platformlibs: platformlibs{
+					nvmllib:   mockNvml,
+					devicelib: device.New(mockNvml),
+				},
+			}
+			m := &migDeviceSpecGenerator{
+				fullGPUDeviceSpecGenerator: &fullGPUDeviceSpecGenerator{
+					nvmllib: l,
+					uuid:    "GPU-5b3b7a3e-4a3b-5b5a-9c1c-0e1f2a3b4c5d",
+					// The feature flags of the parent are not propagated to
+					// MIG devices.
+					featureFlags: make(map[FeatureFlag]bool),
+				},
+				migUUID: "MIG-4b0e6d8e-4a3b-5b5a-9c1c-0e1f2a3b4c5d",
+			}
+
+			require.EqualValues(t, tc.expectedAnnotations, m.getDeviceAnnotations())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+const (
+	// AllocatableAnnotation is the device annotation used to mark devices
+	// that must not be allocated to containers, such as MIG parent
+	// placeholders, by setting it to "false".
+	AllocatableAnnotation = "gpu.nvidia.com/allocatable"
+
+	// migParentPlaceholderEnvVar is set by a MIG parent placeholder device
+	// since a CDI device is required to have non-empty container edits. The
+	// value is the UUID of the GPU.
+	migParentPlaceholderEnvVar = "NVIDIA_MIG_PARENT_PLACEHOLDER"
+)
+
+// IsNonAllocatable returns true if the specified device is annotated as not
+// being allocatable. Such devices are excluded from the 'all' device.
+func IsNonAllocatable(d specs.Device) bool {
+	return d.Annotations[AllocatableAnnotation] == "false"
+}
+
+// A migParentPlaceholder generates the CDI device specs listing a GPU with MIG
+// mode enabled without making it usable in a container.
+type migParentPlaceholder struct {
+	*fullGPUDeviceSpecGenerator
+}
+
+var _ DeviceSpecGenerator = (*migParentPlaceholder)(nil)
+
+func (l *nvmllib) newMIGParentPlaceholderFromDevice(index int, d device.Device) (*migParentPlaceholder, error) {
+	parent, err := l.newFullGPUDeviceSpecGeneratorFromDevice(index, d, l.featureFlags)
+	if err != nil {
+		return nil, err
+	}
+	return &migParentPlaceholder{parent}, nil
+}
+
+// GetDeviceSpecs returns the CDI device specs for the placeholder. These use
+// the same names as the full GPU would, are annotated as non-allocatable,
+// and only set a marker environment variable.
+func (l *migParentPlaceholder) GetDeviceSpecs() ([]specs.Device, error) {
+	names, err := l.getNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device names: %w", err)
+	}
+
+	annotations := map[string]string{
+		AllocatableAnnotation: "false",
+	}
+	if l.featureFlags[FeatureEnableUUIDAnnotations] {
+		annotations[UUIDAnnotation] = l.uuid
+	}
+
+	var deviceSpecs []specs.Device
+	for _, name := range names {
+		deviceSpec := specs.Device{
+			Name: name,
+			ContainerEdits: specs.ContainerEdits{
+				Env: []string{migParentPlaceholderEnvVar + "=" + l.uuid},
+			},
+			Annotations: annotations,
+		}
+		deviceSpecs = append(deviceSpecs, deviceSpec)
+	}
+	return deviceSpecs, nil
+}
@@ -0,0 +1,74 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock/dgxa100"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestMIGParentPlaceholder(t *testing.T) {
+	mockNvml := dgxa100.New()
+	mockOverrides(mockNvml)
+	devicelib := device.New(mockNvml)
+
+	indexNamer, err := NewDeviceNamer(DeviceNameStrategyIndex)
+	require.NoError(t, err)
+	uuidNamer, err := NewDeviceNamer(DeviceNameStrategyUUID)
+	require.NoError(t, err)
+
+	logger, _ := testlog.NewNullLogger()
+	l := &nvmllib{
+		logger: logger,
+		platformlibs: platformlibs{
+			nvmllib:   mockNvml,
+			devicelib: devicelib,
+		},
+		deviceNamers: DeviceNamers{indexNamer, uuidNamer},
+		featureFlags: map[FeatureFlag]bool{FeatureEnableUUIDAnnotations: true},
+	}
+
+	d, err := devicelib.NewDevice(mockNvml.Devices[2])
+	require.NoError(t, err)
+	uuid, _ := d.GetUUID()
+
+	placeholder, err := l.newMIGParentPlaceholderFromDevice(2, d)
+	require.NoError(t, err)
+
+	deviceSpecs, err := placeholder.GetDeviceSpecs()
+	require.NoError(t, err)
+
+	expectedEdits := specs.ContainerEdits{
+		Env: []string{"NVIDIA_MIG_PARENT_PLACEHOLDER=" + uuid},
+	}
+	expectedAnnotations := map[string]string{
+		AllocatableAnnotation: "false",
+		UUIDAnnotation:        uuid,
+	}
+	require.Equal(t, []specs.Device{
+		{Name: "2", ContainerEdits: expectedEdits, Annotations: expectedAnnotations},
+		{Name: uuid, ContainerEdits: expectedEdits, Annotations: expectedAnnotations},
+	}, deviceSpecs)
+	for _, deviceSpec := range deviceSpecs {
+		require.True(t, IsNonAllocatable(deviceSpec))
+	}
+}
@@ -112,10 +112,16 @@ func (o *options) resolveMode() (rmode Mode) {
 		o.logger.Infof("Auto-detected mode as '%v'", rmode)
 	}()
 
-	platform := o.infolib.ResolvePlatform()
+	platform := o.platform
+	if platform == "" || platform == info.PlatformAuto {
+		platform = o.detectPlatform()
+	}
 	switch platform {
 	case info.PlatformNVML:
 		return ModeNvml
+	case PlatformVGPU:
+		o.vgpuGuest = true
+		return ModeNvml
 	case info.PlatformTegra:
 		return ModeCSV
 	case info.PlatformWSL:
@@ -0,0 +1,93 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveMode(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+	testCases := []struct {
+		description      string
+		mode             Mode
+		platform         info.Platform
+		detectedPlatform info.Platform
+		expectedMode     Mode
+		expectedVGPU     bool
+	}{
+		{
+			description:      "detected nvml platform",
+			mode:             ModeAuto,
+			platform:         info.PlatformAuto,
+			detectedPlatform: info.PlatformNVML,
+			expectedMode:     ModeNvml,
+		},
+		{
+			description:      "detected tegra platform",
+			mode:             ModeAuto,
+			platform:         info.PlatformAuto,
+			detectedPlatform: info.PlatformTegra,
+			expectedMode:     ModeCSV,
+		},
+		{
+			description:      "requested tegra platform skips detection",
+			mode:             ModeAuto,
+			platform:         info.PlatformTegra,
+			detectedPlatform: info.PlatformNVML,
+			expectedMode:     ModeCSV,
+		},
+		{
+			description:      "requested vgpu platform selects nvml mode",
+			mode:             ModeAuto,
+			platform:         PlatformVGPU,
+			detectedPlatform: info.PlatformNVML,
+			expectedMode:     ModeNvml,
+			expectedVGPU:     true,
+		},
+		{
+			description:      "explicit mode takes precedence over platform",
+			mode:             ModeNvml,
+			platform:         info.PlatformTegra,
+			detectedPlatform: info.PlatformTegra,
+			expectedMode:     ModeNvml,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			o := &options{
+				logger:   logger,
+				mode:     tc.mode,
+				platform: tc.platform,
+				platformlibs: platformlibs{
+					infolib: &infoInterfaceMock{
+						ResolvePlatformFunc: func() info.Platform {
+							return tc.detectedPlatform
+						},
+					},
+				},
+			}
+			require.Equal(t, tc.expectedMode, o.resolveMode())
+			require.Equal(t, tc.expectedVGPU, o.vgpuGuest)
+		})
+	}
+}
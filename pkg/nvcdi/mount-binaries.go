@@ -0,0 +1,142 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"debug/elf"
+	"strings"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+// systemLibraryPrefixes are the prefixes of the libraries that are provided
+// by the C and C++ runtimes of the container. These are never mounted from
+// the host since doing so would replace the runtime of the container image.
+var systemLibraryPrefixes = []string{
+	"ld-linux",
+	"ld64.so",
+	"libc.so",
+	"libdl.so",
+	"libgcc_s.so",
+	"libm.so",
+	"libpthread.so",
+	"libresolv.so",
+	"librt.so",
+	"libstdc++.so",
+	"libutil.so",
+}
+
+// mountBinaryLibraries is a discoverer for the libraries that the explicitly
+// requested host binaries depend on.
+type mountBinaryLibraries struct {
+	discover.None
+	*nvcdilib
+}
+
+// newMountBinariesDiscoverer creates a discoverer for the additional host
+// binaries that were requested. If no binaries were requested, nil is
+// returned. Binaries that cannot be located are skipped with a warning.
+func (l *nvcdilib) newMountBinariesDiscoverer() discover.Discover {
+	if len(l.mountBinaries) == 0 {
+		return nil
+	}
+	return discover.NewMounts(
+		l.logger,
+		lookup.NewExecutableLocator(l.logger, l.driver.Root),
+		l.driver.Root,
+		l.mountBinaries,
+	)
+}
+
+// newMountBinaryLibrariesDiscoverer creates a discoverer for the libraries
+// required by the additional host binaries that were requested. These are
+// included with the driver libraries so that the ldcache is updated for
+// them. If no binaries were requested, nil is returned.
+func (l *nvcdilib) newMountBinaryLibrariesDiscoverer() discover.Discover {
+	if len(l.mountBinaries) == 0 {
+		return nil
+	}
+	return discover.WithCache(&mountBinaryLibraries{nvcdilib: l})
+}
+
+// Mounts returns the mounts for the libraries that the requested binaries
+// depend on. Only direct dependencies are considered and the libraries of the
+// C and C++ runtimes are skipped.
+func (d *mountBinaryLibraries) Mounts() ([]discover.Mount, error) {
+	binaries := lookup.NewExecutableLocator(d.logger, d.driver.Root)
+
+	var required []string
+	seen := make(map[string]bool)
+	for _, name := range d.mountBinaries {
+		// Binaries that are not found are already reported by the binaries
+		// discoverer.
+		located, err := binaries.Locate(name)
+		if err != nil || len(located) == 0 {
+			continue
+		}
+		needed, err := getNeededLibraries(located[0])
+		if err != nil {
+			d.logger.Warningf("Ignoring library dependencies of %v: %v", located[0], err)
+			continue
+		}
+		for _, library := range needed {
+			if seen[library] || isSystemLibrary(library) {
+				continue
+			}
+			d.logger.Debugf("Including %v required by %v", library, name)
+			required = append(required, library)
+			seen[library] = true
+		}
+	}
+
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	libraries := discover.NewMounts(
+		d.logger,
+		d.driver.Libraries(),
+		d.driver.Root,
+		required,
+	)
+	return libraries.Mounts()
+}
+
+// isSystemLibrary checks whether the specified library is provided by the C
+// or C++ runtime of the container.
+func isSystemLibrary(library string) bool {
+	for _, prefix := range systemLibraryPrefixes {
+		if strings.HasPrefix(library, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// getNeededLibraries returns the libraries that the specified ELF file
+// depends on.
+// We use a function variable here to allow this to be overridden for testing.
+var getNeededLibraries = func(path string) ([]string, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return f.ImportedLibraries()
+}
@@ -0,0 +1,144 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestMountBinariesDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "usr/bin"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "usr/lib64"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "usr/bin/nvidia-persistenced"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "usr/bin/nv-fabricmanager"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "usr/lib64/libnvidia-cfg.so.1"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "usr/lib64/libnvfm.so.1"), nil, 0644))
+
+	defer setGetNeededLibraries(func(path string) ([]string, error) {
+		switch filepath.Base(path) {
+		case "nvidia-persistenced":
+			return []string{"libnvidia-cfg.so.1", "libc.so.6", "libpthread.so.0"}, nil
+		case "nv-fabricmanager":
+			return []string{"libnvfm.so.1", "libnvidia-cfg.so.1", "libstdc++.so.6", "libmissing.so.1"}, nil
+		}
+		return nil, nil
+	})()
+
+	options := []string{"ro", "nosuid", "nodev", "rbind", "rprivate"}
+
+	testCases := []struct {
+		description       string
+		mountBinaries     []string
+		expectedBinaries  []discover.Mount
+		expectedLibraries []discover.Mount
+	}{
+		{
+			description: "no binaries returns no discoverers",
+		},
+		{
+			description:   "binaries are mounted with their dependencies",
+			mountBinaries: []string{"nvidia-persistenced", "nv-fabricmanager"},
+			expectedBinaries: []discover.Mount{
+				{
+					HostPath: filepath.Join(driverRoot, "usr/bin/nvidia-persistenced"),
+					Path:     "/usr/bin/nvidia-persistenced",
+					Options:  options,
+				},
+				{
+					HostPath: filepath.Join(driverRoot, "usr/bin/nv-fabricmanager"),
+					Path:     "/usr/bin/nv-fabricmanager",
+					Options:  options,
+				},
+			},
+			expectedLibraries: []discover.Mount{
+				{
+					HostPath: filepath.Join(driverRoot, "usr/lib64/libnvidia-cfg.so.1"),
+					Path:     "/usr/lib64/libnvidia-cfg.so.1",
+					Options:  options,
+				},
+				{
+					HostPath: filepath.Join(driverRoot, "usr/lib64/libnvfm.so.1"),
+					Path:     "/usr/lib64/libnvfm.so.1",
+					Options:  options,
+				},
+			},
+		},
+		{
+			description:   "missing binary is skipped",
+			mountBinaries: []string{"missing", "nvidia-persistenced"},
+			expectedBinaries: []discover.Mount{
+				{
+					HostPath: filepath.Join(driverRoot, "usr/bin/nvidia-persistenced"),
+					Path:     "/usr/bin/nvidia-persistenced",
+					Options:  options,
+				},
+			},
+			expectedLibraries: []discover.Mount{
+				{
+					HostPath: filepath.Join(driverRoot, "usr/lib64/libnvidia-cfg.so.1"),
+					Path:     "/usr/lib64/libnvidia-cfg.so.1",
+					Options:  options,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvcdilib{
+				logger:        logger,
+				driver:        root.New(root.WithDriverRoot(driverRoot)),
+				mountBinaries: tc.mountBinaries,
+			}
+
+			binaries := l.newMountBinariesDiscoverer()
+			libraries := l.newMountBinaryLibrariesDiscoverer()
+			if len(tc.mountBinaries) == 0 {
+				require.Nil(t, binaries)
+				require.Nil(t, libraries)
+				return
+			}
+
+			mounts, err := binaries.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedBinaries, mounts)
+
+			mounts, err = libraries.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedLibraries, mounts)
+		})
+	}
+}
+
+func setGetNeededLibraries(override func(string) ([]string, error)) func() {
+	original := getNeededLibraries
+	getNeededLibraries = override
+	return func() {
+		getNeededLibraries = original
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"tags.cncf.io/container-device-interface/pkg/parser"
 )
 
 // UUIDer is an interface for getting UUIDs.
@@ -45,6 +46,10 @@ const (
 	DeviceNameStrategyTypeIndex = "type-index"
 	// DeviceNameStrategyUUID uses the device UUID as the name
 	DeviceNameStrategyUUID = "uuid"
+	// DeviceNameStrategyMigProfile generates device names such as gpu0 or
+	// gpu0-mig-1g.5gb-1 that include the MIG profile. This matches the naming
+	// used by the NVIDIA Kubernetes device plugin.
+	DeviceNameStrategyMigProfile = "mig-profile"
 )
 
 type deviceNameIndex struct {
@@ -52,6 +57,13 @@ type deviceNameIndex struct {
 	migPrefix string
 }
 type deviceNameUUID struct{}
+type deviceNameMigProfile struct{}
+
+// A migProfiler returns the name of the MIG profile associated with a MIG
+// device.
+type migProfiler interface {
+	GetProfile() (string, error)
+}
 
 // NewDeviceNamer creates a Device Namer based on the supplied strategy.
 // This namer can be used to construct the names for MIG and GPU devices when generating the CDI spec.
@@ -63,6 +75,8 @@ func NewDeviceNamer(strategy string) (DeviceNamer, error) {
 		return deviceNameIndex{gpuPrefix: "gpu", migPrefix: "mig"}, nil
 	case DeviceNameStrategyUUID:
 		return deviceNameUUID{}, nil
+	case DeviceNameStrategyMigProfile:
+		return deviceNameMigProfile{}, nil
 	}
 
 	return nil, fmt.Errorf("invalid device name strategy: %v", strategy)
@@ -96,6 +110,28 @@ func (s deviceNameUUID) GetMigDeviceName(i int, _ UUIDer, j int, mig UUIDer) (st
 	return uuid, nil
 }
 
+// GetDeviceName returns the name for the specified device based on the naming strategy
+func (s deviceNameMigProfile) GetDeviceName(i int, _ UUIDer) (string, error) {
+	return fmt.Sprintf("gpu%d", i), nil
+}
+
+// GetMigDeviceName returns the name for the specified device based on the naming strategy
+func (s deviceNameMigProfile) GetMigDeviceName(i int, _ UUIDer, j int, mig UUIDer) (string, error) {
+	profiler, ok := mig.(migProfiler)
+	if !ok {
+		return "", fmt.Errorf("MIG profile is not supported for device %d:%d", i, j)
+	}
+	profile, err := profiler.GetProfile()
+	if err != nil {
+		return "", fmt.Errorf("failed to get MIG profile: %w", err)
+	}
+	name := fmt.Sprintf("gpu%d-mig-%s-%d", i, profile, j)
+	if err := parser.ValidateDeviceName(name); err != nil {
+		return "", fmt.Errorf("invalid device name for MIG profile %q: %w", profile, err)
+	}
+	return name, nil
+}
+
 //go:generate moq -rm -fmt=goimports -stub -out namer_nvml_mock.go . nvmlUUIDer
 type nvmlUUIDer interface {
 	GetUUID() (string, nvml.Return)
@@ -17,6 +17,7 @@
 package nvcdi
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
@@ -65,3 +66,70 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+type migProfilerMock struct {
+	profile string
+	err     error
+}
+
+func (m migProfilerMock) GetUUID() (string, error) {
+	return "MIG-SOME_UUID", nil
+}
+
+func (m migProfilerMock) GetProfile() (string, error) {
+	return m.profile, m.err
+}
+
+func TestDeviceNameMigProfile(t *testing.T) {
+	namer, err := NewDeviceNamer(DeviceNameStrategyMigProfile)
+	require.NoError(t, err)
+
+	name, err := namer.GetDeviceName(1, nil)
+	require.NoError(t, err)
+	require.Equal(t, "gpu1", name)
+
+	testCases := []struct {
+		description   string
+		mig           UUIDer
+		expectedName  string
+		expectedError bool
+	}{
+		{
+			description:  "profile is included in name",
+			mig:          migProfilerMock{profile: "1g.5gb"},
+			expectedName: "gpu1-mig-1g.5gb-2",
+		},
+		{
+			description:  "compute instance profile is included in name",
+			mig:          migProfilerMock{profile: "1c.2g.10gb"},
+			expectedName: "gpu1-mig-1c.2g.10gb-2",
+		},
+		{
+			description:   "profile with attributes is not a valid name",
+			mig:           migProfilerMock{profile: "1g.10gb+me"},
+			expectedError: true,
+		},
+		{
+			description:   "profile error is returned",
+			mig:           migProfilerMock{err: errors.New("no profile")},
+			expectedError: true,
+		},
+		{
+			description:   "MIG profile not supported",
+			mig:           uuidUnsupported{},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			name, err := namer.GetMigDeviceName(1, nil, 2, tc.mig)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedName, name)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"strconv"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+const (
+	// NUMANodeAnnotation is the device annotation used to record the NUMA node
+	// of a GPU.
+	NUMANodeAnnotation = "nvidia.com/numa-node"
+)
+
+// getNUMANodeAnnotations returns the annotations recording the NUMA node of the
+// specified device. No annotations are returned if the NUMA node cannot be
+// determined, for example on systems without NUMA support or for drivers that
+// do not support the query.
+func (l *nvmllib) getNUMANodeAnnotations(d nvml.Device) map[string]string {
+	if l.featureFlags[FeatureDisableNUMAAnnotations] {
+		return nil
+	}
+	if err := l.nvmllib.Extensions().LookupSymbol("nvmlDeviceGetNumaNodeId"); err != nil {
+		l.logger.Debugf("Not querying NUMA node: %v", err)
+		return nil
+	}
+	node, ret := d.GetNumaNodeId()
+	if ret != nvml.SUCCESS {
+		if ret != nvml.ERROR_NOT_SUPPORTED {
+			l.logger.Warningf("Ignoring error getting NUMA node of device: %v", ret)
+		}
+		return nil
+	}
+	if node < 0 {
+		return nil
+	}
+	return map[string]string{
+		NUMANodeAnnotation: strconv.Itoa(node),
+	}
+}
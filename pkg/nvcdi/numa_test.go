@@ -0,0 +1,102 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNUMANodeAnnotations(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description         string
+		featureFlags        map[FeatureFlag]bool
+		lookupSymbolError   error
+		numaNode            int
+		numaNodeReturn      nvml.Return
+		expectedAnnotations map[string]string
+	}{
+		{
+			description:         "numa node is annotated",
+			numaNode:            1,
+			numaNodeReturn:      nvml.SUCCESS,
+			expectedAnnotations: map[string]string{"nvidia.com/numa-node": "1"},
+		},
+		{
+			description:         "numa node 0 is annotated",
+			numaNode:            0,
+			numaNodeReturn:      nvml.SUCCESS,
+			expectedAnnotations: map[string]string{"nvidia.com/numa-node": "0"},
+		},
+		{
+			description:    "negative numa node is ignored",
+			numaNode:       -1,
+			numaNodeReturn: nvml.SUCCESS,
+		},
+		{
+			description:    "not supported is ignored",
+			numaNodeReturn: nvml.ERROR_NOT_SUPPORTED,
+		},
+		{
+			description:    "error is ignored",
+			numaNodeReturn: nvml.ERROR_UNKNOWN,
+		},
+		{
+			description:       "missing symbol is ignored",
+			lookupSymbolError: nvml.ERROR_FUNCTION_NOT_FOUND,
+		},
+		{
+			description:    "feature flag disables annotations",
+			featureFlags:   map[FeatureFlag]bool{FeatureDisableNUMAAnnotations: true},
+			numaNode:       1,
+			numaNodeReturn: nvml.SUCCESS,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvmllib{
+				logger:       logger,
+				featureFlags: tc.featureFlags,
+				platformlibs: platformlibs{
+					nvmllib: &mock.Interface{
+						ExtensionsFunc: func() nvml.ExtendedInterface {
+							return &mock.ExtendedInterface{
+								LookupSymbolFunc: func(string) error {
+									return tc.lookupSymbolError
+								},
+							}
+						},
+					},
+				},
+			}
+			d := &mock.Device{
+				GetNumaNodeIdFunc: func() (int, nvml.Return) {
+					return tc.numaNode, tc.numaNodeReturn
+				},
+			}
+
+			require.EqualValues(t, tc.expectedAnnotations, l.getNUMANodeAnnotations(d))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"path/filepath"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/lookup"
+)
+
+// nvidiaSMIContainerPath is the path at which an explicitly specified
+// nvidia-smi executable is mounted in the container.
+const nvidiaSMIContainerPath = "/usr/bin/nvidia-smi"
+
+// nvidiaSMI is a discoverer for an nvidia-smi executable at an explicitly
+// specified path.
+type nvidiaSMI struct {
+	discover.None
+	*nvcdilib
+}
+
+// newNvidiaSMIDiscoverer creates a discoverer for the nvidia-smi executable at
+// the configured path. If no path is configured, nil is returned and
+// nvidia-smi is located along with the other driver binaries.
+func (l *nvcdilib) newNvidiaSMIDiscoverer() discover.Discover {
+	if l.nvidiaSMIPath == "" {
+		return nil
+	}
+	return &nvidiaSMI{nvcdilib: l}
+}
+
+// Mounts returns a read-only mount for the nvidia-smi executable. If the
+// executable cannot be found, a warning is logged and no mounts are returned.
+func (d *nvidiaSMI) Mounts() ([]discover.Mount, error) {
+	hostPath := filepath.Join(d.driver.Root, d.nvidiaSMIPath)
+	located, err := lookup.NewExecutableLocator(d.logger, d.driver.Root).Locate(hostPath)
+	if err != nil || len(located) == 0 {
+		d.logger.Warningf("Ignoring nvidia-smi at %v: %v", d.nvidiaSMIPath, err)
+		return nil, nil
+	}
+
+	mount := discover.Mount{
+		HostPath: located[0],
+		Path:     nvidiaSMIContainerPath,
+		Options: []string{
+			"ro",
+			"nosuid",
+			"nodev",
+			"rbind",
+			"rprivate",
+		},
+	}
+	return []discover.Mount{mount}, nil
+}
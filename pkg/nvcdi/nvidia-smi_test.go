@@ -0,0 +1,87 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/lookup/root"
+)
+
+func TestNvidiaSMIDiscoverer(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	driverRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(driverRoot, "opt/nvidia/bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "opt/nvidia/bin/nvidia-smi"), nil, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(driverRoot, "opt/nvidia/bin/not-executable"), nil, 0644))
+
+	testCases := []struct {
+		description    string
+		nvidiaSMIPath  string
+		expectedMounts []discover.Mount
+	}{
+		{
+			description: "no path returns no discoverer",
+		},
+		{
+			description:   "executable is mounted in the container path",
+			nvidiaSMIPath: "/opt/nvidia/bin/nvidia-smi",
+			expectedMounts: []discover.Mount{
+				{
+					HostPath: filepath.Join(driverRoot, "opt/nvidia/bin/nvidia-smi"),
+					Path:     "/usr/bin/nvidia-smi",
+					Options:  []string{"ro", "nosuid", "nodev", "rbind", "rprivate"},
+				},
+			},
+		},
+		{
+			description:   "missing executable is ignored",
+			nvidiaSMIPath: "/opt/nvidia/bin/missing",
+		},
+		{
+			description:   "non-executable file is ignored",
+			nvidiaSMIPath: "/opt/nvidia/bin/not-executable",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvcdilib{
+				logger:        logger,
+				driver:        root.New(root.WithDriverRoot(driverRoot)),
+				nvidiaSMIPath: tc.nvidiaSMIPath,
+			}
+
+			d := l.newNvidiaSMIDiscoverer()
+			if tc.nvidiaSMIPath == "" {
+				require.Nil(t, d)
+				return
+			}
+
+			mounts, err := d.Mounts()
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedMounts, mounts)
+		})
+	}
+}
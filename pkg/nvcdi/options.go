@@ -17,10 +17,13 @@
 package nvcdi
 
 import (
+	"context"
+
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
 	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/config/image"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
@@ -31,9 +34,14 @@ import (
 )
 
 type options struct {
+	ctx    context.Context
 	logger logger.Interface
 	platformlibs
-	mode               Mode
+	mode     Mode
+	platform info.Platform
+	// vgpuGuest indicates whether the spec is generated for a vGPU guest.
+	// This is set when the mode is resolved.
+	vgpuGuest          bool
 	deviceNamers       DeviceNamers
 	driverRoot         string
 	devRoot            string
@@ -51,9 +59,40 @@ type options struct {
 
 	featureFlags map[FeatureFlag]bool
 
+	allowEmpty bool
+	bestEffort bool
+
+	driverCapabilities image.DriverCapabilities
+	probeCapabilities  bool
+
+	dumpDiscovered bool
+
+	nvidiaSMIPath string
+
+	vulkanManifestDir string
+
+	mountBinaries []string
+
+	applicationClocks *ApplicationClocks
+
+	deviceNodeWait *DeviceNodeWait
+
+	durationRecorder DurationRecorder
+
+	deviceInfoRecorder DeviceInfoRecorder
+
+	verboseDeviceSelection bool
+
+	pciBusIDs         []string
+	excludedPCIBusIDs []string
+	maxDevices        int
+
 	disabledHooks []discover.HookName
 	enabledHooks  []discover.HookName
 
+	deviceFolderMode  string
+	deviceFolderGroup string
+
 	editsFactory edits.Factory
 }
 
@@ -70,6 +109,7 @@ type platformlibs struct {
 func populateOptions(opts ...Option) *options {
 	o := &options{
 		mode:              ModeAuto,
+		platform:          info.PlatformAuto,
 		driverRoot:        "/",
 		nvidiaCDIHookPath: "/usr/bin/nvidia-cdi-hook",
 	}
@@ -77,7 +117,7 @@ func populateOptions(opts ...Option) *options {
 		opt(o)
 	}
 	if o.logger == nil {
-		o.logger = logger.New()
+		o.logger = logger.FromContext(o.ctx)
 	}
 	if len(o.deviceNamers) == 0 {
 		indexNamer, _ := NewDeviceNamer(DeviceNameStrategyIndex)
@@ -95,6 +135,8 @@ func populateOptions(opts ...Option) *options {
 	if o.devicelib == nil {
 		o.devicelib = device.New(o.nvmllib)
 	}
+	o.devicelib = newPCIBusIDFilter(o.deviceSelectionLogger(), o.devicelib, o.pciBusIDs, o.excludedPCIBusIDs)
+	o.devicelib = newMaxDevicesFilter(o.deviceSelectionLogger(), o.devicelib, o.maxDevices)
 	if o.infolib == nil {
 		o.infolib = info.New(
 			info.WithRoot(o.driverRoot),
@@ -118,10 +160,17 @@ func populateOptions(opts ...Option) *options {
 		o.disabledHooks = append(o.disabledHooks, DisableDeviceNodeModificationHook)
 	}
 
+	if o.deviceFolderMode != "" || o.deviceFolderGroup != "" {
+		// An explicit device folder mode or group requires the chmod hook
+		// which is disabled by default.
+		o.enabledHooks = append(o.enabledHooks, discover.ChmodHook)
+	}
+
 	if o.editsFactory == nil {
 		o.editsFactory = edits.NewFactory(
 			edits.WithLogger(o.logger),
 			edits.WithNoAdditionalGIDsForDeviceNodes(o.featureFlags[FeatureNoAdditionalGIDsForDeviceNodes]),
+			edits.WithCgroupDeviceRules(o.featureFlags[FeatureEmitCgroupDeviceRules]),
 		)
 	}
 
@@ -206,6 +255,22 @@ func WithEditsFactory(editsFactory edits.Factory) Option {
 	}
 }
 
+// WithContext sets the context for the library. If no logger is explicitly
+// specified using WithLogger, the logger carried by the context is used. This
+// allows callers to correlate the logs of the library with their own requests.
+func WithContext(ctx context.Context) Option {
+	return func(l *options) {
+		l.ctx = ctx
+	}
+}
+
+// ContextWithLogger returns a copy of the parent context that carries the
+// specified logger. The returned context can be passed to the library using
+// WithContext.
+func ContextWithLogger(ctx context.Context, l logger.Interface) context.Context {
+	return logger.NewContext(ctx, l)
+}
+
 // WithLogger sets the logger for the library
 func WithLogger(logger logger.Interface) Option {
 	return func(l *options) {
@@ -248,6 +313,19 @@ func WithMode[m modeConstraint](mode m) Option {
 	}
 }
 
+// WithPlatform sets the platform that is used to resolve the mode if
+// ModeAuto is requested. This allows platform detection to be skipped, for
+// example to generate a spec for a Tegra-based system where NVML is also
+// available.
+func WithPlatform[p string | info.Platform](platform p) Option {
+	return func(o *options) {
+		if platform == "" {
+			return
+		}
+		o.platform = info.Platform(platform)
+	}
+}
+
 // WithVendor sets the vendor for the library
 func WithVendor(vendor string) Option {
 	return func(o *options) {
@@ -343,6 +421,123 @@ func WithFeatureFlags[T string | FeatureFlag](featureFlags ...T) Option {
 	}
 }
 
+// WithDurationRecorder sets a recorder that is notified of the time taken by
+// phases of CDI spec generation, such as the discovery of MIG devices.
+func WithDurationRecorder(recorder DurationRecorder) Option {
+	return func(o *options) {
+		o.durationRecorder = recorder
+	}
+}
+
+// WithAllowEmpty sets whether devices that would not result in any CDI devices
+// are skipped with a warning instead of triggering an error. An example of
+// such a device is a GPU with MIG mode enabled but no MIG devices configured.
+func WithAllowEmpty(allowEmpty bool) Option {
+	return func(o *options) {
+		o.allowEmpty = allowEmpty
+	}
+}
+
+// WithBestEffort sets whether the generation of CDI device specs continues if
+// it fails for some devices. If enabled, the device specs for the remaining
+// devices are returned along with a *PartialDiscoveryError describing the
+// failures. This currently only applies to the 'all' device ID in NVML mode.
+func WithBestEffort(bestEffort bool) Option {
+	return func(o *options) {
+		o.bestEffort = bestEffort
+	}
+}
+
+// WithDriverCapabilities sets the driver capabilities that the driver files
+// included in the generated spec are restricted to. The capabilities are
+// specified as a comma-separated list using the same values as the
+// NVIDIA_DRIVER_CAPABILITIES environment variable. If this is empty or 'all',
+// the included files are not restricted.
+func WithDriverCapabilities(capabilities string) Option {
+	return func(o *options) {
+		if capabilities == "" {
+			o.driverCapabilities = nil
+			return
+		}
+		o.driverCapabilities = image.NewDriverCapabilities(capabilities)
+	}
+}
+
+// WithDumpDiscovered sets whether the entities returned by the discoverers used
+// to construct the CDI edits are logged at debug level.
+func WithDumpDiscovered(dumpDiscovered bool) Option {
+	return func(o *options) {
+		o.dumpDiscovered = dumpDiscovered
+	}
+}
+
+// WithVulkanManifestDir sets the directory on the host in which copies of
+// Vulkan ICD and layer manifests are created if their library_path needs to be
+// rewritten to the path in the container. If this is not set, the manifests
+// are always mounted as is.
+func WithVulkanManifestDir(dir string) Option {
+	return func(o *options) {
+		o.vulkanManifestDir = dir
+	}
+}
+
+// WithNvidiaSMIPath sets the path to the nvidia-smi executable to inject into
+// containers. The path is resolved relative to the driver root and the
+// executable is mounted at /usr/bin/nvidia-smi in the container. If this is
+// not set, nvidia-smi is located in the PATH of the driver root.
+func WithNvidiaSMIPath(path string) Option {
+	return func(o *options) {
+		o.nvidiaSMIPath = path
+	}
+}
+
+// WithMountBinaries sets the names of additional host binaries, such as
+// nvidia-persistenced or nv-fabricmanager, that are mounted into containers
+// along with the libraries that these depend on. Binaries that cannot be found
+// in the PATH of the driver root are skipped with a warning.
+func WithMountBinaries(names ...string) Option {
+	return func(o *options) {
+		o.mountBinaries = names
+	}
+}
+
+// WithPCIBusIDs restricts the devices that are visited when generating specs
+// for all devices to those with the specified PCI bus IDs. An error is raised
+// if a device with one of the specified bus IDs is not found.
+func WithPCIBusIDs(ids ...string) Option {
+	return func(o *options) {
+		o.pciBusIDs = ids
+	}
+}
+
+// WithExcludedPCIBusIDs skips devices with the specified PCI bus IDs when
+// generating specs for all devices.
+func WithExcludedPCIBusIDs(ids ...string) Option {
+	return func(o *options) {
+		o.excludedPCIBusIDs = ids
+	}
+}
+
+// WithMaxDevices limits the number of full GPUs that are visited when
+// generating specs for all devices to the first maxDevices GPUs (after any PCI
+// bus ID filtering). The limit counts physical GPUs, meaning that all MIG
+// devices of an included GPU are also included. A value of 0 means that no
+// limit is applied.
+func WithMaxDevices(maxDevices int) Option {
+	return func(o *options) {
+		o.maxDevices = maxDevices
+	}
+}
+
+// WithVerboseDeviceSelection sets whether the reasons for including or
+// skipping each enumerated device are logged at info level. By default these
+// are logged at debug level.
+func WithVerboseDeviceSelection(verbose bool) Option {
+	return func(o *options) {
+		o.verboseDeviceSelection = verbose
+	}
+}
+
 // WithDisabledHook allows specific hooks to be disabled.
 // This option can be specified multiple times for each hook.
 //
@@ -0,0 +1,59 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+)
+
+const (
+	// PCIAddressAnnotation is the device annotation used to record the PCI
+	// address of a GPU.
+	PCIAddressAnnotation = "nvidia.com/pci-address"
+	// BoardSerialAnnotation is the device annotation used to record the board
+	// serial number of a GPU.
+	BoardSerialAnnotation = "nvidia.com/board-serial"
+)
+
+// getPCIAddressAndSerialAnnotations returns the annotations recording the PCI
+// address and board serial number of the specified device. An annotation is
+// omitted if the corresponding value is not reported by NVML.
+func (l *nvmllib) getPCIAddressAndSerialAnnotations(d device.Device) map[string]string {
+	if l.featureFlags[FeatureDisablePCIAddressAndSerialAnnotations] {
+		return nil
+	}
+	annotations := make(map[string]string)
+	if busID, err := d.GetPCIBusID(); err != nil {
+		l.logger.Warningf("Ignoring error getting PCI address of device: %v", err)
+	} else if busID != "" {
+		annotations[PCIAddressAnnotation] = normalizePCIBusID(busID)
+	}
+
+	serial, ret := d.GetSerial()
+	switch {
+	case ret == nvml.SUCCESS && serial != "":
+		annotations[BoardSerialAnnotation] = serial
+	case ret != nvml.SUCCESS && ret != nvml.ERROR_NOT_SUPPORTED:
+		l.logger.Warningf("Ignoring error getting serial number of device: %v", ret)
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
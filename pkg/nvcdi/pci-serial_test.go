@@ -0,0 +1,127 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPCIAddressAndSerialAnnotations(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	testCases := []struct {
+		description         string
+		featureFlags        map[FeatureFlag]bool
+		pciBusID            string
+		pciInfoReturn       nvml.Return
+		serial              string
+		serialReturn        nvml.Return
+		expectedAnnotations map[string]string
+	}{
+		{
+			description:   "pci address and serial are annotated",
+			pciBusID:      "00000000:07:00.0",
+			pciInfoReturn: nvml.SUCCESS,
+			serial:        "1564720004631",
+			serialReturn:  nvml.SUCCESS,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/pci-address":  "0000:07:00.0",
+				"nvidia.com/board-serial": "1564720004631",
+			},
+		},
+		{
+			description:   "serial not supported is omitted",
+			pciBusID:      "00000000:07:00.0",
+			pciInfoReturn: nvml.SUCCESS,
+			serialReturn:  nvml.ERROR_NOT_SUPPORTED,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/pci-address": "0000:07:00.0",
+			},
+		},
+		{
+			description:   "empty serial is omitted",
+			pciBusID:      "00000000:07:00.0",
+			pciInfoReturn: nvml.SUCCESS,
+			serialReturn:  nvml.SUCCESS,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/pci-address": "0000:07:00.0",
+			},
+		},
+		{
+			description:   "serial error is ignored",
+			pciBusID:      "00000000:07:00.0",
+			pciInfoReturn: nvml.SUCCESS,
+			serialReturn:  nvml.ERROR_UNKNOWN,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/pci-address": "0000:07:00.0",
+			},
+		},
+		{
+			description:   "pci info error is ignored",
+			pciInfoReturn: nvml.ERROR_NOT_SUPPORTED,
+			serial:        "1564720004631",
+			serialReturn:  nvml.SUCCESS,
+			expectedAnnotations: map[string]string{
+				"nvidia.com/board-serial": "1564720004631",
+			},
+		},
+		{
+			description:   "no annotations if nothing is reported",
+			pciInfoReturn: nvml.ERROR_NOT_SUPPORTED,
+			serialReturn:  nvml.ERROR_NOT_SUPPORTED,
+		},
+		{
+			description:   "feature flag disables annotations",
+			featureFlags:  map[FeatureFlag]bool{FeatureDisablePCIAddressAndSerialAnnotations: true},
+			pciBusID:      "00000000:07:00.0",
+			pciInfoReturn: nvml.SUCCESS,
+			serial:        "1564720004631",
+			serialReturn:  nvml.SUCCESS,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvmllib{
+				logger:       logger,
+				featureFlags: tc.featureFlags,
+			}
+			nvmlDevice := &mock.Device{
+				GetPciInfoFunc: func() (nvml.PciInfo, nvml.Return) {
+					var info nvml.PciInfo
+					for i := 0; i < len(tc.pciBusID); i++ {
+						info.BusId[i] = int8(tc.pciBusID[i])
+					}
+					return info, tc.pciInfoReturn
+				},
+				GetSerialFunc: func() (string, nvml.Return) {
+					return tc.serial, tc.serialReturn
+				},
+			}
+			d, err := device.New(&mock.Interface{}).NewDevice(nvmlDevice)
+			require.NoError(t, err)
+
+			require.EqualValues(t, tc.expectedAnnotations, l.getPCIAddressAndSerialAnnotations(d))
+		})
+	}
+}
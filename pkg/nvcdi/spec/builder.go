@@ -38,6 +38,13 @@ type builder struct {
 	mergedDeviceOptions []transform.MergedDeviceOption
 	noSimplify          bool
 	permissions         os.FileMode
+	yamlIndent          int
+	jsonIndent          bool
+	unsafeKind          bool
+	tempDir             string
+	headerComment       string
+	noYAMLSeparator     bool
+	yamlMarshaler       Marshaler
 
 	transformOnSave transform.Transformer
 }
@@ -97,7 +104,7 @@ func (o *builder) Build() (*spec, error) {
 	}
 
 	if !o.noSimplify {
-		err := transform.NewSimplifier().Transform(raw)
+		err := Canonicalize(raw)
 		if err != nil {
 			return nil, fmt.Errorf("failed to simplify spec: %v", err)
 		}
@@ -117,6 +124,13 @@ func (o *builder) Build() (*spec, error) {
 		Spec:            raw,
 		format:          o.format,
 		permissions:     o.permissions,
+		yamlIndent:      o.yamlIndent,
+		jsonIndent:      o.jsonIndent,
+		unsafeKind:      o.unsafeKind,
+		tempDir:         o.tempDir,
+		headerComment:   o.headerComment,
+		noYAMLSeparator: o.noYAMLSeparator,
+		yamlMarshaler:   o.yamlMarshaler,
 		transformOnSave: o.transformOnSave,
 	}
 	return &s, nil
@@ -188,9 +202,69 @@ func WithPermissions(permissions os.FileMode) Option {
 	}
 }
 
+// WithYAMLIndent sets the number of spaces used for indentation when the spec
+// is saved as YAML. If this is 0, the default indentation is used.
+func WithYAMLIndent(indent int) Option {
+	return func(o *builder) {
+		o.yamlIndent = indent
+	}
+}
+
+// WithJSONIndent sets whether the spec is indented when saved as JSON. If this
+// is false, compact JSON is output.
+func WithJSONIndent(indent bool) Option {
+	return func(o *builder) {
+		o.jsonIndent = indent
+	}
+}
+
+// WithUnsafeKind sets whether the validation of the vendor and class of the
+// spec kind is skipped when the spec is saved. All other validation is still
+// performed. This allows specs with experimental kinds to be generated.
+func WithUnsafeKind(unsafeKind bool) Option {
+	return func(o *builder) {
+		o.unsafeKind = unsafeKind
+	}
+}
+
+// WithTempDir sets the directory in which the temporary files used when saving
+// a spec are created. If this is empty, the directory of the spec is used.
+func WithTempDir(dir string) Option {
+	return func(o *builder) {
+		o.tempDir = dir
+	}
+}
+
+// WithHeaderComment sets a comment that is included in the saved spec. For
+// YAML specs, this is written as leading comment lines. Since JSON does not
+// support comments, this is added as a spec annotation for JSON specs.
+func WithHeaderComment(comment string) Option {
+	return func(o *builder) {
+		o.headerComment = comment
+	}
+}
+
+// WithNoYAMLSeparator sets whether the leading document separator (---) is
+// omitted when the spec is saved as YAML. The separator is included by default.
+func WithNoYAMLSeparator(noYAMLSeparator bool) Option {
+	return func(o *builder) {
+		o.noYAMLSeparator = noYAMLSeparator
+	}
+}
+
 // WithMergedDeviceOptions sets the options for generating a merged device.
 func WithMergedDeviceOptions(opts ...transform.MergedDeviceOption) Option {
 	return func(o *builder) {
 		o.mergedDeviceOptions = opts
 	}
 }
+
+// WithYAMLMarshaler sets the marshaler used to encode the spec when it is
+// saved as YAML. This can be used to preserve the comments of a spec that is
+// loaded, modified, and saved again. If this is nil, the default encoding is
+// used.
+func WithYAMLMarshaler(marshaler Marshaler) Option {
+	return func(o *builder) {
+		o.yamlMarshaler = marshaler
+	}
+}
@@ -0,0 +1,62 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
+)
+
+// Canonicalize transforms the specified spec in-place into the canonical form
+// of generated specs. Duplicate entities are removed, edits that are common to
+// all devices are removed from the device edits, and devices and entities are
+// sorted.
+func Canonicalize(raw *specs.Spec) error {
+	return transform.NewSimplifier().Transform(raw)
+}
+
+// Fingerprint returns a hash of the canonical form of the specified spec.
+// Specs that only differ in the order or duplication of their entities have
+// the same fingerprint. The specified spec is not modified.
+func Fingerprint(raw *specs.Spec) (string, error) {
+	contents, err := json.Marshal(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	var canonical specs.Spec
+	if err := json.Unmarshal(contents, &canonical); err != nil {
+		return "", fmt.Errorf("failed to copy spec: %w", err)
+	}
+	if err := Canonicalize(&canonical); err != nil {
+		return "", fmt.Errorf("failed to canonicalize spec: %w", err)
+	}
+
+	// Since the keys of maps are sorted when marshaling, the JSON encoding of
+	// a canonical spec is stable.
+	contents, err = json.Marshal(&canonical)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal canonical spec: %w", err)
+	}
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:]), nil
+}
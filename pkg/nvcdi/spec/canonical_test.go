@@ -0,0 +1,83 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestFingerprint(t *testing.T) {
+	newSpec := func(mounts ...string) *specs.Spec {
+		s := &specs.Spec{
+			Version: "0.5.0",
+			Kind:    "example.com/device",
+			Devices: []specs.Device{
+				{
+					Name: "0",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: []*specs.DeviceNode{{Path: "/dev/gpu0"}},
+					},
+				},
+			},
+		}
+		for _, m := range mounts {
+			s.ContainerEdits.Mounts = append(s.ContainerEdits.Mounts, &specs.Mount{HostPath: m, ContainerPath: m})
+		}
+		return s
+	}
+
+	reference, err := Fingerprint(newSpec("/usr/lib/a.so", "/usr/lib/b.so"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description string
+		spec        *specs.Spec
+		expectEqual bool
+	}{
+		{
+			description: "identical spec",
+			spec:        newSpec("/usr/lib/a.so", "/usr/lib/b.so"),
+			expectEqual: true,
+		},
+		{
+			description: "reordered and duplicated mounts",
+			spec:        newSpec("/usr/lib/b.so", "/usr/lib/a.so", "/usr/lib/b.so"),
+			expectEqual: true,
+		},
+		{
+			description: "different mounts",
+			spec:        newSpec("/usr/lib/a.so"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			original := len(tc.spec.ContainerEdits.Mounts)
+			fingerprint, err := Fingerprint(tc.spec)
+			require.NoError(t, err)
+			require.Len(t, tc.spec.ContainerEdits.Mounts, original)
+			if tc.expectEqual {
+				require.Equal(t, reference, fingerprint)
+			} else {
+				require.NotEqual(t, reference, fingerprint)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// A Marshaler encodes a CDI spec for output as YAML. This allows the encoding
+// of specs that are loaded, modified, and saved again to be customized.
+type Marshaler interface {
+	Marshal(*specs.Spec) ([]byte, error)
+}
+
+// defaultCommentPreservingIndent is the indentation used by the
+// comment-preserving marshaler if no indentation is specified and the
+// indentation of the original spec cannot be determined. This matches the
+// indentation used when writing specs using the cdi package.
+const defaultCommentPreservingIndent = 4
+
+// commentPreserving is a marshaler that carries the comments of an original
+// YAML document over to the encoded spec.
+type commentPreserving struct {
+	original *yaml.Node
+	indent   int
+}
+
+// NewCommentPreservingMarshaler returns a marshaler that preserves the
+// comments of the specified original YAML spec. Comments are associated with
+// the nodes of the encoded spec at the same location. Mapping entries are
+// matched by key and the elements of sequences are matched by name if these
+// are named (such as devices) and by index otherwise. Comments of nodes that
+// no longer exist are dropped. If indent is 0, the indentation of the original
+// spec is used.
+func NewCommentPreservingMarshaler(original []byte, indent int) (Marshaler, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(original, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse original spec: %w", err)
+	}
+	if indent == 0 {
+		indent = detectIndent(original)
+	}
+	m := &commentPreserving{
+		original: &node,
+		indent:   indent,
+	}
+	return m, nil
+}
+
+// Marshal encodes the specified spec as YAML including the comments of the
+// original spec.
+func (m *commentPreserving) Marshal(raw *specs.Spec) ([]byte, error) {
+	var updated yaml.Node
+	if err := updated.Encode(raw); err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+
+	document := &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{&updated},
+	}
+	copyComments(m.original, document)
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(m.indent)
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// detectIndent returns the indentation of the specified YAML document. This is
+// the smallest indentation of a line that is not a comment.
+func detectIndent(contents []byte) int {
+	indent := 0
+	for _, line := range bytes.Split(contents, []byte("\n")) {
+		trimmed := bytes.TrimLeft(line, " ")
+		if len(trimmed) == 0 || trimmed[0] == '#' {
+			continue
+		}
+		if n := len(line) - len(trimmed); n > 0 && (indent == 0 || n < indent) {
+			indent = n
+		}
+	}
+	if indent < 2 || indent > 9 {
+		return defaultCommentPreservingIndent
+	}
+	return indent
+}
+
+// copyComments copies the comments of the from node and its descendants to
+// the corresponding nodes of the to node.
+func copyComments(from *yaml.Node, to *yaml.Node) {
+	if from == nil || to == nil || from.Kind != to.Kind {
+		return
+	}
+	to.HeadComment = from.HeadComment
+	to.LineComment = from.LineComment
+	to.FootComment = from.FootComment
+
+	switch to.Kind {
+	case yaml.DocumentNode:
+		if len(from.Content) > 0 && len(to.Content) > 0 {
+			copyComments(from.Content[0], to.Content[0])
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(to.Content); i += 2 {
+			key, value := to.Content[i], to.Content[i+1]
+			fromKey, fromValue := lookupKey(from, key.Value)
+			copyComments(fromKey, key)
+			copyComments(fromValue, value)
+		}
+	case yaml.SequenceNode:
+		for i, element := range to.Content {
+			copyComments(lookupElement(from, element, i), element)
+		}
+	}
+}
+
+// lookupKey returns the key and value nodes of the mapping entry with the
+// specified key.
+func lookupKey(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// lookupElement returns the element of the specified sequence that corresponds
+// to the specified element. If the element has a name, the element with the
+// same name is returned. Otherwise the element at the same index is returned.
+func lookupElement(sequence *yaml.Node, element *yaml.Node, index int) *yaml.Node {
+	if _, name := lookupKey(element, "name"); name != nil {
+		for _, candidate := range sequence.Content {
+			if _, candidateName := lookupKey(candidate, "name"); candidateName != nil && candidateName.Value == name.Value {
+				return candidate
+			}
+		}
+		return nil
+	}
+	if index < len(sequence.Content) {
+		return sequence.Content[index]
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestCommentPreservingMarshaler(t *testing.T) {
+	original := `# The NVIDIA GPU spec.
+---
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  # The first GPU.
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0 # the device node
+  # The second GPU.
+  - name: gpu1
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia1
+containerEdits:
+  # Driver libraries.
+  mounts:
+    - hostPath: /driver/usr/lib/libcuda.so.1
+      containerPath: /usr/lib/libcuda.so.1
+`
+
+	testCases := []struct {
+		description string
+		modify      func(*specs.Spec)
+		expected    string
+	}{
+		{
+			description: "unmodified spec",
+			modify:      func(*specs.Spec) {},
+			expected: `---
+# The NVIDIA GPU spec.
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  # The first GPU.
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0 # the device node
+  # The second GPU.
+  - name: gpu1
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia1
+containerEdits:
+  # Driver libraries.
+  mounts:
+    - hostPath: /driver/usr/lib/libcuda.so.1
+      containerPath: /usr/lib/libcuda.so.1
+`,
+		},
+		{
+			description: "modified values keep their comments",
+			modify: func(s *specs.Spec) {
+				s.ContainerEdits.Mounts[0].HostPath = "/usr/lib/libcuda.so.1"
+				s.Devices[0].ContainerEdits.DeviceNodes[0].HostPath = "/host/dev/nvidia0"
+			},
+			expected: `---
+# The NVIDIA GPU spec.
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  # The first GPU.
+  - name: gpu0
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia0 # the device node
+          hostPath: /host/dev/nvidia0
+  # The second GPU.
+  - name: gpu1
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia1
+containerEdits:
+  # Driver libraries.
+  mounts:
+    - hostPath: /usr/lib/libcuda.so.1
+      containerPath: /usr/lib/libcuda.so.1
+`,
+		},
+		{
+			description: "comments follow devices by name",
+			modify: func(s *specs.Spec) {
+				s.Devices = s.Devices[1:]
+			},
+			expected: `---
+# The NVIDIA GPU spec.
+cdiVersion: 0.5.0
+kind: nvidia.com/gpu
+devices:
+  # The second GPU.
+  - name: gpu1
+    containerEdits:
+      deviceNodes:
+        - path: /dev/nvidia1
+containerEdits:
+  # Driver libraries.
+  mounts:
+    - hostPath: /driver/usr/lib/libcuda.so.1
+      containerPath: /usr/lib/libcuda.so.1
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			raw, err := cdi.ParseSpec([]byte(original))
+			require.NoError(t, err)
+			tc.modify(raw)
+
+			marshaler, err := NewCommentPreservingMarshaler([]byte(original), 0)
+			require.NoError(t, err)
+
+			contents, err := marshaler.Marshal(raw)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(contents))
+		})
+	}
+}
+
+func TestDetectIndent(t *testing.T) {
+	testCases := []struct {
+		description    string
+		contents       string
+		expectedIndent int
+	}{
+		{
+			description:    "two spaces",
+			contents:       "devices:\n  - name: gpu0\n    containerEdits: {}\n",
+			expectedIndent: 2,
+		},
+		{
+			description:    "four spaces",
+			contents:       "devices:\n    - name: gpu0\n      containerEdits: {}\n",
+			expectedIndent: 4,
+		},
+		{
+			description:    "indented comments are ignored",
+			contents:       "devices:\n # comment\n  - name: gpu0\n",
+			expectedIndent: 2,
+		},
+		{
+			description:    "no indentation uses the default",
+			contents:       "cdiVersion: 0.5.0\nkind: nvidia.com/gpu\n",
+			expectedIndent: defaultCommentPreservingIndent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			require.Equal(t, tc.expectedIndent, detectIndent([]byte(tc.contents)))
+		})
+	}
+}
@@ -17,14 +17,20 @@
 package spec
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"gopkg.in/yaml.v3"
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/fsutil"
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
 )
 
@@ -32,9 +38,28 @@ type spec struct {
 	*specs.Spec
 	format          string
 	permissions     os.FileMode
+	yamlIndent      int
+	jsonIndent      bool
+	unsafeKind      bool
+	tempDir         string
+	headerComment   string
+	noYAMLSeparator bool
+	yamlMarshaler   Marshaler
 	transformOnSave transform.Transformer
 }
 
+// unsafeKindPlaceholder is the kind used to validate specs for which the
+// validation of the kind is skipped.
+const unsafeKindPlaceholder = "nvidia.com/unsafe-kind"
+
+// HeaderCommentAnnotation is the spec annotation used to include the header
+// comment in JSON specs.
+const HeaderCommentAnnotation = "cdi.nvidia.com/header-comment"
+
+// defaultYAMLIndent is the indentation used by the cdi package when writing
+// YAML specs.
+const defaultYAMLIndent = 4
+
 var _ Interface = (*spec)(nil)
 
 // New creates a new spec with the specified options.
@@ -43,43 +68,117 @@ func New(opts ...Option) (Interface, error) {
 }
 
 // Save writes the spec to the specified path and overwrites the file if it exists.
+// The spec is first written to a temporary directory alongside the specified
+// path and then renamed into place. This ensures that readers never observe a
+// partially written spec. If a different temporary directory is configured
+// and this is on a different filesystem, the spec is copied into place
+// instead.
 func (s *spec) Save(path string) error {
+	path, err := s.normalizePath(path)
+	if err != nil {
+		return fmt.Errorf("failed to normalize path: %w", err)
+	}
+	// The header comment annotation is added before the transform is applied
+	// since this may affect the minimum required spec version.
+	s = s.withHeaderCommentAnnotation(path)
 	if s.transformOnSave != nil {
 		err := s.transformOnSave.Transform(s.Raw())
 		if err != nil {
 			return fmt.Errorf("error applying transform: %w", err)
 		}
 	}
-	path, err := s.normalizePath(path)
-	if err != nil {
-		return fmt.Errorf("failed to normalize path: %w", err)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create spec directory: %w", err)
+	}
+	return fsutil.WriteAtomic(path, s.tempDir, s.writeToDir)
+}
+
+// withHeaderCommentAnnotation returns a copy of the spec that includes the
+// header comment as an annotation if the spec is saved as JSON. The annotations
+// of the original spec are not modified.
+func (s *spec) withHeaderCommentAnnotation(path string) *spec {
+	if s.headerComment == "" || filepath.Ext(path) != ".json" {
+		return s
 	}
+	raw := *s.Spec
+	raw.Annotations = maps.Clone(s.Annotations)
+	if raw.Annotations == nil {
+		raw.Annotations = make(map[string]string)
+	}
+	raw.Annotations[HeaderCommentAnnotation] = s.headerComment
+
+	c := *s
+	c.Spec = &raw
+	return &c
+}
 
-	specDir, filename := filepath.Split(path)
+// writeToDir writes the spec to a file with the specified name in the
+// specified directory and applies the configured indentation and permissions.
+func (s *spec) writeToDir(dir string, filename string) error {
 	cache, _ := cdi.NewCache(
 		cdi.WithAutoRefresh(false),
-		cdi.WithSpecDirs(specDir),
+		cdi.WithSpecDirs(dir),
 	)
-	if err := cache.WriteSpec(s.Raw(), filename); err != nil {
+	if err := s.writeValidatedSpec(cache, filename); err != nil {
 		return fmt.Errorf("failed to write spec: %w", err)
 	}
 
-	specDirAsRoot, err := os.OpenRoot(specDir)
+	dirAsRoot, err := os.OpenRoot(dir)
 	if err != nil {
 		return fmt.Errorf("failed to open root: %w", err)
 	}
-	defer specDirAsRoot.Close()
+	defer dirAsRoot.Close()
+
+	if (s.yamlIndent != 0 || s.unsafeKind || s.yamlMarshaler != nil) && filepath.Ext(filename) == ".yaml" {
+		if err := s.writeYAML(dirAsRoot, filename); err != nil {
+			return fmt.Errorf("failed to write spec with custom indentation: %w", err)
+		}
+	}
+
+	if (s.jsonIndent || s.unsafeKind) && filepath.Ext(filename) == ".json" {
+		if err := s.writeJSON(dirAsRoot, filename); err != nil {
+			return fmt.Errorf("failed to write indented spec: %w", err)
+		}
+	}
+
+	if s.noYAMLSeparator && filepath.Ext(filename) == ".yaml" {
+		if err := s.removeYAMLSeparator(dirAsRoot, filename); err != nil {
+			return fmt.Errorf("failed to remove document separator: %w", err)
+		}
+	}
+
+	if s.headerComment != "" && filepath.Ext(filename) == ".yaml" {
+		if err := s.prependHeaderComment(dirAsRoot, filename); err != nil {
+			return fmt.Errorf("failed to add header comment: %w", err)
+		}
+	}
 
-	if err := specDirAsRoot.Chmod(filename, s.permissions); err != nil {
+	if err := dirAsRoot.Chmod(filename, s.permissions); err != nil {
 		return fmt.Errorf("failed to set permissions on spec file: %w", err)
 	}
 
 	return nil
 }
 
+// writeValidatedSpec writes the spec using the cdi package to ensure that it is
+// validated. If the validation of the kind is skipped, the spec is validated
+// using a placeholder kind. The file is then rewritten with the actual kind.
+func (s *spec) writeValidatedSpec(cache *cdi.Cache, filename string) error {
+	if !s.unsafeKind {
+		return cache.WriteSpec(s.Raw(), filename)
+	}
+	kind := s.Kind
+	s.Kind = unsafeKindPlaceholder
+	defer func() {
+		s.Kind = kind
+	}()
+	return cache.WriteSpec(s.Raw(), filename)
+}
+
 // WriteTo writes the spec to the specified writer.
 func (s *spec) WriteTo(w io.Writer) (int64, error) {
-	tmpFile, err := os.CreateTemp("", "nvcdi-spec-*"+s.extension())
+	tmpFile, err := os.CreateTemp(s.tempDir, "nvcdi-spec-*"+s.extension())
 	if err != nil {
 		return 0, err
 	}
@@ -111,6 +210,94 @@ func (s *spec) WriteTo(w io.Writer) (int64, error) {
 	return savedFile.WriteTo(w)
 }
 
+// writeYAML rewrites the spec file with the configured indentation or using
+// the configured marshaler. The spec is first written using the cdi package to
+// ensure that it is validated.
+func (s *spec) writeYAML(root *os.Root, filename string) error {
+	if s.yamlMarshaler != nil {
+		contents, err := s.yamlMarshaler.Marshal(s.Raw())
+		if err != nil {
+			return err
+		}
+		return root.WriteFile(filename, contents, s.permissions)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+
+	indent := s.yamlIndent
+	if indent == 0 {
+		indent = defaultYAMLIndent
+	}
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(indent)
+	if err := encoder.Encode(s.Raw()); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return root.WriteFile(filename, buf.Bytes(), s.permissions)
+}
+
+// writeJSON rewrites the spec file as JSON. If requested, the JSON is indented
+// using two spaces. The spec is first written using the cdi package to ensure
+// that it is validated.
+func (s *spec) writeJSON(root *os.Root, filename string) error {
+	var contents []byte
+	var err error
+	if s.jsonIndent {
+		contents, err = json.MarshalIndent(s.Raw(), "", "  ")
+	} else {
+		contents, err = json.Marshal(s.Raw())
+	}
+	if err != nil {
+		return err
+	}
+	return root.WriteFile(filename, append(contents, '\n'), s.permissions)
+}
+
+// removeYAMLSeparator rewrites the spec file without the leading YAML document
+// separator.
+func (s *spec) removeYAMLSeparator(root *os.Root, filename string) error {
+	contents, err := root.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	trimmed, found := bytes.CutPrefix(contents, []byte("---\n"))
+	if !found {
+		return nil
+	}
+	return root.WriteFile(filename, trimmed, s.permissions)
+}
+
+// prependHeaderComment rewrites the spec file with the header comment as
+// leading comment lines. Lines of the comment that are already YAML comments
+// are included as is.
+func (s *spec) prependHeaderComment(root *os.Root, filename string) error {
+	contents, err := root.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, line := range strings.Split(strings.TrimRight(s.headerComment, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "#"):
+			buf.WriteString(line)
+		case strings.TrimSpace(line) == "":
+			buf.WriteString("#")
+		default:
+			buf.WriteString("# " + line)
+		}
+		buf.WriteString("\n")
+	}
+	buf.Write(contents)
+
+	return root.WriteFile(filename, buf.Bytes(), s.permissions)
+}
+
 // Raw returns a pointer to the raw spec.
 func (s *spec) Raw() *specs.Spec {
 	return s.Spec
@@ -18,9 +18,14 @@ package spec
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/specs-go"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/pkg/nvcdi/transform"
@@ -58,6 +63,39 @@ devices:
       containerEdits:
         env:
             - DEVICE_FOO=bar
+`,
+		},
+		{
+			description: "custom YAML indent is used",
+			options:     []Option{WithVersion("0.8.0"), WithRawSpec(minimalSpec), WithYAMLIndent(2)},
+			expectedSpec: `---
+cdiVersion: 0.8.0
+kind: nvidia.com/gpu
+devices:
+  - name: one
+    containerEdits:
+      env:
+        - DEVICE_FOO=bar
+`,
+		},
+		{
+			description: "JSON indent is used",
+			options:     []Option{WithVersion("0.8.0"), WithRawSpec(minimalSpec), WithFormat(FormatJSON), WithJSONIndent(true)},
+			expectedSpec: `{
+  "cdiVersion": "0.8.0",
+  "kind": "nvidia.com/gpu",
+  "devices": [
+    {
+      "name": "one",
+      "containerEdits": {
+        "env": [
+          "DEVICE_FOO=bar"
+        ]
+      }
+    }
+  ],
+  "containerEdits": {}
+}
 `,
 		},
 		{
@@ -215,3 +253,285 @@ containerEdits:
 		})
 	}
 }
+
+func TestSpecJSONIndentRoundTrip(t *testing.T) {
+	raw := &specs.Spec{
+		Version: "0.5.0",
+		Kind:    "nvidia.com/gpu",
+		Devices: []specs.Device{
+			{
+				Name: "one",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"DEVICE_FOO=bar"},
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/nvidia0", HostPath: "/dev/nvidia0"},
+					},
+				},
+			},
+		},
+	}
+
+	var parsed []specs.Spec
+	for _, indent := range []bool{false, true} {
+		s, err := New(WithRawSpec(raw), WithFormat(FormatJSON), WithJSONIndent(indent))
+		require.NoError(t, err)
+
+		buf := new(bytes.Buffer)
+		_, err = s.WriteTo(buf)
+		require.NoError(t, err)
+
+		var spec specs.Spec
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &spec))
+		parsed = append(parsed, spec)
+	}
+
+	require.Equal(t, parsed[0], parsed[1])
+}
+
+func TestSaveIsAtomic(t *testing.T) {
+	specDir := t.TempDir()
+	path := filepath.Join(specDir, "nvidia.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("existing"), 0600))
+
+	invalid, err := New(
+		WithVersion("0.5.0"),
+		WithRawSpec(&specs.Spec{
+			Kind:    "nvidia.com/gpu",
+			Devices: []specs.Device{{Name: "invalid name!"}},
+		}),
+	)
+	require.NoError(t, err)
+	require.Error(t, invalid.Save(path))
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "existing", string(contents))
+
+	valid, err := New(
+		WithVersion("0.5.0"),
+		WithPermissions(0640),
+		WithDeviceSpecs([]specs.Device{
+			{
+				Name: "one",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"DEVICE_FOO=bar"},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, valid.Save(path))
+
+	contents, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), "DEVICE_FOO=bar")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	entries, err := os.ReadDir(specDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files must be removed")
+}
+
+func TestSaveWithTempDir(t *testing.T) {
+	specDir := t.TempDir()
+	tempDir := t.TempDir()
+	path := filepath.Join(specDir, "nvidia.yaml")
+
+	s, err := New(
+		WithVersion("0.5.0"),
+		WithTempDir(tempDir),
+		WithDeviceSpecs([]specs.Device{
+			{
+				Name: "one",
+				ContainerEdits: specs.ContainerEdits{
+					Env: []string{"DEVICE_FOO=bar"},
+				},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.Save(path))
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(contents), "DEVICE_FOO=bar")
+
+	entries, err := os.ReadDir(specDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	entries, err = os.ReadDir(tempDir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "temporary files must be removed")
+}
+
+func TestSpecUnsafeKind(t *testing.T) {
+	newRaw := func() *specs.Spec {
+		return &specs.Spec{
+			Version: "0.5.0",
+			Kind:    "example.com/Experimental_Class!",
+			Devices: []specs.Device{
+				{
+					Name: "one",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: []*specs.DeviceNode{
+							{Path: "/dev/nvidia0", HostPath: "/dev/nvidia0"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			s, err := New(WithRawSpec(newRaw()), WithFormat(format))
+			require.NoError(t, err)
+			_, err = s.WriteTo(new(bytes.Buffer))
+			require.ErrorContains(t, err, "invalid")
+
+			s, err = New(WithRawSpec(newRaw()), WithFormat(format), WithUnsafeKind(true))
+			require.NoError(t, err)
+			buf := new(bytes.Buffer)
+			_, err = s.WriteTo(buf)
+			require.NoError(t, err)
+			require.Contains(t, buf.String(), "example.com/Experimental_Class!")
+			require.Equal(t, "example.com/Experimental_Class!", s.Raw().Kind)
+		})
+	}
+
+	t.Run("yaml indentation matches the default", func(t *testing.T) {
+		raw := newRaw()
+		raw.Kind = "example.com/class"
+		s, err := New(WithRawSpec(raw), WithFormat(FormatYAML))
+		require.NoError(t, err)
+		expected := new(bytes.Buffer)
+		_, err = s.WriteTo(expected)
+		require.NoError(t, err)
+
+		s, err = New(WithRawSpec(raw), WithFormat(FormatYAML), WithUnsafeKind(true))
+		require.NoError(t, err)
+		buf := new(bytes.Buffer)
+		_, err = s.WriteTo(buf)
+		require.NoError(t, err)
+		require.Equal(t, expected.String(), buf.String())
+	})
+
+	t.Run("other validation is still performed", func(t *testing.T) {
+		raw := newRaw()
+		raw.Devices = nil
+		s, err := New(WithRawSpec(raw), WithUnsafeKind(true))
+		require.NoError(t, err)
+		_, err = s.WriteTo(new(bytes.Buffer))
+		require.ErrorContains(t, err, "no devices")
+	})
+}
+
+func TestSaveWithHeaderComment(t *testing.T) {
+	newSpec := func(format string) Interface {
+		s, err := New(
+			WithFormat(format),
+			WithHeaderComment("Copyright (c) Example Corp.\n\n# SPDX-License-Identifier: Apache-2.0\n"),
+			WithDeviceSpecs([]specs.Device{
+				{
+					Name: "one",
+					ContainerEdits: specs.ContainerEdits{
+						Env: []string{"DEVICE_FOO=bar"},
+					},
+				},
+			}),
+		)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nvidia.yaml")
+		require.NoError(t, newSpec(FormatYAML).Save(path))
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(contents), "# Copyright (c) Example Corp.\n#\n# SPDX-License-Identifier: Apache-2.0\n---\n"))
+
+		_, err = cdi.ReadSpec(path, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nvidia.json")
+		s := newSpec(FormatJSON)
+		require.NoError(t, s.Save(path))
+		require.NotContains(t, s.Raw().Annotations, HeaderCommentAnnotation)
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var raw specs.Spec
+		require.NoError(t, json.Unmarshal(contents, &raw))
+		require.Equal(t, "0.6.0", raw.Version)
+		require.Equal(t, "Copyright (c) Example Corp.\n\n# SPDX-License-Identifier: Apache-2.0\n", raw.Annotations[HeaderCommentAnnotation])
+	})
+}
+
+func TestSaveWithNoYAMLSeparator(t *testing.T) {
+	testCases := []struct {
+		description     string
+		options         []Option
+		noYAMLSeparator bool
+		expectedPrefix  string
+	}{
+		{
+			description:    "separator is included by default",
+			expectedPrefix: "---\ncdiVersion:",
+		},
+		{
+			description:     "separator is omitted",
+			noYAMLSeparator: true,
+			expectedPrefix:  "cdiVersion:",
+		},
+		{
+			description:     "separator is omitted with custom indentation",
+			options:         []Option{WithYAMLIndent(2)},
+			noYAMLSeparator: true,
+			expectedPrefix:  "cdiVersion:",
+		},
+		{
+			description:     "separator is omitted after header comment",
+			options:         []Option{WithHeaderComment("Example")},
+			noYAMLSeparator: true,
+			expectedPrefix:  "# Example\ncdiVersion:",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			options := append([]Option{
+				WithFormat(FormatYAML),
+				WithNoYAMLSeparator(tc.noYAMLSeparator),
+				WithDeviceSpecs([]specs.Device{
+					{
+						Name: "one",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"DEVICE_FOO=bar"},
+						},
+					},
+				}),
+			}, tc.options...)
+			s, err := New(options...)
+			require.NoError(t, err)
+
+			path := filepath.Join(t.TempDir(), "nvidia.yaml")
+			require.NoError(t, s.Save(path))
+
+			contents, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(string(contents), tc.expectedPrefix), string(contents))
+
+			_, err = cdi.ReadSpec(path, 0)
+			require.NoError(t, err)
+		})
+	}
+}
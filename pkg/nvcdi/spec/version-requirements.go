@@ -0,0 +1,169 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+// VersionRequirement describes the minimum CDI spec version required by a
+// single device or by the spec-level fields of a CDI spec.
+type VersionRequirement struct {
+	// Device is the name of the device. This is empty for the spec-level
+	// fields such as the kind, annotations, and common container edits.
+	Device string
+	// Version is the minimum CDI spec version required.
+	Version string
+	// Features are the fields that require Version. This is empty if the
+	// earliest supported version is sufficient.
+	Features []string
+}
+
+// placeholderDeviceName is the name used for a device when determining the
+// version required by a single field of the device. Since this starts with a
+// letter, the name itself does not require a later version.
+const placeholderDeviceName = "device"
+
+// GetVersionRequirements returns the minimum CDI spec version required by the
+// spec-level fields and by each device of the specified spec. The spec-level
+// requirement is returned first followed by the devices in the order that
+// these are defined. The minimum required version of the spec as a whole is
+// the latest of these versions.
+func GetVersionRequirements(raw *specs.Spec) ([]VersionRequirement, error) {
+	earliest, err := cdi.MinimumRequiredVersion(&specs.Spec{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get earliest CDI spec version: %w", err)
+	}
+
+	specFeatures := map[string]*specs.Spec{
+		"kind":        {Kind: raw.Kind},
+		"annotations": {Annotations: raw.Annotations},
+	}
+	editsFeatures, err := splitContainerEdits(raw.ContainerEdits)
+	if err != nil {
+		return nil, err
+	}
+	for name, edits := range editsFeatures {
+		specFeatures[name] = &specs.Spec{ContainerEdits: edits}
+	}
+	specLevel := &specs.Spec{
+		Kind:           raw.Kind,
+		Annotations:    raw.Annotations,
+		ContainerEdits: raw.ContainerEdits,
+	}
+	specRequirement, err := getVersionRequirement("", earliest, specLevel, specFeatures)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := []VersionRequirement{*specRequirement}
+	for _, device := range raw.Devices {
+		deviceFeatures := map[string]*specs.Spec{
+			"name": {Devices: []specs.Device{{Name: device.Name}}},
+			"annotations": {Devices: []specs.Device{{
+				Name:        placeholderDeviceName,
+				Annotations: device.Annotations,
+			}}},
+		}
+		editsFeatures, err := splitContainerEdits(device.ContainerEdits)
+		if err != nil {
+			return nil, err
+		}
+		for name, edits := range editsFeatures {
+			deviceFeatures[name] = &specs.Spec{Devices: []specs.Device{{
+				Name:           placeholderDeviceName,
+				ContainerEdits: edits,
+			}}}
+		}
+		deviceLevel := &specs.Spec{Devices: []specs.Device{device}}
+		requirement, err := getVersionRequirement(device.Name, earliest, deviceLevel, deviceFeatures)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, *requirement)
+	}
+	return requirements, nil
+}
+
+// getVersionRequirement determines the minimum version required by the
+// specified partial spec. Each feature is a spec containing only a single
+// field of the partial spec and the features that require the same version as
+// the partial spec are included in the requirement unless this is the earliest
+// supported version.
+func getVersionRequirement(device string, earliest string, partial *specs.Spec, features map[string]*specs.Spec) (*VersionRequirement, error) {
+	versions := make(map[string]string)
+	for name, feature := range features {
+		version, err := cdi.MinimumRequiredVersion(feature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get minimum required CDI spec version for %v: %w", name, err)
+		}
+		versions[name] = version
+	}
+
+	version, err := cdi.MinimumRequiredVersion(partial)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get minimum required CDI spec version: %w", err)
+	}
+
+	requirement := &VersionRequirement{
+		Device:  device,
+		Version: version,
+	}
+	if version == earliest {
+		return requirement, nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(versions)) {
+		if versions[name] == version {
+			requirement.Features = append(requirement.Features, name)
+		}
+	}
+	return requirement, nil
+}
+
+// splitContainerEdits splits the specified container edits into a set of
+// edits each containing a single field. The edits are keyed by the JSON name
+// of the field prefixed with containerEdits.
+func splitContainerEdits(edits specs.ContainerEdits) (map[string]specs.ContainerEdits, error) {
+	contents, err := json.Marshal(edits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal container edits: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(contents, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal container edits: %w", err)
+	}
+
+	split := make(map[string]specs.ContainerEdits)
+	for name, value := range fields {
+		field, err := json.Marshal(map[string]json.RawMessage{name: value})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal container edits field %v: %w", name, err)
+		}
+		var single specs.ContainerEdits
+		if err := json.Unmarshal(field, &single); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal container edits field %v: %w", name, err)
+		}
+		split["containerEdits."+name] = single
+	}
+	return split, nil
+}
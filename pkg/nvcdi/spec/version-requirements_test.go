@@ -0,0 +1,113 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+func TestGetVersionRequirements(t *testing.T) {
+	testCases := []struct {
+		description          string
+		spec                 *specs.Spec
+		expectedRequirements []VersionRequirement
+	}{
+		{
+			description: "earliest version has no features",
+			spec: &specs.Spec{
+				Kind: "example.com/device",
+				Devices: []specs.Device{
+					{
+						Name: "gpu0",
+						ContainerEdits: specs.ContainerEdits{
+							Env: []string{"FOO=bar"},
+						},
+					},
+				},
+			},
+			expectedRequirements: []VersionRequirement{
+				{Version: "0.3.0"},
+				{Device: "gpu0", Version: "0.3.0"},
+			},
+		},
+		{
+			description: "device features are reported",
+			spec: &specs.Spec{
+				Kind: "example.com/device",
+				ContainerEdits: specs.ContainerEdits{
+					DeviceNodes: []*specs.DeviceNode{
+						{Path: "/dev/nvidiactl", HostPath: "/dev/nvidiactl"},
+					},
+				},
+				Devices: []specs.Device{
+					{
+						Name: "0",
+						ContainerEdits: specs.ContainerEdits{
+							DeviceNodes: []*specs.DeviceNode{
+								{Path: "/dev/nvidia0", HostPath: "/dev/nvidia0"},
+							},
+						},
+					},
+					{
+						Name: "gpu1",
+						ContainerEdits: specs.ContainerEdits{
+							AdditionalGIDs: []uint32{44},
+							Mounts: []*specs.Mount{
+								{HostPath: "/lib/libcuda.so", ContainerPath: "/lib/libcuda.so", Type: "bind"},
+							},
+						},
+					},
+				},
+			},
+			expectedRequirements: []VersionRequirement{
+				{Version: "0.5.0", Features: []string{"containerEdits.deviceNodes"}},
+				{Device: "0", Version: "0.5.0", Features: []string{"containerEdits.deviceNodes", "name"}},
+				{Device: "gpu1", Version: "0.7.0", Features: []string{"containerEdits.additionalGids"}},
+			},
+		},
+		{
+			description: "spec-level features are reported",
+			spec: &specs.Spec{
+				Kind:        "example.com/device.class",
+				Annotations: map[string]string{"foo": "bar"},
+				Devices: []specs.Device{
+					{
+						Name: "gpu0",
+						Annotations: map[string]string{
+							"foo": "bar",
+						},
+					},
+				},
+			},
+			expectedRequirements: []VersionRequirement{
+				{Version: "0.6.0", Features: []string{"annotations", "kind"}},
+				{Device: "gpu0", Version: "0.6.0", Features: []string{"annotations"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			requirements, err := GetVersionRequirements(tc.spec)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.expectedRequirements, requirements)
+		})
+	}
+}
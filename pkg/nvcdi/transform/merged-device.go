@@ -18,8 +18,11 @@ package transform
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/edits"
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
 
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 	"tags.cncf.io/container-device-interface/pkg/parser"
@@ -31,8 +34,11 @@ const (
 )
 
 type mergedDevice struct {
+	logger       logger.Interface
 	name         string
 	skipIfExists bool
+	strict       bool
+	exclude      func(specs.Device) bool
 	simplifier   Transformer
 }
 
@@ -55,12 +61,39 @@ func WithSkipIfExists(skipIfExists bool) MergedDeviceOption {
 	}
 }
 
+// WithLogger sets the logger used to report conflicting edits in the merged
+// device.
+func WithLogger(logger logger.Interface) MergedDeviceOption {
+	return func(m *mergedDevice) {
+		m.logger = logger
+	}
+}
+
+// WithStrict sets whether conflicting environment variables in the devices
+// being merged are treated as an error instead of being logged as a warning.
+func WithStrict(strict bool) MergedDeviceOption {
+	return func(m *mergedDevice) {
+		m.strict = strict
+	}
+}
+
+// WithExcludedDevices sets a function that selects the devices whose edits are
+// not included in the merged device.
+func WithExcludedDevices(exclude func(specs.Device) bool) MergedDeviceOption {
+	return func(m *mergedDevice) {
+		m.exclude = exclude
+	}
+}
+
 // NewMergedDevice creates a transformer with the specified options
 func NewMergedDevice(opts ...MergedDeviceOption) (Transformer, error) {
 	m := &mergedDevice{}
 	for _, opt := range opts {
 		opt(m)
 	}
+	if m.logger == nil {
+		m.logger = logger.New()
+	}
 	if m.name == "" {
 		m.name = allDeviceName
 	}
@@ -79,7 +112,23 @@ func (m mergedDevice) Transform(spec *specs.Spec) error {
 		return nil
 	}
 
-	mergedDevice, err := mergeDeviceSpecs(spec.Devices, m.name)
+	if slices.ContainsFunc(spec.Devices, func(d specs.Device) bool { return d.Name == m.name }) {
+		if m.skipIfExists {
+			return nil
+		}
+		return fmt.Errorf("device %q already exists", m.name)
+	}
+
+	devices := spec.Devices
+	if m.exclude != nil {
+		devices = slices.DeleteFunc(slices.Clone(devices), m.exclude)
+		if len(devices) == 0 && len(spec.Devices) > 0 {
+			m.logger.Warningf("Skipping merged device %q: all devices are excluded", m.name)
+			return nil
+		}
+	}
+
+	mergedDevice, err := mergeDeviceSpecs(devices, m.name)
 	if err != nil {
 		return fmt.Errorf("failed to generate merged device %q: %v", m.name, err)
 	}
@@ -90,6 +139,13 @@ func (m mergedDevice) Transform(spec *specs.Spec) error {
 		return fmt.Errorf("device %q already exists", m.name)
 	}
 
+	if conflicts := getConflictingEnvs(devices); len(conflicts) > 0 {
+		if m.strict {
+			return fmt.Errorf("conflicting environment variables in merged device %q: %v", m.name, conflicts)
+		}
+		m.logger.Warningf("Conflicting environment variables in merged device %q; the last value takes precedence: %v", m.name, conflicts)
+	}
+
 	spec.Devices = append(spec.Devices, *mergedDevice)
 
 	if err := m.simplifier.Transform(spec); err != nil {
@@ -124,3 +180,41 @@ func mergeDeviceSpecs(deviceSpecs []specs.Device, mergedDeviceName string) (*spe
 	}
 	return &merged, nil
 }
+
+// envConflict records the distinct values that are set for an environment
+// variable by the devices being merged.
+type envConflict struct {
+	name   string
+	values []string
+}
+
+func (c envConflict) String() string {
+	return fmt.Sprintf("%v=%v", c.name, strings.Join(c.values, "|"))
+}
+
+// getConflictingEnvs returns the environment variables that are set to
+// different values by the specified devices. The conflicts are sorted by
+// name and the values are listed in the order in which they were encountered.
+func getConflictingEnvs(deviceSpecs []specs.Device) []envConflict {
+	values := make(map[string][]string)
+	for _, d := range deviceSpecs {
+		for _, env := range d.ContainerEdits.Env {
+			name, value, _ := strings.Cut(env, "=")
+			if !slices.Contains(values[name], value) {
+				values[name] = append(values[name], value)
+			}
+		}
+	}
+
+	var conflicts []envConflict
+	for name, v := range values {
+		if len(v) < 2 {
+			continue
+		}
+		conflicts = append(conflicts, envConflict{name: name, values: v})
+	}
+	slices.SortFunc(conflicts, func(a, b envConflict) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return conflicts
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/require"
 	"tags.cncf.io/container-device-interface/specs-go"
 )
@@ -220,3 +221,115 @@ func TestMergedDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestMergedDeviceConflictingEnvs(t *testing.T) {
+	newSpec := func() *specs.Spec {
+		return &specs.Spec{
+			Devices: []specs.Device{
+				{
+					Name: "gpu0",
+					ContainerEdits: specs.ContainerEdits{
+						Env: []string{"FOO=bar", "SAME=value", "NVIDIA_GPU=0"},
+					},
+				},
+				{
+					Name: "gpu1",
+					ContainerEdits: specs.ContainerEdits{
+						Env: []string{"FOO=baz", "SAME=value", "NVIDIA_GPU=1"},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		description      string
+		strict           bool
+		expectedError    string
+		expectedWarnings []string
+	}{
+		{
+			description: "conflicts are logged as a single warning",
+			expectedWarnings: []string{
+				`Conflicting environment variables in merged device "all"; the last value takes precedence: [FOO=bar|baz NVIDIA_GPU=0|1]`,
+			},
+		},
+		{
+			description:   "conflicts are an error in strict mode",
+			strict:        true,
+			expectedError: `conflicting environment variables in merged device "all": [FOO=bar|baz NVIDIA_GPU=0|1]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			logger, hook := testlog.NewNullLogger()
+
+			m, err := NewMergedDevice(WithLogger(logger), WithStrict(tc.strict))
+			require.NoError(t, err)
+
+			err = m.Transform(newSpec())
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var warnings []string
+			for _, entry := range hook.AllEntries() {
+				warnings = append(warnings, entry.Message)
+			}
+			require.Equal(t, tc.expectedWarnings, warnings)
+		})
+	}
+}
+
+func TestMergedDeviceExcludedDevices(t *testing.T) {
+	newSpec := func() *specs.Spec {
+		return &specs.Spec{
+			Devices: []specs.Device{
+				{
+					Name: "gpu0",
+					ContainerEdits: specs.ContainerEdits{
+						Env: []string{"PLACEHOLDER=gpu0"},
+					},
+					Annotations: map[string]string{"excluded": "true"},
+				},
+				{
+					Name: "gpu1",
+					ContainerEdits: specs.ContainerEdits{
+						DeviceNodes: []*specs.DeviceNode{{Path: "/dev/gpu1"}},
+					},
+				},
+			},
+		}
+	}
+	exclude := func(d specs.Device) bool {
+		return d.Annotations["excluded"] == "true"
+	}
+
+	t.Run("excluded devices are not merged", func(t *testing.T) {
+		logger, hook := testlog.NewNullLogger()
+		m, err := NewMergedDevice(WithLogger(logger), WithExcludedDevices(exclude))
+		require.NoError(t, err)
+
+		spec := newSpec()
+		require.NoError(t, m.Transform(spec))
+		require.Len(t, spec.Devices, 3)
+		require.Equal(t, "all", spec.Devices[0].Name)
+		require.Equal(t, []*specs.DeviceNode{{Path: "/dev/gpu1"}}, spec.Devices[0].ContainerEdits.DeviceNodes)
+		require.Empty(t, spec.Devices[0].ContainerEdits.Env)
+		require.Empty(t, hook.AllEntries())
+	})
+
+	t.Run("no merged device if all devices are excluded", func(t *testing.T) {
+		logger, hook := testlog.NewNullLogger()
+		m, err := NewMergedDevice(WithLogger(logger), WithExcludedDevices(func(specs.Device) bool { return true }))
+		require.NoError(t, err)
+
+		spec := newSpec()
+		require.NoError(t, m.Transform(spec))
+		require.Len(t, spec.Devices, 2)
+		require.Len(t, hook.AllEntries(), 1)
+	})
+}
@@ -0,0 +1,78 @@
+/**
+# Copyright (c) NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package transform
+
+import (
+	"path/filepath"
+
+	"tags.cncf.io/container-device-interface/specs-go"
+)
+
+type hookRemover map[string]bool
+
+// NewHookRemover creates a transformer that removes the hooks with the
+// specified names from the spec-level and device-level container edits.
+// The name of an nvidia-cdi-hook (or nvidia-ctk hook) is the name of the
+// subcommand that it invokes. For other hooks, the base name of the hook path
+// is used.
+func NewHookRemover(names ...string) Transformer {
+	r := make(hookRemover)
+	for _, name := range names {
+		r[name] = true
+	}
+	return r
+}
+
+// Transform removes the matching hooks from the spec.
+func (r hookRemover) Transform(spec *specs.Spec) error {
+	if spec == nil {
+		return nil
+	}
+
+	for i := range spec.Devices {
+		r.transformEdits(&spec.Devices[i].ContainerEdits)
+	}
+	r.transformEdits(&spec.ContainerEdits)
+
+	return nil
+}
+
+func (r hookRemover) transformEdits(edits *specs.ContainerEdits) {
+	var hooks []*specs.Hook
+	for _, hook := range edits.Hooks {
+		if hook != nil && r[getHookName(hook)] {
+			continue
+		}
+		hooks = append(hooks, hook)
+	}
+	edits.Hooks = hooks
+}
+
+// getHookName returns the name used to match the specified hook.
+func getHookName(h *specs.Hook) string {
+	if len(h.Args) > 1 {
+		switch filepath.Base(h.Args[0]) {
+		case "nvidia-cdi-hook":
+			return h.Args[1]
+		case "nvidia-ctk":
+			if h.Args[1] == "hook" && len(h.Args) > 2 {
+				return h.Args[2]
+			}
+		}
+	}
+	return filepath.Base(h.Path)
+}
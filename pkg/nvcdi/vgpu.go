@@ -0,0 +1,102 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// PlatformVGPU is the platform for vGPU virtual machines running the GRID
+// guest driver. Devices are enumerated using NVML as for the nvml platform,
+// with the files specific to the guest driver also being included.
+const PlatformVGPU = info.Platform("vgpu")
+
+// detectPlatform resolves the platform of the current system. If NVML-based
+// discovery is detected, the devices are queried to determine whether this is
+// a vGPU guest.
+func (o *options) detectPlatform() info.Platform {
+	platform := o.infolib.ResolvePlatform()
+	if platform != info.PlatformNVML {
+		return platform
+	}
+	isGuest, reason := o.isVGPUGuest()
+	o.logger.Debugf("Is vGPU guest? %v: %v", isGuest, reason)
+	if isGuest {
+		return PlatformVGPU
+	}
+	return platform
+}
+
+// isVGPUGuest checks whether all devices report the vGPU virtualization mode
+// as is the case for the vGPU devices of a virtual machine.
+func (o *options) isVGPUGuest() (isGuest bool, reason string) {
+	// We ensure that this function never panics
+	defer func() {
+		if err := recover(); err != nil {
+			isGuest = false
+			reason = fmt.Sprintf("panic: %v", err)
+		}
+	}()
+
+	if o.nvmllib == nil || o.devicelib == nil {
+		return false, "nvml is not available"
+	}
+
+	ret := o.nvmllib.Init()
+	if ret != nvml.SUCCESS {
+		return false, fmt.Sprintf("failed to initialize nvml: %v", ret)
+	}
+	defer func() {
+		_ = o.nvmllib.Shutdown()
+	}()
+
+	var count int
+	err := o.devicelib.VisitDevices(func(i int, d device.Device) error {
+		mode, ret := d.GetVirtualizationMode()
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("device %v: %v", i, ret)
+		}
+		if mode != nvml.GPU_VIRTUALIZATION_MODE_VGPU {
+			return fmt.Errorf("device %v: virtualization mode is %v", i, mode)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return false, err.Error()
+	}
+	if count == 0 {
+		return false, "no devices found"
+	}
+	return true, "all devices are vGPU devices"
+}
+
+// newVGPUGuestDiscoverer returns a discoverer for the files specific to the
+// GRID guest driver. Nil is returned if the spec is not generated for a vGPU
+// guest.
+func (l *nvcdilib) newVGPUGuestDiscoverer() discover.Discover {
+	if !l.vgpuGuest {
+		return nil
+	}
+	return l.withDebugDump("vgpu", discover.NewVGPUGuestDiscoverer(l.logger, l.driver))
+}
@@ -0,0 +1,119 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/device"
+	"github.com/NVIDIA/go-nvlib/pkg/nvlib/info"
+	"github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/NVIDIA/go-nvml/pkg/nvml/mock"
+	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	logger, _ := testlog.NewNullLogger()
+
+	newNvmlLib := func(modes ...nvml.GpuVirtualizationMode) nvml.Interface {
+		return &mock.Interface{
+			InitFunc: func() nvml.Return {
+				return nvml.SUCCESS
+			},
+			ShutdownFunc: func() nvml.Return {
+				return nvml.SUCCESS
+			},
+			DeviceGetCountFunc: func() (int, nvml.Return) {
+				return len(modes), nvml.SUCCESS
+			},
+			DeviceGetHandleByIndexFunc: func(index int) (nvml.Device, nvml.Return) {
+				device := &mock.Device{
+					GetNameFunc: func() (string, nvml.Return) {
+						return "NVIDIA A100-SXM4-40GB", nvml.SUCCESS
+					},
+					GetVirtualizationModeFunc: func() (nvml.GpuVirtualizationMode, nvml.Return) {
+						return modes[index], nvml.SUCCESS
+					},
+				}
+				return device, nvml.SUCCESS
+			},
+		}
+	}
+
+	testCases := []struct {
+		description      string
+		detectedPlatform info.Platform
+		nvmllib          nvml.Interface
+		expectedPlatform info.Platform
+	}{
+		{
+			description:      "non-nvml platform is not queried",
+			detectedPlatform: info.PlatformTegra,
+			nvmllib:          &mock.Interface{},
+			expectedPlatform: info.PlatformTegra,
+		},
+		{
+			description:      "passthrough devices are nvml",
+			detectedPlatform: info.PlatformNVML,
+			nvmllib:          newNvmlLib(nvml.GPU_VIRTUALIZATION_MODE_PASSTHROUGH),
+			expectedPlatform: info.PlatformNVML,
+		},
+		{
+			description:      "vgpu devices are vgpu",
+			detectedPlatform: info.PlatformNVML,
+			nvmllib:          newNvmlLib(nvml.GPU_VIRTUALIZATION_MODE_VGPU, nvml.GPU_VIRTUALIZATION_MODE_VGPU),
+			expectedPlatform: PlatformVGPU,
+		},
+		{
+			description:      "mixed devices are nvml",
+			detectedPlatform: info.PlatformNVML,
+			nvmllib:          newNvmlLib(nvml.GPU_VIRTUALIZATION_MODE_VGPU, nvml.GPU_VIRTUALIZATION_MODE_NONE),
+			expectedPlatform: info.PlatformNVML,
+		},
+		{
+			description:      "no devices are nvml",
+			detectedPlatform: info.PlatformNVML,
+			nvmllib:          newNvmlLib(),
+			expectedPlatform: info.PlatformNVML,
+		},
+		{
+			description:      "unsupported query is nvml",
+			detectedPlatform: info.PlatformNVML,
+			nvmllib:          &mock.Interface{},
+			expectedPlatform: info.PlatformNVML,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			o := &options{
+				logger: logger,
+				platformlibs: platformlibs{
+					nvmllib:   tc.nvmllib,
+					devicelib: device.New(tc.nvmllib),
+					infolib: &infoInterfaceMock{
+						ResolvePlatformFunc: func() info.Platform {
+							return tc.detectedPlatform
+						},
+					},
+				},
+			}
+			require.Equal(t, tc.expectedPlatform, o.detectPlatform())
+		})
+	}
+}
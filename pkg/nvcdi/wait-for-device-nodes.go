@@ -0,0 +1,56 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"time"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+// DeviceNodeWait defines the device nodes that a container waits for when it
+// is started and the maximum time to wait for these.
+type DeviceNodeWait struct {
+	DeviceNodes []string
+	Timeout     time.Duration
+}
+
+// WithDeviceNodeWait sets the device nodes that a container waits for when it
+// is started. This is useful on systems where device nodes such as
+// /dev/nvidia-uvm are created lazily. If this is nil, no wait hook is added.
+func WithDeviceNodeWait(wait *DeviceNodeWait) Option {
+	return func(o *options) {
+		o.deviceNodeWait = wait
+	}
+}
+
+// newWaitForDeviceNodesHookDiscoverer returns a discoverer for the hook that
+// waits for the configured device nodes to exist in the container. Nil is
+// returned if no wait was requested.
+func (l *nvcdilib) newWaitForDeviceNodesHookDiscoverer() discover.Discover {
+	if l.deviceNodeWait == nil || len(l.deviceNodeWait.DeviceNodes) == 0 {
+		return nil
+	}
+	var args []string
+	for _, deviceNode := range l.deviceNodeWait.DeviceNodes {
+		args = append(args, "--device-node="+deviceNode)
+	}
+	if l.deviceNodeWait.Timeout > 0 {
+		args = append(args, "--timeout="+l.deviceNodeWait.Timeout.String())
+	}
+	return l.hookCreator.Create(discover.WaitForDeviceNodesHook, args...)
+}
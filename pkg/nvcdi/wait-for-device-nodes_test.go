@@ -0,0 +1,79 @@
+/**
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+**/
+
+package nvcdi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
+)
+
+func TestWaitForDeviceNodesHookDiscoverer(t *testing.T) {
+	testCases := []struct {
+		description   string
+		wait          *DeviceNodeWait
+		expectedHooks []discover.Hook
+	}{
+		{
+			description: "no wait requested",
+		},
+		{
+			description: "no device nodes",
+			wait:        &DeviceNodeWait{Timeout: time.Second},
+		},
+		{
+			description: "device nodes and timeout",
+			wait: &DeviceNodeWait{
+				DeviceNodes: []string{"/dev/nvidia-uvm", "/dev/nvidia-uvm-tools"},
+				Timeout:     15 * time.Second,
+			},
+			expectedHooks: []discover.Hook{
+				{
+					Lifecycle: "createContainer",
+					Path:      "/usr/bin/nvidia-cdi-hook",
+					Args: []string{
+						"nvidia-cdi-hook", "wait-for-device-nodes",
+						"--device-node=/dev/nvidia-uvm",
+						"--device-node=/dev/nvidia-uvm-tools",
+						"--timeout=15s",
+					},
+					Env: []string{"NVIDIA_CTK_DEBUG=false"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := &nvcdilib{
+				deviceNodeWait: tc.wait,
+				hookCreator:    discover.NewHookCreator(),
+			}
+			d := l.newWaitForDeviceNodesHookDiscoverer()
+			if tc.expectedHooks == nil {
+				require.Nil(t, d)
+				return
+			}
+			hooks, err := d.Hooks()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedHooks, hooks)
+		})
+	}
+}
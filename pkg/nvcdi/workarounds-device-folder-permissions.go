@@ -18,7 +18,9 @@ package nvcdi
 
 import (
 	"fmt"
+	"io/fs"
 	"path/filepath"
+	"strconv"
 
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/discover"
 	"github.com/NVIDIA/nvidia-container-toolkit/internal/logger"
@@ -33,6 +35,24 @@ type deviceFolderPermissions struct {
 
 var _ discover.Discover = (*deviceFolderPermissions)(nil)
 
+// WithDeviceFolderMode sets the file mode that the device folder permission
+// hook applies to the parent folders of nested device nodes. Setting a mode
+// enables the (otherwise disabled) chmod hook.
+func WithDeviceFolderMode(mode fs.FileMode) Option {
+	return func(o *options) {
+		o.deviceFolderMode = strconv.FormatUint(uint64(mode), 8)
+	}
+}
+
+// WithDeviceFolderGroup sets the group ID that the device folder permission
+// hook assigns to the parent folders of nested device nodes. Setting a group
+// enables the (otherwise disabled) chmod hook.
+func WithDeviceFolderGroup(gid uint32) Option {
+	return func(o *options) {
+		o.deviceFolderGroup = strconv.FormatUint(uint64(gid), 10)
+	}
+}
+
 // newDeviceFolderPermissionHookDiscoverer creates a discoverer that can be used to update the permissions for the parent folders of nested device nodes from the specified set of device specs.
 // This works around an issue with rootless podman when using crun as a low-level runtime.
 // See https://github.com/containers/crun/issues/1047
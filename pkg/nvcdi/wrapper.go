@@ -68,7 +68,21 @@ func (l *wrapper) GetSpec(devices ...string) (spec.Interface, error) {
 		spec.WithEdits(*edits.ContainerEdits),
 		spec.WithVendor(l.vendor),
 		spec.WithClass(l.class),
-		spec.WithMergedDeviceOptions(l.mergedDeviceOptions...),
+		spec.WithMergedDeviceOptions(l.getMergedDeviceOptions()...),
+	)
+}
+
+// getMergedDeviceOptions returns the options for the merged device. Devices
+// that are not allocatable are excluded from the merged device unless
+// otherwise specified in the configured options. If no options are
+// configured, no merged device is added.
+func (l *wrapper) getMergedDeviceOptions() []transform.MergedDeviceOption {
+	if len(l.mergedDeviceOptions) == 0 {
+		return nil
+	}
+	return append(
+		[]transform.MergedDeviceOption{transform.WithExcludedDevices(IsNonAllocatable)},
+		l.mergedDeviceOptions...,
 	)
 }
 